@@ -5,27 +5,44 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DBHost            string
-	DBPort            string
-	DBUser            string
-	DBPassword        string
-	DBName            string
-	DBSSLMode         string
-	DBTimezone        string
-	DBLogLevel        string
-	JWTSecret         string
-	Port              string
-	Environment       string
-	TLSCertFile       string
-	TLSKeyFile        string
-	AllowedOrigins    string
-	RateLimitDuration time.Duration
+	DBHost                   string
+	DBPort                   string
+	DBUser                   string
+	DBPassword               string
+	DBName                   string
+	DBSSLMode                string
+	DBTimezone               string
+	DBLogLevel               string
+	JWTSecret                string
+	Port                     string
+	Environment              string
+	TLSCertFile              string
+	TLSKeyFile               string
+	AllowedOrigins           string
+	RateLimitDuration        time.Duration
+	ClickFlushInterval       time.Duration
+	UrlBlocklistFile         string
+	UrlAllowedSchemes        string
+	UrlSafetyRecheckInterval time.Duration
+	PublicBaseUrl            string
+	ShortCodeStrategy        string
+	ShortCodeAlphabet        string
+	ShortCodeLength          int
+	ShortCodeSalt            string
+	LinkAccessTTL            time.Duration
+	LinkUnavailableStatus    int
+	LinkUnavailableUrl       string
+	GeoIPCountryFile         string
+	LinkCacheLocalSize       int
+	LinkCacheLocalTTL        time.Duration
+	MetricsAddr              string
 }
 
 func LoadConfig() *Config {
@@ -37,23 +54,46 @@ func LoadConfig() *Config {
 		log.Printf("Warning: Could not load .env file from %s: %v", envPath, err)
 	}
 	rateLimitDuration, _ := time.ParseDuration(getEnvWithDefault("RATE_LIMIT_DURATION", "1m"))
+	clickFlushInterval, _ := time.ParseDuration(getEnvWithDefault("CLICK_FLUSH_INTERVAL", "1m"))
+	urlSafetyRecheckInterval, _ := time.ParseDuration(getEnvWithDefault("URL_SAFETY_RECHECK_INTERVAL", "24h"))
+	shortCodeLength, _ := strconv.Atoi(getEnvWithDefault("SHORT_CODE_LENGTH", "8"))
+	linkAccessTTL, _ := time.ParseDuration(getEnvWithDefault("LINK_ACCESS_TTL", "30m"))
+	linkUnavailableStatus, _ := strconv.Atoi(getEnvWithDefault("LINK_UNAVAILABLE_STATUS", "403"))
+	linkCacheLocalSize, _ := strconv.Atoi(getEnvWithDefault("LINK_CACHE_LOCAL_SIZE", "0"))
+	linkCacheLocalTTL, _ := time.ParseDuration(getEnvWithDefault("LINK_CACHE_LOCAL_TTL", "10s"))
 
 	config := &Config{
-		DBHost:            getRequiredEnv("DB_HOST"),
-		DBPort:            getEnvWithDefault("DB_PORT", "5432"),
-		DBUser:            getRequiredEnv("DB_USER"),
-		DBPassword:        getRequiredEnv("DB_PASSWORD"),
-		DBName:            getRequiredEnv("DB_NAME"),
-		DBSSLMode:         getEnvWithDefault("DB_SSLMODE", "disable"),
-		DBTimezone:        getEnvWithDefault("DB_TIMEZONE", "UTC"),
-		DBLogLevel:        getEnvWithDefault("DB_LOG_LEVEL", "warn"),
-		JWTSecret:         getRequiredEnv("JWT_SECRET"),
-		Port:              getEnvWithDefault("PORT", "8080"),
-		Environment:       getEnvWithDefault("ENV", "development"),
-		TLSCertFile:       getEnvWithDefault("TLS_CERT_FILE", "cert.pem"),
-		TLSKeyFile:        getEnvWithDefault("TLS_KEY_FILE", "key.pem"),
-		AllowedOrigins:    getEnvWithDefault("ALLOWED_ORIGINS", "https://localhost:3000"),
-		RateLimitDuration: rateLimitDuration,
+		DBHost:                   getRequiredEnv("DB_HOST"),
+		DBPort:                   getEnvWithDefault("DB_PORT", "5432"),
+		DBUser:                   getRequiredEnv("DB_USER"),
+		DBPassword:               getRequiredEnv("DB_PASSWORD"),
+		DBName:                   getRequiredEnv("DB_NAME"),
+		DBSSLMode:                getEnvWithDefault("DB_SSLMODE", "disable"),
+		DBTimezone:               getEnvWithDefault("DB_TIMEZONE", "UTC"),
+		DBLogLevel:               getEnvWithDefault("DB_LOG_LEVEL", "warn"),
+		JWTSecret:                getRequiredEnv("JWT_SECRET"),
+		Port:                     getEnvWithDefault("PORT", "8080"),
+		Environment:              getEnvWithDefault("ENV", "development"),
+		TLSCertFile:              getEnvWithDefault("TLS_CERT_FILE", "cert.pem"),
+		TLSKeyFile:               getEnvWithDefault("TLS_KEY_FILE", "key.pem"),
+		AllowedOrigins:           getEnvWithDefault("ALLOWED_ORIGINS", "https://localhost:3000"),
+		RateLimitDuration:        rateLimitDuration,
+		ClickFlushInterval:       clickFlushInterval,
+		UrlBlocklistFile:         getEnvWithDefault("URL_BLOCKLIST_FILE", ""),
+		UrlAllowedSchemes:        getEnvWithDefault("URL_ALLOWED_SCHEMES", "http,https"),
+		UrlSafetyRecheckInterval: urlSafetyRecheckInterval,
+		PublicBaseUrl:            getEnvWithDefault("PUBLIC_BASE_URL", "http://localhost:8080"),
+		ShortCodeStrategy:        getEnvWithDefault("SHORT_CODE_STRATEGY", "random"),
+		ShortCodeAlphabet:        getEnvWithDefault("SHORT_CODE_ALPHABET", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"),
+		ShortCodeLength:          shortCodeLength,
+		ShortCodeSalt:            getEnvWithDefault("SHORT_CODE_SALT", ""),
+		LinkAccessTTL:            linkAccessTTL,
+		LinkUnavailableStatus:    linkUnavailableStatus,
+		LinkUnavailableUrl:       getEnvWithDefault("LINK_UNAVAILABLE_URL", ""),
+		GeoIPCountryFile:         getEnvWithDefault("GEO_IP_COUNTRY_FILE", ""),
+		LinkCacheLocalSize:       linkCacheLocalSize,
+		LinkCacheLocalTTL:        linkCacheLocalTTL,
+		MetricsAddr:              getEnvWithDefault("METRICS_ADDR", "127.0.0.1:9091"),
 	}
 
 	log.Println("Configuration loaded successfully")
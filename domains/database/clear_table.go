@@ -12,9 +12,15 @@ import (
 var ClearModels = []interface{}{
 	&entities.Inventory{},
 	&entities.Distributor{},
+	&entities.ClickFlushBatch{},
+	&entities.ClickEvent{},
 	&entities.UrlSafety{},
+	&entities.UtmTemplate{},
+	&entities.ShortUrlDestination{},
 	&entities.ShortClickDaily{},
 	&entities.ShortUrl{},
+	&entities.CustomDomain{},
+	&entities.InstitutionSetting{},
 	&entities.UserSession{},
 	&entities.User{},
 }
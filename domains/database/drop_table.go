@@ -12,9 +12,15 @@ import (
 var DropModels = []interface{}{
 	&entities.Inventory{},
 	&entities.Distributor{},
+	&entities.ClickFlushBatch{},
+	&entities.ClickEvent{},
 	&entities.UrlSafety{},
+	&entities.UtmTemplate{},
+	&entities.ShortUrlDestination{},
 	&entities.ShortClickDaily{},
 	&entities.ShortUrl{},
+	&entities.CustomDomain{},
+	&entities.InstitutionSetting{},
 	&entities.UserSession{},
 	&entities.User{},
 }
@@ -48,6 +54,14 @@ func DropTables(ctx context.Context, dbConfig dto.DBConfig) error {
 		log.Printf("Successfully dropped table for: %s", modelName)
 	}
 
+	for _, sequence := range MigrateSequences {
+		if err := db.Exec(fmt.Sprintf("DROP SEQUENCE IF EXISTS %s", sequence)).Error; err != nil {
+			return fmt.Errorf("failed to drop sequence %s: %w", sequence, err)
+		}
+
+		log.Printf("Successfully dropped sequence: %s", sequence)
+	}
+
 	log.Println("Database table drop completed successfully!")
 	return nil
 }
\ No newline at end of file
@@ -7,14 +7,30 @@ import (
 
 	"short-url/domains/dto"
 	"short-url/domains/entities"
+	"short-url/domains/helper"
+
+	"gorm.io/gorm"
 )
 
+// ShortCodeSequence backs the sequence and obfuscated short code strategies.
+const ShortCodeSequence = "short_code_seq"
+
+var MigrateSequences = []string{
+	ShortCodeSequence,
+}
+
 var MigrateModels = []interface{}{
 	&entities.User{},
 	&entities.UserSession{},
+	&entities.InstitutionSetting{},
+	&entities.CustomDomain{},
 	&entities.ShortUrl{},
+	&entities.ShortUrlDestination{},
+	&entities.UtmTemplate{},
 	&entities.ShortClickDaily{},
 	&entities.UrlSafety{},
+	&entities.ClickFlushBatch{},
+	&entities.ClickEvent{},
 	&entities.Distributor{},
 	&entities.Inventory{},
 }
@@ -48,6 +64,91 @@ func Migrate(ctx context.Context, dbConfig dto.DBConfig) error {
 		log.Printf("Successfully migrated: %s", modelName)
 	}
 
+	for _, sequence := range MigrateSequences {
+		if err := db.Exec(fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s", sequence)).Error; err != nil {
+			return fmt.Errorf("failed to create sequence %s: %w", sequence, err)
+		}
+
+		log.Printf("Successfully created sequence: %s", sequence)
+	}
+
+	if err := backfillLongUrlHashes(db); err != nil {
+		return fmt.Errorf("failed to backfill long url hashes: %w", err)
+	}
+
+	if err := dropLegacyShortCodeIndex(db); err != nil {
+		return fmt.Errorf("failed to drop legacy short code index: %w", err)
+	}
+
+	if err := dropLegacyCustomDomainHostIndex(db); err != nil {
+		return fmt.Errorf("failed to drop legacy custom domain host index: %w", err)
+	}
+
 	log.Println("Database migration completed successfully!")
 	return nil
 }
+
+// dropLegacyShortCodeIndex removes the unique indexes on short_code alone and
+// on (user_id, short_code). Codes are unique per domain since custom domains,
+// which idx_short_urls_domain_code enforces, so one user may reuse a code on
+// each of their domains.
+func dropLegacyShortCodeIndex(db *gorm.DB) error {
+	for _, legacyIndex := range []string{"idx_short_urls_short_code", "idx_short_urls_user_code"} {
+		if !db.Migrator().HasIndex(&entities.ShortUrl{}, legacyIndex) {
+			continue
+		}
+		if err := db.Migrator().DropIndex(&entities.ShortUrl{}, legacyIndex); err != nil {
+			return err
+		}
+		log.Printf("Dropped legacy index %s", legacyIndex)
+	}
+	return nil
+}
+
+// dropLegacyCustomDomainHostIndex removes the unique index on host alone, which
+// let an unverified claim block a host for everyone else, and the one on
+// (institution_id, host), which did the same among users without an
+// institution. Hosts are unique among verified domains, which
+// idx_custom_domains_verified_host enforces.
+func dropLegacyCustomDomainHostIndex(db *gorm.DB) error {
+	for _, legacyIndex := range []string{"idx_custom_domains_host", "idx_custom_domains_institution_host"} {
+		if !db.Migrator().HasIndex(&entities.CustomDomain{}, legacyIndex) {
+			continue
+		}
+		if err := db.Migrator().DropIndex(&entities.CustomDomain{}, legacyIndex); err != nil {
+			return err
+		}
+		log.Printf("Dropped legacy index %s", legacyIndex)
+	}
+	return nil
+}
+
+// backfillLongUrlHashes fills long_url_hash for links created before the
+// column existed so they take part in dedupe. Only empty rows are touched, so
+// it is cheap once done.
+func backfillLongUrlHashes(db *gorm.DB) error {
+	var shortUrls []entities.ShortUrl
+	var updated int
+	err := db.Unscoped().Select("id", "long_url").
+		Where("long_url_hash IS NULL OR long_url_hash = ''").
+		FindInBatches(&shortUrls, 500, func(_ *gorm.DB, _ int) error {
+			for _, shortUrl := range shortUrls {
+				err := db.Unscoped().Model(&entities.ShortUrl{}).
+					Where("id = ?", shortUrl.ID).
+					UpdateColumn("long_url_hash", helper.LongUrlHash(shortUrl.LongUrl)).Error
+				if err != nil {
+					return err
+				}
+			}
+			updated += len(shortUrls)
+			return nil
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	if updated > 0 {
+		log.Printf("Backfilled long url hashes for %d short urls", updated)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package dto
+
+// AvailabilityWindow restricts a link to recurring hours, e.g. days
+// ["mon","tue","wed","thu","fri"] from "09:00" to "17:00" in "Europe/Berlin".
+// An end that is not after the start runs past midnight. No days means every
+// day and no timezone means UTC.
+type AvailabilityWindow struct {
+	Days     []string `json:"days,omitempty"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Timezone string   `json:"timezone,omitempty"`
+}
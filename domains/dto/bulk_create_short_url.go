@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type BulkCreateShortUrlResult struct {
+	Index     int        `json:"index"`
+	ShortCode string     `json:"short_code,omitempty"`
+	LongUrl   string     `json:"long_url"`
+	ExpireAt  *time.Time `json:"expire_at,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+type BulkCreateShortUrlResponse struct {
+	Created int                        `json:"created"`
+	Failed  int                        `json:"failed"`
+	Results []BulkCreateShortUrlResult `json:"results"`
+}
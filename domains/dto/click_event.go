@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+const (
+	ClickDimensionReferrer    = "referrer"
+	ClickDimensionBrowser     = "browser"
+	ClickDimensionLanguage    = "language"
+	ClickDimensionDestination = "destination"
+)
+
+// ClickEventInput carries the raw request details of a redirect; it is parsed
+// into an entities.ClickEvent off the request path.
+type ClickEventInput struct {
+	ShortUrlID     uint
+	ClickedAt      time.Time
+	Referrer       string
+	UserAgent      string
+	AcceptLanguage string
+	IP             string
+	// DestinationID is the split destination that was served, if any.
+	DestinationID uint
+}
+
+type ClickBreakdownQuery struct {
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Timezone string `json:"tz,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+}
+
+type ClickBreakdownItem struct {
+	Value  string `json:"value"`
+	Clicks int64  `json:"clicks"`
+	// Url is the destination URL when Value is a split destination ID.
+	Url string `json:"url,omitempty"`
+}
+
+type ClickBreakdownResponse struct {
+	ShortCode   string               `json:"short_code"`
+	Dimension   string               `json:"dimension"`
+	Timezone    string               `json:"timezone"`
+	From        string               `json:"from"`
+	To          string               `json:"to"`
+	TotalClicks int64                `json:"total_clicks"`
+	Items       []ClickBreakdownItem `json:"items"`
+}
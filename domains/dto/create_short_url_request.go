@@ -1,5 +1,54 @@
 package dto
 
+import "time"
+
+const (
+	DedupeScopeUser        = "user"
+	DedupeScopeInstitution = "institution"
+)
+
 type CreateShortUrlRequest struct {
-	LongUrl string `json:"long_url" validate:"required,url"`
-}
\ No newline at end of file
+	LongUrl     string     `json:"long_url" validate:"required,url"`
+	Alias       string     `json:"alias,omitempty" validate:"omitempty,min=3,max=10"`
+	ExpireAt    *time.Time `json:"expire_at,omitempty"`
+	TTL         int64      `json:"ttl,omitempty" validate:"omitempty,min=1"`
+	FallbackUrl string     `json:"fallback_url,omitempty" validate:"omitempty,url"`
+	// ActiveFrom and Availability keep the link from redirecting before
+	// launch and outside its recurring hours.
+	ActiveFrom   *time.Time          `json:"active_from,omitempty"`
+	Availability *AvailabilityWindow `json:"availability,omitempty"`
+	// MaxClicks deactivates the link after that many redirects; 1 makes a
+	// one-time link.
+	MaxClicks int64 `json:"max_clicks,omitempty" validate:"omitempty,min=1"`
+	// Dedupe opts in to returning an existing active link for the same
+	// normalized URL: "user" matches the caller's links, "institution" any
+	// link of a user in the caller's institution.
+	Dedupe string `json:"dedupe,omitempty" validate:"omitempty,oneof=user institution"`
+	// Password protects the public redirect. It is stored as a bcrypt hash.
+	Password string `json:"password,omitempty" validate:"omitempty,min=4,max=72"`
+	// ForwardQuery merges the query string of a redirect into the
+	// destination; ForwardPath appends path segments after the short code.
+	ForwardQuery bool `json:"forward_query,omitempty"`
+	ForwardPath  bool `json:"forward_path,omitempty"`
+	// Utm is added to the destination on redirect. UtmTemplateID starts from
+	// a saved template; values set in Utm take precedence over it.
+	Utm           *UtmParams `json:"utm,omitempty"`
+	UtmTemplateID uint       `json:"utm_template_id,omitempty"`
+	// TargetingRules are tried in order on every redirect; the first match
+	// picks the destination and LongUrl is used when none matches.
+	TargetingRules []TargetingRule `json:"targeting_rules,omitempty"`
+	// Destinations split the remaining visitors by weight instead of sending
+	// them to LongUrl. StickyDestinations keeps a returning visitor on the
+	// destination they got first.
+	Destinations       []ShortUrlDestination `json:"destinations,omitempty"`
+	StickyDestinations bool                  `json:"sticky_destinations,omitempty"`
+	// Preview makes every public redirect show the interstitial page with the
+	// destination host before continuing.
+	Preview bool `json:"preview,omitempty"`
+	// DomainID serves the link on a verified custom domain of the caller's
+	// institution instead of the service's own host.
+	DomainID uint `json:"domain_id,omitempty"`
+	// RedirectType is the status code of the public redirect: 301, 302, 307
+	// or 308. It defaults to the institution's redirect type.
+	RedirectType int `json:"redirect_type,omitempty"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type CreateCustomDomainRequest struct {
+	Host string `json:"host" validate:"required,max=253"`
+}
+
+// CustomDomainVerification tells the owner where to publish Token: as the
+// value of a TXT record named TxtRecord, or as the body of WellKnownUrl.
+type CustomDomainVerification struct {
+	Token        string `json:"token"`
+	TxtRecord    string `json:"txt_record"`
+	WellKnownUrl string `json:"well_known_url"`
+}
+
+type CustomDomainResponse struct {
+	ID           uint                     `json:"id"`
+	Host         string                   `json:"host"`
+	Verified     bool                     `json:"verified"`
+	VerifiedAt   *time.Time               `json:"verified_at,omitempty"`
+	Verification CustomDomainVerification `json:"verification"`
+	CreatedAt    time.Time                `json:"created_at"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type DailyClickCount struct {
+	ShortUrlID uint      `json:"short_url_id"`
+	Date       time.Time `json:"date"`
+	Count      int64     `json:"count"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+// UpdateInstitutionSettingsRequest changes only the settings that are set.
+type UpdateInstitutionSettingsRequest struct {
+	RedirectType *int `json:"redirect_type,omitempty"`
+}
+
+type InstitutionSettingsResponse struct {
+	RedirectType int `json:"redirect_type"`
+}
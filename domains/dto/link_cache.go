@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// LinkCacheConfig sizes the in-process tier of the public redirect's link
+// cache. It is disabled when LocalSize or LocalTTL is not positive.
+type LinkCacheConfig struct {
+	LocalSize int
+	LocalTTL  time.Duration
+}
+
+// LinkCacheStats counts link lookups of the public redirect since the process
+// started. Local counters stay 0 while the in-process tier is disabled; only
+// lookups that missed it reach Redis.
+type LinkCacheStats struct {
+	LocalHits   int64 `json:"local_hits"`
+	LocalMisses int64 `json:"local_misses"`
+	LocalSize   int   `json:"local_size"`
+	RedisHits   int64 `json:"redis_hits"`
+	RedisMisses int64 `json:"redis_misses"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+const (
+	QrCodeFormatPNG = "png"
+	QrCodeFormatSVG = "svg"
+)
+
+// QrCodeOptions are the rendering parameters of a QR code. Zero values are
+// replaced by the service defaults.
+type QrCodeOptions struct {
+	Format          string `json:"format,omitempty"`
+	Size            int    `json:"size,omitempty"`
+	ErrorCorrection string `json:"ecl,omitempty"`
+	Margin          *int   `json:"margin,omitempty"`
+	Foreground      string `json:"fg,omitempty"`
+	Background      string `json:"bg,omitempty"`
+}
+
+type QrCodeImage struct {
+	ContentType string
+	Data        []byte
+}
@@ -0,0 +1,10 @@
+package dto
+
+// ShortUrlDestination is one variant of a link that splits its traffic.
+// Weights are relative: variants weighted 70 and 30 get 70% and 30% of the
+// visitors.
+type ShortUrlDestination struct {
+	ID     uint   `json:"id,omitempty"`
+	Url    string `json:"url"`
+	Weight int    `json:"weight"`
+}
@@ -2,8 +2,23 @@ package dto
 
 import "time"
 
+const (
+	ShortUrlSortByCreatedAt  = "created_at"
+	ShortUrlSortByClickCount = "click_count"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
 type ShortUrlQueryFilter struct {
-	UserID    *uint      `json:"user_id,omitempty"`
-	IsActive  *bool      `json:"is_active,omitempty"`
-	ExpiredAt *time.Time `json:"expired_at,omitempty"`
+	UserID          *uint      `json:"user_id,omitempty"`
+	IsActive        *bool      `json:"is_active,omitempty"`
+	ExpiredAt       *time.Time `json:"expired_at,omitempty"`
+	Expired         *bool      `json:"expired,omitempty"`
+	CreatedFrom     *time.Time `json:"created_from,omitempty"`
+	CreatedTo       *time.Time `json:"created_to,omitempty"`
+	LongUrlContains string     `json:"long_url_contains,omitempty"`
+	UtmCampaign     string     `json:"utm_campaign,omitempty"`
+	SortBy          string     `json:"sort_by,omitempty"`
+	SortOrder       string     `json:"sort_order,omitempty"`
 }
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+type ShortUrlResponse struct {
+	ID                 uint                  `json:"id"`
+	ShortCode          string                `json:"short_code"`
+	LongUrl            string                `json:"long_url"`
+	UserID             uint                  `json:"user_id"`
+	DomainID           uint                  `json:"domain_id,omitempty"`
+	Domain             string                `json:"domain,omitempty"`
+	IsActive           bool                  `json:"is_active"`
+	ExpireAt           *time.Time            `json:"expire_at,omitempty"`
+	FallbackUrl        *string               `json:"fallback_url,omitempty"`
+	ActiveFrom         *time.Time            `json:"active_from,omitempty"`
+	Availability       *AvailabilityWindow   `json:"availability,omitempty"`
+	PasswordProtected  bool                  `json:"password_protected"`
+	MaxClicks          *int64                `json:"max_clicks,omitempty"`
+	RemainingClicks    *int64                `json:"remaining_clicks,omitempty"`
+	ForwardQuery       bool                  `json:"forward_query"`
+	ForwardPath        bool                  `json:"forward_path"`
+	Utm                *UtmParams            `json:"utm,omitempty"`
+	TargetingRules     []TargetingRule       `json:"targeting_rules,omitempty"`
+	Destinations       []ShortUrlDestination `json:"destinations,omitempty"`
+	StickyDestinations bool                  `json:"sticky_destinations"`
+	Preview            bool                  `json:"preview"`
+	RedirectType       int                   `json:"redirect_type"`
+	Safety             *UrlSafetyVerdict     `json:"safety,omitempty"`
+	ClickCount         int64                 `json:"click_count"`
+	CreatedAt          time.Time             `json:"created_at"`
+	UpdatedAt          time.Time             `json:"updated_at"`
+}
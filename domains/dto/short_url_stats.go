@@ -0,0 +1,30 @@
+package dto
+
+type ShortUrlStatsQuery struct {
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+	TrendDays int    `json:"trend_days,omitempty"`
+	Timezone  string `json:"tz,omitempty"`
+}
+
+type DailyClickPoint struct {
+	Date   string `json:"date"`
+	Clicks int64  `json:"clicks"`
+}
+
+type ClickTrend struct {
+	Days           int      `json:"days"`
+	Clicks         int64    `json:"clicks"`
+	PreviousClicks int64    `json:"previous_clicks"`
+	ChangePercent  *float64 `json:"change_percent"`
+}
+
+type ShortUrlStatsResponse struct {
+	ShortCode   string            `json:"short_code"`
+	TotalClicks int64             `json:"total_clicks"`
+	Timezone    string            `json:"timezone"`
+	From        string            `json:"from"`
+	To          string            `json:"to"`
+	Daily       []DailyClickPoint `json:"daily"`
+	Trend       ClickTrend        `json:"trend"`
+}
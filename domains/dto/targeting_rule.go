@@ -0,0 +1,30 @@
+package dto
+
+// TargetingRule sends visitors that match all of its conditions to
+// Destination. OS takes "ios", "android" and "desktop", Languages are
+// Accept-Language prefixes such as "de" or "pt-BR" and Countries are ISO
+// 3166-1 alpha-2 codes.
+type TargetingRule struct {
+	OS          []string `json:"os,omitempty"`
+	Languages   []string `json:"languages,omitempty"`
+	Countries   []string `json:"countries,omitempty"`
+	Destination string   `json:"destination"`
+}
+
+// RedirectVisitor is what the redirect knows about the visitor that targeting
+// rules are matched against.
+type RedirectVisitor struct {
+	UserAgent      string
+	AcceptLanguage string
+	IP             string
+	// DestinationID is the split destination the visitor was sent to before,
+	// if the link is sticky and the visitor kept its cookie.
+	DestinationID uint
+}
+
+// RedirectDestination is where one redirect goes. DestinationID names the
+// split destination that was picked and is 0 for LongUrl and targeting rules.
+type RedirectDestination struct {
+	Url           string
+	DestinationID uint
+}
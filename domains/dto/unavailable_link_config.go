@@ -0,0 +1,9 @@
+package dto
+
+// UnavailableLinkConfig is how the public redirect answers for a link outside
+// its active_from or availability window. With RedirectUrl set, browsers are
+// sent there; otherwise, and for JSON clients, Status is returned.
+type UnavailableLinkConfig struct {
+	Status      int
+	RedirectUrl string
+}
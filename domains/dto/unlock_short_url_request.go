@@ -0,0 +1,7 @@
+package dto
+
+// UnlockShortUrlRequest is posted by the password form of a protected link,
+// either form encoded or as JSON.
+type UnlockShortUrlRequest struct {
+	Password string `json:"password" form:"password"`
+}
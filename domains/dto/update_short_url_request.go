@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type UpdateShortUrlRequest struct {
+	LongUrl           *string             `json:"long_url,omitempty" validate:"omitempty,url"`
+	IsActive          *bool               `json:"is_active,omitempty"`
+	ExpireAt          *time.Time          `json:"expire_at,omitempty"`
+	TTL               int64               `json:"ttl,omitempty" validate:"omitempty,min=1"`
+	ClearExpiry       bool                `json:"clear_expiry,omitempty"`
+	FallbackUrl       *string             `json:"fallback_url,omitempty" validate:"omitempty,url"`
+	ActiveFrom        *time.Time          `json:"active_from,omitempty"`
+	ClearActiveFrom   bool                `json:"clear_active_from,omitempty"`
+	Availability      *AvailabilityWindow `json:"availability,omitempty"`
+	ClearAvailability bool                `json:"clear_availability,omitempty"`
+	// Password replaces the link's password; an empty string removes it.
+	Password *string `json:"password,omitempty" validate:"omitempty,max=72"`
+	// MaxClicks sets a new click limit with a full budget; 0 removes it.
+	MaxClicks    *int64 `json:"max_clicks,omitempty" validate:"omitempty,min=0"`
+	ForwardQuery *bool  `json:"forward_query,omitempty"`
+	ForwardPath  *bool  `json:"forward_path,omitempty"`
+	// Utm and UtmTemplateID replace all UTM values of the link, resolved as
+	// on creation; an empty utm object removes them.
+	Utm           *UtmParams `json:"utm,omitempty"`
+	UtmTemplateID uint       `json:"utm_template_id,omitempty"`
+	// TargetingRules replaces all rules of the link; an empty list removes
+	// them.
+	TargetingRules *[]TargetingRule `json:"targeting_rules,omitempty"`
+	// Destinations replaces the split destinations; an empty list removes
+	// them. Destinations whose URL is unchanged keep their ID, so sticky
+	// visitors and click statistics carry over.
+	Destinations       *[]ShortUrlDestination `json:"destinations,omitempty"`
+	StickyDestinations *bool                  `json:"sticky_destinations,omitempty"`
+	Preview            *bool                  `json:"preview,omitempty"`
+	RedirectType       *int                   `json:"redirect_type,omitempty"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type UrlSafetyVerdict struct {
+	Safe    bool   `json:"safe"`
+	Checker string `json:"checker,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
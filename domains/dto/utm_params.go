@@ -0,0 +1,11 @@
+package dto
+
+// UtmParams are sent to the destination as utm_source, utm_medium,
+// utm_campaign, utm_term and utm_content.
+type UtmParams struct {
+	Source   string `json:"source,omitempty" validate:"omitempty,max=255"`
+	Medium   string `json:"medium,omitempty" validate:"omitempty,max=255"`
+	Campaign string `json:"campaign,omitempty" validate:"omitempty,max=255"`
+	Term     string `json:"term,omitempty" validate:"omitempty,max=255"`
+	Content  string `json:"content,omitempty" validate:"omitempty,max=255"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type CreateUtmTemplateRequest struct {
+	Name string    `json:"name" validate:"required,max=100"`
+	Utm  UtmParams `json:"utm"`
+}
+
+// UpdateUtmTemplateRequest changes the name and, if Utm is set, replaces all
+// of the template's values.
+type UpdateUtmTemplateRequest struct {
+	Name *string    `json:"name,omitempty" validate:"omitempty,max=100"`
+	Utm  *UtmParams `json:"utm,omitempty"`
+}
+
+type UtmTemplateResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Utm       UtmParams `json:"utm"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Weekdays lists the day names an AvailabilityWindow accepts, in
+// time.Weekday order.
+var Weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// AvailabilityWindow limits a link to recurring hours, such as weekdays from
+// 09:00 to 17:00 in Europe/Berlin. Start and End are HH:MM wall clock times
+// and End may be 24:00. A window whose End is not after its Start runs past
+// midnight and belongs to the day it starts on. No Days means every day.
+type AvailabilityWindow struct {
+	Days     []string `json:"days,omitempty"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Timezone string   `json:"timezone"`
+}
+
+// Validate checks the window and normalizes its day names to lower case.
+func (w *AvailabilityWindow) Validate() error {
+	for i, day := range w.Days {
+		w.Days[i] = strings.ToLower(strings.TrimSpace(day))
+		if weekdayIndex(w.Days[i]) < 0 {
+			return fmt.Errorf("unknown day %q", day)
+		}
+	}
+
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return err
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return err
+	}
+	if start == end || start == minutesPerDay {
+		return errors.New("start and end must differ and start must be before 24:00")
+	}
+
+	if _, err := loadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("unknown time zone %q", w.Timezone)
+	}
+	return nil
+}
+
+// Contains reports whether t falls inside the window. An invalid window
+// contains nothing.
+func (w *AvailabilityWindow) Contains(t time.Time) bool {
+	start, end, location, err := w.parse()
+	if err != nil {
+		return false
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return w.allowsDay(local.Weekday()) && minute >= start && minute < end
+	}
+
+	// The window runs past midnight: the evening part belongs to today, the
+	// early morning part to yesterday.
+	if minute >= start {
+		return w.allowsDay(local.Weekday())
+	}
+	return minute < end && w.allowsDay((local.Weekday()+6)%7)
+}
+
+// NextStart returns the first time after t at which the window opens, or
+// nil if it never does.
+func (w *AvailabilityWindow) NextStart(t time.Time) *time.Time {
+	start, _, location, err := w.parse()
+	if err != nil {
+		return nil
+	}
+
+	local := t.In(location)
+	for day := 0; day <= 7; day++ {
+		date := local.AddDate(0, 0, day)
+		opens := time.Date(date.Year(), date.Month(), date.Day(), start/60, start%60, 0, 0, location)
+		if opens.After(t) && w.allowsDay(opens.Weekday()) {
+			return &opens
+		}
+	}
+	return nil
+}
+
+func (w *AvailabilityWindow) parse() (int, int, *time.Location, error) {
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	location, err := loadLocation(w.Timezone)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return start, end, location, nil
+}
+
+func (w *AvailabilityWindow) allowsDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, allowed := range w.Days {
+		if weekdayIndex(allowed) == int(day) {
+			return true
+		}
+	}
+	return false
+}
+
+const minutesPerDay = 24 * 60
+
+// parseClock turns HH:MM into minutes after midnight; 24:00 is the end of
+// the day.
+func parseClock(value string) (int, error) {
+	var hour, minute int
+	if len(value) != 5 || value[2] != ':' {
+		return 0, fmt.Errorf("time %q must be HH:MM", value)
+	}
+	if _, err := fmt.Sscanf(value, "%02d:%02d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("time %q must be HH:MM", value)
+	}
+	if hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("time %q is out of range", value)
+	}
+	return hour*60 + minute, nil
+}
+
+func weekdayIndex(day string) int {
+	for i, name := range Weekdays {
+		if name == day {
+			return i
+		}
+	}
+	return -1
+}
+
+// locations caches loaded time zones; time.LoadLocation reads the zone
+// database on every call.
+var locations sync.Map
+
+func loadLocation(name string) (*time.Location, error) {
+	if cached, ok := locations.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, location)
+	return location, nil
+}
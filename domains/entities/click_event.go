@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+)
+
+// ClickEvent is one redirect served by the public endpoints. The IP address is
+// stored anonymized and the user agent only in its parsed form.
+type ClickEvent struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	ShortUrlID     uint      `json:"short_url_id" gorm:"not null;index:idx_click_event_url_clicked_at"`
+	ClickedAt      time.Time `json:"clicked_at" gorm:"not null;index:idx_click_event_url_clicked_at"`
+	ReferrerHost   string    `json:"referrer_host" gorm:"type:varchar(255)"`
+	Browser        string    `json:"browser" gorm:"type:varchar(50)"`
+	OS             string    `json:"os" gorm:"type:varchar(50)"`
+	DeviceClass    string    `json:"device_class" gorm:"type:varchar(20)"`
+	AcceptLanguage string    `json:"accept_language" gorm:"type:varchar(255)"`
+	Language       string    `json:"language" gorm:"type:varchar(35)"`
+	IPAddress      string    `json:"ip_address" gorm:"type:varchar(45)"`
+	// DestinationID is the split destination the visitor was sent to; nil
+	// when the link does not split its traffic.
+	DestinationID *uint `json:"destination_id"`
+}
@@ -0,0 +1,15 @@
+package entities
+
+import (
+	"time"
+)
+
+// ClickFlushBatch marks a drained batch of Redis click counters as applied to
+// ShortClickDaily, so a batch replayed after a crash is not counted twice.
+// Markers are only needed until the batch is completed in Redis, so the
+// flusher deletes old ones.
+type ClickFlushBatch struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	BatchID   string    `json:"batch_id" gorm:"type:varchar(64);uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+}
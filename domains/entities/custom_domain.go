@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+const (
+	// CustomDomainTxtPrefix is prepended to the host to name the TXT record
+	// that holds the verification token.
+	CustomDomainTxtPrefix = "_short-url-verification."
+	// CustomDomainWellKnownPath is the file on the host that may hold the
+	// verification token instead.
+	CustomDomainWellKnownPath = "/.well-known/short-url-verification"
+)
+
+// CustomDomain is a branded host an institution serves its links from. Links
+// on it only resolve once the institution proved it controls the host by
+// publishing VerificationToken. Several institutions may claim a host, but
+// only one can verify it, so an unverified claim never blocks the owner.
+// Users without an institution (InstitutionID 0) each own their domains.
+// Rows are deleted for good, so a released host can be claimed again.
+type CustomDomain struct {
+	ID                uint       `json:"id" gorm:"primaryKey"`
+	InstitutionID     uint       `json:"institution_id" gorm:"not null;index;uniqueIndex:idx_custom_domains_institution_claim,where:institution_id <> 0"`
+	Host              string     `json:"host" gorm:"type:varchar(253);not null;uniqueIndex:idx_custom_domains_institution_claim;uniqueIndex:idx_custom_domains_user_claim;uniqueIndex:idx_custom_domains_verified_host,where:verified_at IS NOT NULL"`
+	VerificationToken string     `json:"-" gorm:"type:varchar(64);not null"`
+	VerifiedAt        *time.Time `json:"verified_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	CreatedBy         uint       `json:"created_by" gorm:"uniqueIndex:idx_custom_domains_user_claim,where:institution_id = 0"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func (d *CustomDomain) IsVerified() bool {
+	return d.VerifiedAt != nil
+}
+
+func (d *CustomDomain) VerificationTxtName() string {
+	return CustomDomainTxtPrefix + d.Host
+}
+
+func (d *CustomDomain) VerificationWellKnownUrl() string {
+	return "http://" + d.Host + CustomDomainWellKnownPath
+}
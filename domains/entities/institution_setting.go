@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// InstitutionSetting holds the defaults an institution applies to new links
+// of its users. Institutions without a row use the built-in defaults.
+type InstitutionSetting struct {
+	InstitutionID uint      `json:"institution_id" gorm:"primaryKey;autoIncrement:false"`
+	RedirectType  int       `json:"redirect_type" gorm:"not null;default:302"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	UpdatedBy     uint      `json:"updated_by"`
+}
+
+// EffectiveRedirectType is the redirect type new links get when the request
+// does not name one.
+func (s *InstitutionSetting) EffectiveRedirectType() int {
+	if s == nil || s.RedirectType == 0 {
+		return DefaultRedirectType
+	}
+	return s.RedirectType
+}
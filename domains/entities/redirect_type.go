@@ -0,0 +1,26 @@
+package entities
+
+// Redirect types are the HTTP status codes a link may redirect with.
+const (
+	RedirectTypeMovedPermanently  = 301
+	RedirectTypeFound             = 302
+	RedirectTypeTemporaryRedirect = 307
+	RedirectTypePermanentRedirect = 308
+
+	// DefaultRedirectType applies to institutions that never chose one.
+	DefaultRedirectType = RedirectTypeFound
+)
+
+func IsValidRedirectType(redirectType int) bool {
+	switch redirectType {
+	case RedirectTypeMovedPermanently, RedirectTypeFound, RedirectTypeTemporaryRedirect, RedirectTypePermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// IsPermanentRedirect reports whether browsers may cache the redirect
+// without being told to.
+func IsPermanentRedirect(redirectType int) bool {
+	return redirectType == RedirectTypeMovedPermanently || redirectType == RedirectTypePermanentRedirect
+}
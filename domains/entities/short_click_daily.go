@@ -8,8 +8,8 @@ import (
 
 type ShortClickDaily struct {
 	ID         uint           `json:"id" gorm:"primaryKey"`
-	ShortUrlID uint           `json:"short_url_id" gorm:"not null"`
-	Date       time.Time      `json:"date" gorm:"type:date;not null"`
+	ShortUrlID uint           `json:"short_url_id" gorm:"not null;uniqueIndex:idx_short_click_daily_url_date"`
+	Date       time.Time      `json:"date" gorm:"type:date;not null;uniqueIndex:idx_short_click_daily_url_date"`
 	NumRequest int            `json:"num_request" gorm:"default:0"`
 	CreatedAt  time.Time      `json:"created_at"`
 	CreatedBy  uint           `json:"created_by"`
@@ -7,18 +7,188 @@ import (
 )
 
 type ShortUrl struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	UserID    uint           `json:"user_id" gorm:"not null"`
-	LongUrl   string         `json:"long_url" gorm:"type:text;not null"`
-	ShortCode string         `json:"short_code" gorm:"type:varchar(10);uniqueIndex;not null"`
-	IsActive  bool           `json:"is_active" gorm:"default:true"`
-	ExpireAt  *time.Time     `json:"expire_at"`
-	CreatedAt time.Time      `json:"created_at"`
-	CreatedBy uint           `json:"created_by"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	UpdatedBy uint           `json:"updated_by"`
-	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID              uint                `json:"id" gorm:"primaryKey"`
+	UserID          uint                `json:"user_id" gorm:"not null"`
+	LongUrl         string              `json:"long_url" gorm:"type:text;not null"`
+	LongUrlHash     string              `json:"-" gorm:"type:varchar(64);index:idx_short_url_long_url_hash"`
+	ShortCode       string              `json:"short_code" gorm:"type:varchar(10);not null;uniqueIndex:idx_short_urls_domain_code,priority:2"`
+	DomainID        uint                `json:"domain_id" gorm:"not null;default:0;uniqueIndex:idx_short_urls_domain_code,priority:1"`
+	IsActive        bool                `json:"is_active" gorm:"default:true"`
+	ExpireAt        *time.Time          `json:"expire_at"`
+	ActiveFrom      *time.Time          `json:"active_from"`
+	Availability    *AvailabilityWindow `json:"availability" gorm:"type:text;serializer:json"`
+	FallbackUrl     *string             `json:"fallback_url" gorm:"type:text"`
+	PasswordHash    string              `json:"-" gorm:"type:varchar(255)"`
+	MaxClicks       *int64              `json:"max_clicks"`
+	RemainingClicks *int64              `json:"remaining_clicks"`
+	ForwardQuery    bool                `json:"forward_query" gorm:"not null;default:false"`
+	ForwardPath     bool                `json:"forward_path" gorm:"not null;default:false"`
+	Utm             UtmParams           `json:"utm" gorm:"embedded;embeddedPrefix:utm_"`
+	TargetingRules  []TargetingRule     `json:"targeting_rules" gorm:"type:text;serializer:json"`
+	CreatedAt       time.Time           `json:"created_at"`
+	CreatedBy       uint                `json:"created_by"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	UpdatedBy       uint                `json:"updated_by"`
+	DeletedAt       gorm.DeletedAt      `json:"deleted_at" gorm:"index"`
+
+	// StickyDestinations keeps a returning visitor on the split destination
+	// they were first sent to.
+	StickyDestinations bool `json:"sticky_destinations" gorm:"not null;default:false"`
+
+	// Preview shows the interstitial page on every public redirect, not only
+	// when the short code is followed by "+".
+	Preview bool `json:"preview" gorm:"not null;default:false"`
+
+	// RedirectType is the status code of the public redirect, one of 301,
+	// 302, 307 and 308.
+	RedirectType int `json:"redirect_type" gorm:"not null;default:302"`
+
+	// ClickCount is only populated by queries that select it explicitly.
+	ClickCount int64 `json:"click_count" gorm:"->;-:migration"`
 
 	User             User              `json:"user" gorm:"foreignKey:UserID"`
 	ShortClickDailys []ShortClickDaily `json:"short_click_dailys" gorm:"foreignKey:ShortUrlID"`
+	UrlSafety        *UrlSafety        `json:"url_safety,omitempty" gorm:"foreignKey:ShortUrlID"`
+	// Domain is the custom domain the link is served on; DomainID 0 is the
+	// service's own host and has no row.
+	Domain *CustomDomain `json:"domain,omitempty" gorm:"foreignKey:DomainID;constraint:-"`
+	// Destinations split the traffic that no targeting rule picks up. A link
+	// without them redirects to LongUrl.
+	Destinations []ShortUrlDestination `json:"destinations,omitempty" gorm:"foreignKey:ShortUrlID"`
+}
+
+func (s *ShortUrl) IsExpired(now time.Time) bool {
+	return s.ExpireAt != nil && !now.Before(*s.ExpireAt)
+}
+
+// IsAvailable reports whether the link may redirect at now: it has reached
+// ActiveFrom and now lies inside its availability window, if it has one.
+func (s *ShortUrl) IsAvailable(now time.Time) bool {
+	if s.ActiveFrom != nil && now.Before(*s.ActiveFrom) {
+		return false
+	}
+	return s.Availability == nil || s.Availability.Contains(now)
+}
+
+// NextAvailableAt returns when an unavailable link will next redirect, or nil
+// if that cannot be told.
+func (s *ShortUrl) NextAvailableAt(now time.Time) *time.Time {
+	from := now
+	if s.ActiveFrom != nil && s.ActiveFrom.After(now) {
+		from = *s.ActiveFrom
+		if s.Availability == nil || s.Availability.Contains(from) {
+			return &from
+		}
+	}
+	if s.Availability == nil {
+		return nil
+	}
+	return s.Availability.NextStart(from)
+}
+
+// TargetDestination returns the destination of the first targeting rule the
+// visitor matches and whether there was one.
+func (s *ShortUrl) TargetDestination(visitor Visitor) (string, bool) {
+	for _, rule := range s.TargetingRules {
+		if rule.Matches(visitor) {
+			return rule.Destination, true
+		}
+	}
+	return "", false
+}
+
+// HasCountryTargeting reports whether any rule needs the visitor's country,
+// so the lookup can be skipped otherwise.
+func (s *ShortUrl) HasCountryTargeting() bool {
+	for _, rule := range s.TargetingRules {
+		if len(rule.Countries) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// DestinationUrls lists every URL the link can redirect to, LongUrl first
+// and the FallbackUrl used after it ended last.
+func (s *ShortUrl) DestinationUrls() []string {
+	urls := []string{s.LongUrl}
+	for _, rule := range s.TargetingRules {
+		urls = append(urls, rule.Destination)
+	}
+	for _, destination := range s.Destinations {
+		urls = append(urls, destination.Url)
+	}
+	if s.FallbackUrl != nil {
+		urls = append(urls, *s.FallbackUrl)
+	}
+	return urls
+}
+
+func (s *ShortUrl) HasSplitDestinations() bool {
+	return len(s.Destinations) > 0
+}
+
+// FindDestination returns the split destination with the given ID, or nil.
+func (s *ShortUrl) FindDestination(id uint) *ShortUrlDestination {
+	for i := range s.Destinations {
+		if s.Destinations[i].ID == id {
+			return &s.Destinations[i]
+		}
+	}
+	return nil
+}
+
+func (s *ShortUrl) TotalDestinationWeight() int {
+	total := 0
+	for _, destination := range s.Destinations {
+		total += destination.Weight
+	}
+	return total
+}
+
+// PickDestination returns the split destination that roll, a number in
+// [0, TotalDestinationWeight()), falls on when the weights are laid out one
+// after another. It returns nil for rolls outside that range.
+func (s *ShortUrl) PickDestination(roll int) *ShortUrlDestination {
+	if roll < 0 {
+		return nil
+	}
+	for i := range s.Destinations {
+		roll -= s.Destinations[i].Weight
+		if roll < 0 {
+			return &s.Destinations[i]
+		}
+	}
+	return nil
+}
+
+func (s *ShortUrl) HasClickLimit() bool {
+	return s.MaxClicks != nil
+}
+
+// IsClickLimitReached reports whether a click-limited link has used up its
+// redirects. It reflects the row as loaded; ConsumeClick is the atomic check.
+func (s *ShortUrl) IsClickLimitReached() bool {
+	return s.RemainingClicks != nil && *s.RemainingClicks <= 0
+}
+
+// IsPasswordProtected reports whether the public redirect needs a password.
+// PasswordHash holds its bcrypt hash.
+func (s *ShortUrl) IsPasswordProtected() bool {
+	return s.PasswordHash != ""
+}
+
+// RedirectStatus is the status code to redirect with. Links that were built
+// without a redirect type use DefaultRedirectType.
+func (s *ShortUrl) RedirectStatus() int {
+	if s.RedirectType == 0 {
+		return DefaultRedirectType
+	}
+	return s.RedirectType
+}
+
+// IsFlaggedUnsafe reports whether the last safety scan flagged the link. Links
+// that were never scanned, or whose UrlSafety was not loaded, are not flagged.
+func (s *ShortUrl) IsFlaggedUnsafe() bool {
+	return s.UrlSafety != nil && !s.UrlSafety.IsSafe
 }
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShortUrlDestination is one variant of a link that splits its traffic, such
+// as in an A/B test. A visitor is sent to a variant with the probability of
+// its Weight over the sum of the weights of all variants of the link.
+type ShortUrlDestination struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	ShortUrlID uint           `json:"short_url_id" gorm:"not null;index"`
+	Url        string         `json:"url" gorm:"type:text;not null"`
+	Weight     int            `json:"weight" gorm:"not null"`
+	Position   int            `json:"position" gorm:"not null;default:0"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
@@ -0,0 +1,68 @@
+package entities
+
+import "strings"
+
+const (
+	TargetOSIOS     = "ios"
+	TargetOSAndroid = "android"
+	TargetOSDesktop = "desktop"
+)
+
+// TargetingRule sends matching visitors to Destination instead of the link's
+// LongUrl. Every condition that is set must match; within a condition any
+// listed value does. Languages are prefixes of the visitor's preferred
+// Accept-Language tag, so "de" matches "de-AT" while "pt-BR" only matches
+// itself. Countries are ISO 3166-1 alpha-2 codes.
+type TargetingRule struct {
+	OS          []string `json:"os,omitempty"`
+	Languages   []string `json:"languages,omitempty"`
+	Countries   []string `json:"countries,omitempty"`
+	Destination string   `json:"destination"`
+}
+
+// Visitor is what targeting rules are evaluated against. Empty fields are
+// unknown and match no condition on them.
+type Visitor struct {
+	OS       string
+	Language string
+	Country  string
+}
+
+func (r TargetingRule) Matches(visitor Visitor) bool {
+	if len(r.OS) > 0 && !containsFold(r.OS, visitor.OS) {
+		return false
+	}
+	if len(r.Languages) > 0 && !matchesLanguage(r.Languages, visitor.Language) {
+		return false
+	}
+	if len(r.Countries) > 0 && !containsFold(r.Countries, visitor.Country) {
+		return false
+	}
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesLanguage(prefixes []string, language string) bool {
+	if language == "" {
+		return false
+	}
+	language = strings.ToLower(language)
+	for _, prefix := range prefixes {
+		prefix = strings.ToLower(prefix)
+		if language == prefix || strings.HasPrefix(language, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
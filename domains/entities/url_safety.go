@@ -10,7 +10,8 @@ type UrlSafety struct {
 	ID         uint           `json:"id" gorm:"primaryKey"`
 	ShortUrlID uint           `json:"short_url_id" gorm:"not null;uniqueIndex"`
 	IsSafe     bool           `json:"is_safe" gorm:"default:false"`
+	Checker    string         `json:"checker" gorm:"type:varchar(50)"`
+	Reason     string         `json:"reason" gorm:"type:varchar(255)"`
 	CheckedAt  time.Time      `json:"checked_at"`
 	DeletedAt  gorm.DeletedAt `json:"deleted_at" gorm:"index"`
-
-}
\ No newline at end of file
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"net/url"
+	"strings"
+)
+
+// UtmParams are the campaign parameters a redirect adds to the destination as
+// utm_source, utm_medium, utm_campaign, utm_term and utm_content. Empty values
+// are left out.
+type UtmParams struct {
+	Source   string `json:"source,omitempty" gorm:"type:varchar(255);not null;default:''"`
+	Medium   string `json:"medium,omitempty" gorm:"type:varchar(255);not null;default:''"`
+	Campaign string `json:"campaign,omitempty" gorm:"type:varchar(255);not null;default:''"`
+	Term     string `json:"term,omitempty" gorm:"type:varchar(255);not null;default:''"`
+	Content  string `json:"content,omitempty" gorm:"type:varchar(255);not null;default:''"`
+}
+
+func (u UtmParams) IsEmpty() bool {
+	return u == UtmParams{}
+}
+
+// Encode returns the parameters as a query string, always in the order
+// source, medium, campaign, term, content.
+func (u UtmParams) Encode() string {
+	var params []string
+	for _, param := range []struct{ key, value string }{
+		{"utm_source", u.Source},
+		{"utm_medium", u.Medium},
+		{"utm_campaign", u.Campaign},
+		{"utm_term", u.Term},
+		{"utm_content", u.Content},
+	} {
+		if param.value != "" {
+			params = append(params, param.key+"="+url.QueryEscape(param.value))
+		}
+	}
+	return strings.Join(params, "&")
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UtmTemplate is a named set of UTM parameters a user can apply to links.
+// Applying a template copies its values, so later edits do not change links
+// that already use it.
+type UtmTemplate struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	UserID    uint           `json:"user_id" gorm:"not null;index"`
+	Name      string         `json:"name" gorm:"type:varchar(100);not null"`
+	Utm       UtmParams      `json:"utm" gorm:"embedded;embeddedPrefix:utm_"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
@@ -0,0 +1,39 @@
+package helper
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+const maxHostnameLength = 253
+
+var hostnameLabelRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// NormalizeHost lowercases a Host header value and drops its port and any
+// trailing dot, so it can be compared with stored hostnames.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return strings.TrimSuffix(host, ".")
+}
+
+// IsValidHostname reports whether host is a normalized DNS name of at least
+// two labels. IP addresses are not hostnames.
+func IsValidHostname(host string) bool {
+	if host == "" || len(host) > maxHostnameLength || net.ParseIP(host) != nil {
+		return false
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if !hostnameLabelRegex.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package jwt
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// LinkAccessClaims grant a visitor access to one password protected link.
+// PasswordFingerprint ties the token to the password it was issued for, so
+// changing the password revokes every token handed out before.
+type LinkAccessClaims struct {
+	ShortCode           string `json:"short_code"`
+	PasswordFingerprint string `json:"pwd"`
+	jwt.RegisteredClaims
+}
+
+func GenerateLinkAccessToken(shortCode, passwordFingerprint, secretKey string, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl)
+
+	claims := LinkAccessClaims{
+		ShortCode:           shortCode,
+		PasswordFingerprint: passwordFingerprint,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := token.SignedString([]byte(secretKey))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signedToken, expiresAt, nil
+}
+
+func ValidateLinkAccessToken(tokenString, secretKey string) (*LinkAccessClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &LinkAccessClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*LinkAccessClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("invalid token")
+}
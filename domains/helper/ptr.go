@@ -14,6 +14,10 @@ func IntPtr(i int) *int {
 	return &i
 }
 
+func Int64Ptr(i int64) *int64 {
+	return &i
+}
+
 func InventoryCategoryPtr(ic enums.InventoryCategory) *enums.InventoryCategory {
 	return &ic
 }
\ No newline at end of file
@@ -0,0 +1,584 @@
+// Package qrcode encodes text as a QR Code (ISO/IEC 18004, model 2) using
+// byte mode. It only produces the module matrix; see render.go for images.
+package qrcode
+
+import (
+	"errors"
+	"strings"
+)
+
+type ErrorCorrectionLevel int
+
+const (
+	Low ErrorCorrectionLevel = iota
+	Medium
+	Quartile
+	High
+)
+
+const (
+	minVersion = 1
+	maxVersion = 40
+
+	penaltyN1 = 3
+	penaltyN2 = 3
+	penaltyN3 = 40
+	penaltyN4 = 10
+)
+
+var (
+	ErrInvalidErrorCorrectionLevel = errors.New("error correction level must be L, M, Q or H")
+	ErrContentTooLong              = errors.New("content is too long to fit in a QR code")
+)
+
+// formatBits are the two bits each level contributes to the format
+// information, which is not the same order as the levels themselves.
+var formatBits = [4]int{Low: 1, Medium: 0, Quartile: 3, High: 2}
+
+// eccCodewordsPerBlock and numErrorCorrectionBlocks are indexed by level and
+// then version; index 0 is unused.
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var numErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// ParseErrorCorrectionLevel accepts the single letter names L, M, Q and H in
+// either case.
+func ParseErrorCorrectionLevel(value string) (ErrorCorrectionLevel, error) {
+	switch strings.ToUpper(value) {
+	case "L":
+		return Low, nil
+	case "M":
+		return Medium, nil
+	case "Q":
+		return Quartile, nil
+	case "H":
+		return High, nil
+	}
+	return Low, ErrInvalidErrorCorrectionLevel
+}
+
+func (l ErrorCorrectionLevel) String() string {
+	return [4]string{"L", "M", "Q", "H"}[l]
+}
+
+// Code is an encoded symbol. Modules are addressed as (x, y) with the origin
+// in the top-left corner and do not include the quiet zone.
+type Code struct {
+	Version int
+	Size    int
+	Level   ErrorCorrectionLevel
+	Mask    int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Encode picks the smallest version that fits content at the given level and
+// the mask with the lowest penalty score.
+func Encode(content string, level ErrorCorrectionLevel) (*Code, error) {
+	return encode(content, level, -1)
+}
+
+// encode is Encode with a fixed mask, or the best one if mask is -1.
+func encode(content string, level ErrorCorrectionLevel, mask int) (*Code, error) {
+	if level < Low || level > High {
+		return nil, ErrInvalidErrorCorrectionLevel
+	}
+
+	data := []byte(content)
+	version := minVersion
+	for ; version <= maxVersion; version++ {
+		if segmentBits(version, len(data)) <= numDataCodewords(version, level)*8 {
+			break
+		}
+	}
+	if version > maxVersion {
+		return nil, ErrContentTooLong
+	}
+
+	codewords := encodeData(data, version, level)
+
+	size := version*4 + 17
+	code := &Code{
+		Version:    version,
+		Size:       size,
+		Level:      level,
+		modules:    newGrid(size),
+		isFunction: newGrid(size),
+	}
+	code.drawFunctionPatterns()
+	code.drawCodewords(addEccAndInterleave(codewords, version, level))
+
+	if mask < 0 {
+		mask = code.bestMask()
+	}
+	code.Mask = mask
+	code.applyMask(mask)
+	code.drawFormatBits(mask)
+
+	return code, nil
+}
+
+// bestMask returns the mask with the lowest penalty score. The modules are
+// left unmasked.
+func (c *Code) bestMask() int {
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		penalty := c.penaltyScore()
+		if bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask)
+	}
+	return bestMask
+}
+
+// Dark reports whether the module at (x, y) is dark. Coordinates outside the
+// symbol are light, which makes the quiet zone free for renderers.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+// segmentBits is the length of a single byte mode segment: the mode
+// indicator, the character count and the data itself.
+func segmentBits(version, length int) int {
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	if length >= 1<<countBits {
+		return 1 << 30
+	}
+	return 4 + countBits + length*8
+}
+
+// numRawDataModules is the number of modules left for data and error
+// correction once every function pattern is drawn.
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+func numDataCodewords(version int, level ErrorCorrectionLevel) int {
+	return numRawDataModules(version)/8 - eccCodewordsPerBlock[level][version]*numErrorCorrectionBlocks[level][version]
+}
+
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+	positions := make([]int, numAlign)
+	positions[0] = 6
+	for i, pos := numAlign-1, version*4+17-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+	return positions
+}
+
+// encodeData builds the data codewords: the byte segment, a terminator and
+// the alternating pad bytes up to the capacity of the version.
+func encodeData(data []byte, version int, level ErrorCorrectionLevel) []byte {
+	capacityBits := numDataCodewords(version, level) * 8
+
+	var bits bitBuffer
+	bits.append(0x4, 4)
+	if version >= 10 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	bits.append(0, min(4, capacityBits-bits.len()))
+	bits.append(0, (8-bits.len()%8)%8)
+	for pad := 0xEC; bits.len() < capacityBits; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	return bits.bytes()
+}
+
+// addEccAndInterleave splits the data into blocks, appends the Reed-Solomon
+// codewords of each one and interleaves the result column by column.
+func addEccAndInterleave(data []byte, version int, level ErrorCorrectionLevel) []byte {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	blockEccLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(blockEccLen)
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		dataLen := shortBlockLen - blockEccLen
+		if i >= numShortBlocks {
+			dataLen++
+		}
+		block := make([]byte, shortBlockLen+1)
+		copy(block, data[k:k+dataLen])
+		copy(block[shortBlockLen+1-blockEccLen:], reedSolomonRemainder(data[k:k+dataLen], divisor))
+		k += dataLen
+		blocks[i] = block
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i <= shortBlockLen; i++ {
+		for j, block := range blocks {
+			// Short blocks carry one data codeword less, so that column is padding.
+			if i != shortBlockLen-blockEccLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+func (c *Code) setFunctionModule(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.Size; i++ {
+		c.setFunctionModule(6, i, i%2 == 0)
+		c.setFunctionModule(i, 6, i%2 == 0)
+	}
+
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(c.Size-4, 3)
+	c.drawFinderPattern(3, c.Size-4)
+
+	positions := alignmentPatternPositions(c.Version)
+	last := len(positions) - 1
+	for i, y := range positions {
+		for j, x := range positions {
+			// The three corners are already taken by the finder patterns.
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignmentPattern(x, y)
+		}
+	}
+
+	// Reserve the format areas now; the real bits depend on the mask.
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+func (c *Code) drawFinderPattern(centerX, centerY int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := centerX+dx, centerY+dy
+			if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunctionModule(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignmentPattern(centerX, centerY int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunctionModule(centerX+dx, centerY+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits writes both copies of the level and mask, protected by a
+// BCH(15,5) code, plus the always dark module next to the bottom-left finder.
+func (c *Code) drawFormatBits(mask int) {
+	data := formatBits[c.Level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		c.setFunctionModule(8, i, bit(bits, i))
+	}
+	c.setFunctionModule(8, 7, bit(bits, 6))
+	c.setFunctionModule(8, 8, bit(bits, 7))
+	c.setFunctionModule(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunctionModule(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunctionModule(c.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunctionModule(8, c.Size-15+i, bit(bits, i))
+	}
+	c.setFunctionModule(8, c.Size-8, true)
+}
+
+// drawVersion writes the two version blocks carried by version 7 and up,
+// protected by a BCH(18,6) code.
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	rem := c.Version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.Version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := bit(bits, i)
+		a := c.Size - 11 + i%3
+		b := i / 3
+		c.setFunctionModule(a, b, dark)
+		c.setFunctionModule(b, a, dark)
+	}
+}
+
+// drawCodewords places the bits in the zigzag order of the standard: pairs of
+// columns from the right, alternating upwards and downwards, skipping the
+// vertical timing pattern.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	totalBits := len(codewords) * 8
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < c.Size; vert++ {
+			y := vert
+			if upward {
+				y = c.Size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if c.isFunction[y][x] || i >= totalBits {
+					continue
+				}
+				c.modules[y][x] = bit(int(codewords[i>>3]), 7-(i&7))
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules selected by the mask pattern. Applying the
+// same mask twice restores the original modules.
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penaltyScore implements the four evaluation rules used to pick a mask:
+// long runs, 2x2 blocks, finder-like patterns and dark/light imbalance.
+func (c *Code) penaltyScore() int {
+	result := 0
+
+	for y := 0; y < c.Size; y++ {
+		result += linePenalty(func(i int) bool { return c.modules[y][i] }, c.Size)
+	}
+	for x := 0; x < c.Size; x++ {
+		result += linePenalty(func(i int) bool { return c.modules[i][x] }, c.Size)
+	}
+
+	for y := 0; y < c.Size-1; y++ {
+		for x := 0; x < c.Size-1; x++ {
+			color := c.modules[y][x]
+			if color == c.modules[y][x+1] && color == c.modules[y+1][x] && color == c.modules[y+1][x+1] {
+				result += penaltyN2
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	total := c.Size * c.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	result += k * penaltyN4
+
+	return result
+}
+
+var finderLikePatterns = [2][11]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// linePenalty scores one row or column for rules 1 and 3.
+func linePenalty(module func(int) bool, size int) int {
+	result := 0
+
+	runLength := 1
+	for i := 1; i <= size; i++ {
+		if i < size && module(i) == module(i-1) {
+			runLength++
+			continue
+		}
+		if runLength >= 5 {
+			result += penaltyN1 + runLength - 5
+		}
+		runLength = 1
+	}
+
+	for i := 0; i+len(finderLikePatterns[0]) <= size; i++ {
+		for _, pattern := range finderLikePatterns {
+			matched := true
+			for j, dark := range pattern {
+				if module(i+j) != dark {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				result += penaltyN3
+			}
+		}
+	}
+
+	return result
+}
+
+// reedSolomonDivisor returns the generator polynomial of the given degree,
+// highest coefficient first with the leading 1 omitted.
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coefficient := range divisor {
+			result[i] ^= gfMultiply(coefficient, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+type bitBuffer struct {
+	bits []bool
+}
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		b.bits = append(b.bits, (value>>i)&1 != 0)
+	}
+}
+
+func (b *bitBuffer) len() int {
+	return len(b.bits)
+}
+
+func (b *bitBuffer) bytes() []byte {
+	result := make([]byte, (len(b.bits)+7)/8)
+	for i, set := range b.bits {
+		if set {
+			result[i>>3] |= 1 << (7 - (i & 7))
+		}
+	}
+	return result
+}
+
+func bit(value, index int) bool {
+	return (value>>index)&1 != 0
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
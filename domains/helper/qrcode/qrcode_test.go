@@ -0,0 +1,117 @@
+package qrcode
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type matrixCase struct {
+	file    string
+	content string
+	level   ErrorCorrectionLevel
+	version int
+	mask    int
+}
+
+// The matrices in testdata come from a separate reference encoder written
+// from the standard, one row per line with # for dark modules.
+func TestEncode_KnownMatrices(t *testing.T) {
+	cases := []matrixCase{
+		// Version 7 has a version block and six blocks of two lengths;
+		// version 10 has a 16 bit character count and eight blocks.
+		{"v7-q-mask1.txt", "https://example.com/campaign/spring-sale?utm_source=newsletter&utm_medium=email&id=7", Quartile, 7, 1},
+		{"v10-h-mask1.txt", "https://example.com/a/very/long/path/to/a/landing/page?utm_source=newsletter&utm_medium=email&utm_campaign=spring", High, 10, 1},
+	}
+	for mask := 0; mask < 8; mask++ {
+		cases = append(cases, matrixCase{fmt.Sprintf("hello-world-1q-mask%d.txt", mask), "HELLO WORLD", Quartile, 1, mask})
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.file, func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join("testdata", tc.file))
+			require.NoError(t, err)
+
+			code, err := encode(tc.content, tc.level, tc.mask)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.version, code.Version)
+			assert.Equal(t, string(want), matrixString(code))
+		})
+	}
+}
+
+func TestEncode_PicksLowestPenaltyMask(t *testing.T) {
+	code, err := Encode("https://example.com/a/very/long/path/to/a/landing/page?utm_source=newsletter&utm_medium=email&utm_campaign=spring", High)
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, code.Version)
+	assert.Equal(t, 1, code.Mask)
+}
+
+func TestEncode_ContentTooLong(t *testing.T) {
+	_, err := Encode(strings.Repeat("a", 2954), Low)
+
+	assert.ErrorIs(t, err, ErrContentTooLong)
+}
+
+// The example of annex I of the standard: "01234567" at version 1-M.
+func TestReedSolomonRemainder_KnownAnswer(t *testing.T) {
+	data := []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11}
+
+	ecc := reedSolomonRemainder(data, reedSolomonDivisor(10))
+
+	assert.Equal(t, []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}, ecc)
+}
+
+// Version 5-Q has two blocks of 15 data codewords followed by two of 16, each
+// with 18 error correction codewords.
+func TestAddEccAndInterleave_MixedBlockLengths(t *testing.T) {
+	data := make([]byte, 62)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	blocks := [][]byte{data[0:15], data[15:30], data[30:46], data[46:62]}
+
+	result := addEccAndInterleave(data, 5, Quartile)
+
+	var want []byte
+	for i := 0; i < 16; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				want = append(want, block[i])
+			}
+		}
+	}
+	divisor := reedSolomonDivisor(18)
+	var eccs [][]byte
+	for _, block := range blocks {
+		eccs = append(eccs, reedSolomonRemainder(block, divisor))
+	}
+	for i := 0; i < 18; i++ {
+		for _, ecc := range eccs {
+			want = append(want, ecc[i])
+		}
+	}
+	assert.Equal(t, want, result)
+}
+
+func matrixString(code *Code) string {
+	var b strings.Builder
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if code.Dark(x, y) {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// RenderOptions control how a Code is drawn. Size is the requested width and
+// height in pixels and Margin the quiet zone in modules.
+type RenderOptions struct {
+	Size       int
+	Margin     int
+	Foreground color.NRGBA
+	Background color.NRGBA
+}
+
+// PNG draws the code with whole-pixel modules so edges stay sharp when
+// printed. The symbol is centred in a Size x Size image; if Size is too small
+// for one pixel per module the image grows to fit instead.
+func (c *Code) PNG(opts RenderOptions) ([]byte, error) {
+	units := c.Size + opts.Margin*2
+	scale := max(1, opts.Size/units)
+	imageSize := max(opts.Size, units)
+	offset := (imageSize-units*scale)/2 + opts.Margin*scale
+
+	palette := color.Palette{opts.Background, opts.Foreground}
+	img := image.NewPaletted(image.Rect(0, 0, imageSize, imageSize), palette)
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.modules[y][x] {
+				continue
+			}
+			for py := 0; py < scale; py++ {
+				row := img.Pix[(offset+y*scale+py)*img.Stride:]
+				for px := 0; px < scale; px++ {
+					row[offset+x*scale+px] = 1
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG draws the code in module units and lets the viewer scale it, which is
+// what print workflows want. Horizontal runs of dark modules are merged into
+// a single path to keep the document small.
+func (c *Code) SVG(opts RenderOptions) []byte {
+	units := c.Size + opts.Margin*2
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		opts.Size, opts.Size, units, units)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"%s/>`+"\n", svgColor(opts.Background), svgOpacity(opts.Background))
+	fmt.Fprintf(&buf, `<path fill="%s"%s d="`, svgColor(opts.Foreground), svgOpacity(opts.Foreground))
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; {
+			if !c.modules[y][x] {
+				x++
+				continue
+			}
+			run := 1
+			for x+run < c.Size && c.modules[y][x+run] {
+				run++
+			}
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", x+opts.Margin, y+opts.Margin, run, run)
+			x += run
+		}
+	}
+	buf.WriteString(`"/>` + "\n</svg>\n")
+	return buf.Bytes()
+}
+
+func svgColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgOpacity(c color.NRGBA) string {
+	if c.A == 0xff {
+		return ""
+	}
+	return fmt.Sprintf(` fill-opacity="%.3f"`, float64(c.A)/0xff)
+}
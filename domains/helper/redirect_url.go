@@ -0,0 +1,136 @@
+package helper
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+var ErrInvalidForwardedPath = errors.New("forwarded path may not contain . or .. segments")
+
+// ForwardPath appends extraPath, the still escaped remainder of a request
+// path, below the path of destination. Dot segments are refused so a visitor
+// cannot climb out of the destination path.
+func ForwardPath(destination, extraPath string) (string, error) {
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return "", err
+	}
+	extraPath = strings.TrimLeft(extraPath, "/")
+	if extraPath == "" {
+		return destination, nil
+	}
+
+	unescaped, err := url.PathUnescape(extraPath)
+	if err != nil {
+		return "", err
+	}
+	for _, segment := range strings.Split(unescaped, "/") {
+		if segment == "." || segment == ".." {
+			return "", ErrInvalidForwardedPath
+		}
+	}
+
+	escapedBase := strings.TrimSuffix(parsed.EscapedPath(), "/")
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/" + unescaped
+	parsed.RawPath = escapedBase + "/" + extraPath
+	return parsed.String(), nil
+}
+
+// ForwardQuery merges rawQuery, the query string of a request, into the query
+// of destination. Parameters keep their order: those of the destination come
+// first and an incoming parameter with the same name replaces them in place,
+// the others follow in the order they arrived. Parameters of the destination
+// that are not replaced are kept exactly as written.
+func ForwardQuery(destination, rawQuery string) (string, error) {
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return "", err
+	}
+	incoming, err := parseQueryPairs(rawQuery)
+	if err != nil {
+		return "", err
+	}
+	if len(incoming) == 0 {
+		return destination, nil
+	}
+
+	incomingByKey := make(map[string][]queryPair, len(incoming))
+	for _, pair := range incoming {
+		incomingByKey[pair.key] = append(incomingByKey[pair.key], pair)
+	}
+
+	var merged []string
+	replaced := make(map[string]bool, len(incomingByKey))
+	for _, raw := range splitQuery(parsed.RawQuery) {
+		key, _, _ := strings.Cut(raw, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		pairs, ok := incomingByKey[key]
+		if !ok {
+			merged = append(merged, raw)
+			continue
+		}
+		if !replaced[key] {
+			replaced[key] = true
+			for _, pair := range pairs {
+				merged = append(merged, pair.encode())
+			}
+		}
+	}
+	for _, pair := range incoming {
+		if !replaced[pair.key] {
+			merged = append(merged, pair.encode())
+		}
+	}
+
+	parsed.RawQuery = strings.Join(merged, "&")
+	parsed.ForceQuery = false
+	return parsed.String(), nil
+}
+
+type queryPair struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+func (p queryPair) encode() string {
+	if !p.hasValue {
+		return url.QueryEscape(p.key)
+	}
+	return url.QueryEscape(p.key) + "=" + url.QueryEscape(p.value)
+}
+
+// parseQueryPairs decodes a query string without losing the order of its
+// parameters, which url.ParseQuery does.
+func parseQueryPairs(rawQuery string) ([]queryPair, error) {
+	var pairs []queryPair
+	for _, raw := range splitQuery(rawQuery) {
+		rawKey, rawValue, hasValue := strings.Cut(raw, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return nil, err
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			continue
+		}
+		pairs = append(pairs, queryPair{key: key, value: value, hasValue: hasValue})
+	}
+	return pairs, nil
+}
+
+func splitQuery(rawQuery string) []string {
+	var parts []string
+	for _, part := range strings.Split(rawQuery, "&") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AnonymizeIP drops the host part of an address: IPv4 keeps its /24 and IPv6
+// its /48. Unparseable input returns an empty string.
+func AnonymizeIP(ip string) string {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return ""
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
+
+// ReferrerHost returns the lower-cased host of a Referer header, or an empty
+// string for direct traffic and malformed values.
+func ReferrerHost(referrer string) string {
+	if referrer == "" {
+		return ""
+	}
+	parsed, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// PrimaryLanguage picks the most preferred tag from an Accept-Language header
+// and normalises it to the "en-US" form.
+func PrimaryLanguage(acceptLanguage string) string {
+	type weightedTag struct {
+		tag    string
+		weight float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		tags = append(tags, weightedTag{tag: tag, weight: weight})
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].weight > tags[j].weight })
+	return normalizeLanguageTag(tags[0].tag)
+}
+
+func normalizeLanguageTag(tag string) string {
+	subtags := strings.Split(strings.ReplaceAll(tag, "_", "-"), "-")
+	subtags[0] = strings.ToLower(subtags[0])
+	for i := 1; i < len(subtags); i++ {
+		if len(subtags[i]) == 2 {
+			subtags[i] = strings.ToUpper(subtags[i])
+		} else {
+			subtags[i] = strings.ToLower(subtags[i])
+		}
+	}
+
+	normalized := strings.Join(subtags, "-")
+	if len(normalized) > 35 {
+		normalized = normalized[:35]
+	}
+	return normalized
+}
@@ -0,0 +1,39 @@
+package helper
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	MinAliasLength = 3
+	MaxAliasLength = 10
+)
+
+var aliasRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ReservedAliases are path segments routed by the services themselves and
+// can never be claimed as a short code.
+var ReservedAliases = []string{
+	"api",
+	"health",
+	"url",
+	"admin",
+	"static",
+}
+
+func IsValidAlias(alias string) bool {
+	if len(alias) < MinAliasLength || len(alias) > MaxAliasLength {
+		return false
+	}
+	return aliasRegex.MatchString(alias)
+}
+
+func IsReservedAlias(alias string) bool {
+	for _, reserved := range ReservedAliases {
+		if strings.EqualFold(alias, reserved) {
+			return true
+		}
+	}
+	return false
+}
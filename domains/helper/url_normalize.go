@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+// NormalizeUrl reduces a URL to a canonical form for duplicate detection:
+// the scheme and host are lower-cased, default ports and the fragment are
+// dropped, an empty path becomes "/" and query parameters are sorted by key.
+// The path and the parameter values are kept as they are, since servers may
+// treat them case-sensitively. Input that does not parse is only trimmed.
+func NormalizeUrl(rawUrl string) string {
+	trimmed := strings.TrimSpace(rawUrl)
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return trimmed
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	host := strings.ToLower(parsed.Hostname())
+	port := parsed.Port()
+	if (parsed.Scheme == "http" && port == "80") || (parsed.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	parsed.Host = host
+
+	if parsed.Path == "" {
+		parsed.Path = "/"
+		parsed.RawPath = ""
+	}
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+	if query, err := url.ParseQuery(parsed.RawQuery); err == nil {
+		parsed.RawQuery = query.Encode()
+	}
+	parsed.ForceQuery = false
+
+	return parsed.String()
+}
+
+// LongUrlHash is the hex SHA-256 of the normalized URL, short enough to index
+// where the URL itself is not.
+func LongUrlHash(rawUrl string) string {
+	sum := sha256.Sum256([]byte(NormalizeUrl(rawUrl)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,94 @@
+package helper
+
+import "strings"
+
+const (
+	DeviceClassDesktop = "desktop"
+	DeviceClassMobile  = "mobile"
+	DeviceClassTablet  = "tablet"
+	DeviceClassBot     = "bot"
+	DeviceClassUnknown = "unknown"
+)
+
+type UserAgentInfo struct {
+	Browser     string
+	OS          string
+	DeviceClass string
+}
+
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "curl/", "wget/", "python-requests", "go-http-client", "facebookexternalhit"}
+
+// ParseUserAgent classifies a User-Agent header into coarse browser, OS and
+// device buckets. It only recognises the common families; anything else is
+// reported as "Other".
+func ParseUserAgent(userAgent string) UserAgentInfo {
+	ua := strings.ToLower(userAgent)
+	info := UserAgentInfo{
+		Browser:     parseBrowser(ua),
+		OS:          parseOS(ua),
+		DeviceClass: DeviceClassUnknown,
+	}
+
+	switch {
+	case ua == "":
+	case containsAny(ua, botMarkers...):
+		info.DeviceClass = DeviceClassBot
+	case containsAny(ua, "ipad", "tablet") || (strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		info.DeviceClass = DeviceClassTablet
+	case containsAny(ua, "mobi", "iphone", "ipod", "android"):
+		info.DeviceClass = DeviceClassMobile
+	case info.OS != "Other":
+		info.DeviceClass = DeviceClassDesktop
+	}
+
+	return info
+}
+
+func parseBrowser(ua string) string {
+	switch {
+	case strings.Contains(ua, "edg/"), strings.Contains(ua, "edge/"):
+		return "Edge"
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		return "Opera"
+	case strings.Contains(ua, "samsungbrowser/"):
+		return "Samsung Internet"
+	case strings.Contains(ua, "chrome/"), strings.Contains(ua, "crios/"):
+		return "Chrome"
+	case strings.Contains(ua, "firefox/"), strings.Contains(ua, "fxios/"):
+		return "Firefox"
+	case strings.Contains(ua, "safari/"):
+		return "Safari"
+	case strings.Contains(ua, "msie "), strings.Contains(ua, "trident/"):
+		return "Internet Explorer"
+	default:
+		return "Other"
+	}
+}
+
+func parseOS(ua string) string {
+	switch {
+	case strings.Contains(ua, "windows"):
+		return "Windows"
+	case containsAny(ua, "iphone", "ipad", "ipod"):
+		return "iOS"
+	case strings.Contains(ua, "android"):
+		return "Android"
+	case strings.Contains(ua, "cros"):
+		return "ChromeOS"
+	case strings.Contains(ua, "mac os x"), strings.Contains(ua, "macintosh"):
+		return "macOS"
+	case strings.Contains(ua, "linux"):
+		return "Linux"
+	default:
+		return "Other"
+	}
+}
+
+func containsAny(s string, substrings ...string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"short-url/domains/dto"
+)
+
+type ClickCounterRepositoryInterface interface {
+	Increment(ctx context.Context, shortUrlID uint, at time.Time) error
+	Drain(ctx context.Context, batchID string) (bool, error)
+	PendingBatches(ctx context.Context) ([]string, error)
+	BatchCounts(ctx context.Context, batchID string) ([]dto.DailyClickCount, error)
+	CompleteBatch(ctx context.Context, batchID string) error
+}
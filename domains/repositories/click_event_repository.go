@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+)
+
+type ClickEventCommandRepositoryInterface interface {
+	SaveBatch(ctx context.Context, events []entities.ClickEvent) error
+}
+
+type ClickEventQueryRepositoryInterface interface {
+	CountByShortUrlID(ctx context.Context, shortUrlID uint, from, to time.Time) (int64, error)
+	TopValues(ctx context.Context, shortUrlID uint, dimension string, from, to time.Time, limit int) ([]dto.ClickBreakdownItem, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"short-url/domains/entities"
+)
+
+type CustomDomainCommandRepositoryInterface interface {
+	Save(ctx context.Context, domain *entities.CustomDomain) error
+	Update(ctx context.Context, domain *entities.CustomDomain) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// CustomDomainQueryRepositoryInterface scopes lookups by user to the domains
+// of the user's institution.
+type CustomDomainQueryRepositoryInterface interface {
+	FindByIDAndUserID(ctx context.Context, id uint, userID uint) (*entities.CustomDomain, error)
+	FindByUserID(ctx context.Context, userID uint) ([]entities.CustomDomain, error)
+	FindVerifiedByHost(ctx context.Context, host string) (*entities.CustomDomain, error)
+	FindInstitutionIDByUserID(ctx context.Context, userID uint) (uint, error)
+	CountShortUrls(ctx context.Context, domainID uint) (int64, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"short-url/domains/entities"
+)
+
+type InstitutionSettingCommandRepositoryInterface interface {
+	Save(ctx context.Context, setting *entities.InstitutionSetting) error
+}
+
+type InstitutionSettingQueryRepositoryInterface interface {
+	// FindByUserID returns the settings of the user's institution. An
+	// institution that never saved any comes back with only InstitutionID set.
+	FindByUserID(ctx context.Context, userID uint) (*entities.InstitutionSetting, error)
+}
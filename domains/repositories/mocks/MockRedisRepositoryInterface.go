@@ -70,6 +70,54 @@ func (_c *MockRedisRepositoryInterface_Delete_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// DeleteIfEqual provides a mock function with given fields: ctx, key, value
+func (_m *MockRedisRepositoryInterface) DeleteIfEqual(ctx context.Context, key string, value string) error {
+	ret := _m.Called(ctx, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIfEqual")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRedisRepositoryInterface_DeleteIfEqual_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIfEqual'
+type MockRedisRepositoryInterface_DeleteIfEqual_Call struct {
+	*mock.Call
+}
+
+// DeleteIfEqual is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value string
+func (_e *MockRedisRepositoryInterface_Expecter) DeleteIfEqual(ctx interface{}, key interface{}, value interface{}) *MockRedisRepositoryInterface_DeleteIfEqual_Call {
+	return &MockRedisRepositoryInterface_DeleteIfEqual_Call{Call: _e.mock.On("DeleteIfEqual", ctx, key, value)}
+}
+
+func (_c *MockRedisRepositoryInterface_DeleteIfEqual_Call) Run(run func(ctx context.Context, key string, value string)) *MockRedisRepositoryInterface_DeleteIfEqual_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_DeleteIfEqual_Call) Return(_a0 error) *MockRedisRepositoryInterface_DeleteIfEqual_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_DeleteIfEqual_Call) RunAndReturn(run func(context.Context, string, string) error) *MockRedisRepositoryInterface_DeleteIfEqual_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Exists provides a mock function with given fields: ctx, key
 func (_m *MockRedisRepositoryInterface) Exists(ctx context.Context, key string) (bool, error) {
 	ret := _m.Called(ctx, key)
@@ -346,6 +394,54 @@ func (_c *MockRedisRepositoryInterface_Increment_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// Publish provides a mock function with given fields: ctx, channel, message
+func (_m *MockRedisRepositoryInterface) Publish(ctx context.Context, channel string, message interface{}) error {
+	ret := _m.Called(ctx, channel, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, channel, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRedisRepositoryInterface_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockRedisRepositoryInterface_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - message interface{}
+func (_e *MockRedisRepositoryInterface_Expecter) Publish(ctx interface{}, channel interface{}, message interface{}) *MockRedisRepositoryInterface_Publish_Call {
+	return &MockRedisRepositoryInterface_Publish_Call{Call: _e.mock.On("Publish", ctx, channel, message)}
+}
+
+func (_c *MockRedisRepositoryInterface_Publish_Call) Run(run func(ctx context.Context, channel string, message interface{})) *MockRedisRepositoryInterface_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_Publish_Call) Return(_a0 error) *MockRedisRepositoryInterface_Publish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_Publish_Call) RunAndReturn(run func(context.Context, string, interface{}) error) *MockRedisRepositoryInterface_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Set provides a mock function with given fields: ctx, key, value, expiration
 func (_m *MockRedisRepositoryInterface) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	ret := _m.Called(ctx, key, value, expiration)
@@ -395,6 +491,173 @@ func (_c *MockRedisRepositoryInterface_Set_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// SetIfEqual provides a mock function with given fields: ctx, key, expected, value, expiration
+func (_m *MockRedisRepositoryInterface) SetIfEqual(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, expected, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIfEqual")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, expected, value, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, expected, value, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, expected, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisRepositoryInterface_SetIfEqual_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIfEqual'
+type MockRedisRepositoryInterface_SetIfEqual_Call struct {
+	*mock.Call
+}
+
+// SetIfEqual is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - expected string
+//   - value interface{}
+//   - expiration time.Duration
+func (_e *MockRedisRepositoryInterface_Expecter) SetIfEqual(ctx interface{}, key interface{}, expected interface{}, value interface{}, expiration interface{}) *MockRedisRepositoryInterface_SetIfEqual_Call {
+	return &MockRedisRepositoryInterface_SetIfEqual_Call{Call: _e.mock.On("SetIfEqual", ctx, key, expected, value, expiration)}
+}
+
+func (_c *MockRedisRepositoryInterface_SetIfEqual_Call) Run(run func(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration)) *MockRedisRepositoryInterface_SetIfEqual_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(interface{}), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_SetIfEqual_Call) Return(_a0 bool, _a1 error) *MockRedisRepositoryInterface_SetIfEqual_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_SetIfEqual_Call) RunAndReturn(run func(context.Context, string, string, interface{}, time.Duration) (bool, error)) *MockRedisRepositoryInterface_SetIfEqual_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNX provides a mock function with given fields: ctx, key, value, expiration
+func (_m *MockRedisRepositoryInterface) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNX")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRedisRepositoryInterface_SetNX_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNX'
+type MockRedisRepositoryInterface_SetNX_Call struct {
+	*mock.Call
+}
+
+// SetNX is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - expiration time.Duration
+func (_e *MockRedisRepositoryInterface_Expecter) SetNX(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *MockRedisRepositoryInterface_SetNX_Call {
+	return &MockRedisRepositoryInterface_SetNX_Call{Call: _e.mock.On("SetNX", ctx, key, value, expiration)}
+}
+
+func (_c *MockRedisRepositoryInterface_SetNX_Call) Run(run func(ctx context.Context, key string, value interface{}, expiration time.Duration)) *MockRedisRepositoryInterface_SetNX_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_SetNX_Call) Return(_a0 bool, _a1 error) *MockRedisRepositoryInterface_SetNX_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_SetNX_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockRedisRepositoryInterface_SetNX_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: ctx, channel, handle
+func (_m *MockRedisRepositoryInterface) Subscribe(ctx context.Context, channel string, handle func(message string)) error {
+	ret := _m.Called(ctx, channel, handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(message string)) error); ok {
+		r0 = rf(ctx, channel, handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRedisRepositoryInterface_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockRedisRepositoryInterface_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - handle func(message string)
+func (_e *MockRedisRepositoryInterface_Expecter) Subscribe(ctx interface{}, channel interface{}, handle interface{}) *MockRedisRepositoryInterface_Subscribe_Call {
+	return &MockRedisRepositoryInterface_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, channel, handle)}
+}
+
+func (_c *MockRedisRepositoryInterface_Subscribe_Call) Run(run func(ctx context.Context, channel string, handle func(message string))) *MockRedisRepositoryInterface_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(func(message string)))
+	})
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_Subscribe_Call) Return(_a0 error) *MockRedisRepositoryInterface_Subscribe_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRedisRepositoryInterface_Subscribe_Call) RunAndReturn(run func(context.Context, string, func(message string)) error) *MockRedisRepositoryInterface_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TTL provides a mock function with given fields: ctx, key
 func (_m *MockRedisRepositoryInterface) TTL(ctx context.Context, key string) (time.Duration, error) {
 	ret := _m.Called(ctx, key)
@@ -22,6 +22,218 @@ func (_m *MockShortUrlCommandRepositoryInterface) EXPECT() *MockShortUrlCommandR
 	return &MockShortUrlCommandRepositoryInterface_Expecter{mock: &_m.Mock}
 }
 
+// ConsumeClick provides a mock function with given fields: ctx, id
+func (_m *MockShortUrlCommandRepositoryInterface) ConsumeClick(ctx context.Context, id uint) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeClick")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlCommandRepositoryInterface_ConsumeClick_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeClick'
+type MockShortUrlCommandRepositoryInterface_ConsumeClick_Call struct {
+	*mock.Call
+}
+
+// ConsumeClick is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *MockShortUrlCommandRepositoryInterface_Expecter) ConsumeClick(ctx interface{}, id interface{}) *MockShortUrlCommandRepositoryInterface_ConsumeClick_Call {
+	return &MockShortUrlCommandRepositoryInterface_ConsumeClick_Call{Call: _e.mock.On("ConsumeClick", ctx, id)}
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ConsumeClick_Call) Run(run func(ctx context.Context, id uint)) *MockShortUrlCommandRepositoryInterface_ConsumeClick_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ConsumeClick_Call) Return(_a0 bool, _a1 error) *MockShortUrlCommandRepositoryInterface_ConsumeClick_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ConsumeClick_Call) RunAndReturn(run func(context.Context, uint) (bool, error)) *MockShortUrlCommandRepositoryInterface_ConsumeClick_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockShortUrlCommandRepositoryInterface) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShortUrlCommandRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockShortUrlCommandRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+func (_e *MockShortUrlCommandRepositoryInterface_Expecter) Delete(ctx interface{}, id interface{}) *MockShortUrlCommandRepositoryInterface_Delete_Call {
+	return &MockShortUrlCommandRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_Delete_Call) Run(run func(ctx context.Context, id uint)) *MockShortUrlCommandRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_Delete_Call) Return(_a0 error) *MockShortUrlCommandRepositoryInterface_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_Delete_Call) RunAndReturn(run func(context.Context, uint) error) *MockShortUrlCommandRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceDestinations provides a mock function with given fields: ctx, shortUrlID, destinations
+func (_m *MockShortUrlCommandRepositoryInterface) ReplaceDestinations(ctx context.Context, shortUrlID uint, destinations []entities.ShortUrlDestination) ([]entities.ShortUrlDestination, error) {
+	ret := _m.Called(ctx, shortUrlID, destinations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceDestinations")
+	}
+
+	var r0 []entities.ShortUrlDestination
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []entities.ShortUrlDestination) ([]entities.ShortUrlDestination, error)); ok {
+		return rf(ctx, shortUrlID, destinations)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []entities.ShortUrlDestination) []entities.ShortUrlDestination); ok {
+		r0 = rf(ctx, shortUrlID, destinations)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ShortUrlDestination)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []entities.ShortUrlDestination) error); ok {
+		r1 = rf(ctx, shortUrlID, destinations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceDestinations'
+type MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call struct {
+	*mock.Call
+}
+
+// ReplaceDestinations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortUrlID uint
+//   - destinations []entities.ShortUrlDestination
+func (_e *MockShortUrlCommandRepositoryInterface_Expecter) ReplaceDestinations(ctx interface{}, shortUrlID interface{}, destinations interface{}) *MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call {
+	return &MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call{Call: _e.mock.On("ReplaceDestinations", ctx, shortUrlID, destinations)}
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call) Run(run func(ctx context.Context, shortUrlID uint, destinations []entities.ShortUrlDestination)) *MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].([]entities.ShortUrlDestination))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call) Return(_a0 []entities.ShortUrlDestination, _a1 error) *MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call) RunAndReturn(run func(context.Context, uint, []entities.ShortUrlDestination) ([]entities.ShortUrlDestination, error)) *MockShortUrlCommandRepositoryInterface_ReplaceDestinations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetClickBudget provides a mock function with given fields: ctx, id, maxClicks
+func (_m *MockShortUrlCommandRepositoryInterface) ResetClickBudget(ctx context.Context, id uint, maxClicks *int64) error {
+	ret := _m.Called(ctx, id, maxClicks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetClickBudget")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, *int64) error); ok {
+		r0 = rf(ctx, id, maxClicks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetClickBudget'
+type MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call struct {
+	*mock.Call
+}
+
+// ResetClickBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+//   - maxClicks *int64
+func (_e *MockShortUrlCommandRepositoryInterface_Expecter) ResetClickBudget(ctx interface{}, id interface{}, maxClicks interface{}) *MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call {
+	return &MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call{Call: _e.mock.On("ResetClickBudget", ctx, id, maxClicks)}
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call) Run(run func(ctx context.Context, id uint, maxClicks *int64)) *MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(*int64))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call) Return(_a0 error) *MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call) RunAndReturn(run func(context.Context, uint, *int64) error) *MockShortUrlCommandRepositoryInterface_ResetClickBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, shortUrl
 func (_m *MockShortUrlCommandRepositoryInterface) Save(ctx context.Context, shortUrl *entities.ShortUrl) error {
 	ret := _m.Called(ctx, shortUrl)
@@ -69,6 +281,100 @@ func (_c *MockShortUrlCommandRepositoryInterface_Save_Call) RunAndReturn(run fun
 	return _c
 }
 
+// SaveAll provides a mock function with given fields: ctx, shortUrls
+func (_m *MockShortUrlCommandRepositoryInterface) SaveAll(ctx context.Context, shortUrls []*entities.ShortUrl) error {
+	ret := _m.Called(ctx, shortUrls)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*entities.ShortUrl) error); ok {
+		r0 = rf(ctx, shortUrls)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShortUrlCommandRepositoryInterface_SaveAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveAll'
+type MockShortUrlCommandRepositoryInterface_SaveAll_Call struct {
+	*mock.Call
+}
+
+// SaveAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortUrls []*entities.ShortUrl
+func (_e *MockShortUrlCommandRepositoryInterface_Expecter) SaveAll(ctx interface{}, shortUrls interface{}) *MockShortUrlCommandRepositoryInterface_SaveAll_Call {
+	return &MockShortUrlCommandRepositoryInterface_SaveAll_Call{Call: _e.mock.On("SaveAll", ctx, shortUrls)}
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_SaveAll_Call) Run(run func(ctx context.Context, shortUrls []*entities.ShortUrl)) *MockShortUrlCommandRepositoryInterface_SaveAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*entities.ShortUrl))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_SaveAll_Call) Return(_a0 error) *MockShortUrlCommandRepositoryInterface_SaveAll_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_SaveAll_Call) RunAndReturn(run func(context.Context, []*entities.ShortUrl) error) *MockShortUrlCommandRepositoryInterface_SaveAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, shortUrl
+func (_m *MockShortUrlCommandRepositoryInterface) Update(ctx context.Context, shortUrl *entities.ShortUrl) error {
+	ret := _m.Called(ctx, shortUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.ShortUrl) error); ok {
+		r0 = rf(ctx, shortUrl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShortUrlCommandRepositoryInterface_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockShortUrlCommandRepositoryInterface_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortUrl *entities.ShortUrl
+func (_e *MockShortUrlCommandRepositoryInterface_Expecter) Update(ctx interface{}, shortUrl interface{}) *MockShortUrlCommandRepositoryInterface_Update_Call {
+	return &MockShortUrlCommandRepositoryInterface_Update_Call{Call: _e.mock.On("Update", ctx, shortUrl)}
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_Update_Call) Run(run func(ctx context.Context, shortUrl *entities.ShortUrl)) *MockShortUrlCommandRepositoryInterface_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entities.ShortUrl))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_Update_Call) Return(_a0 error) *MockShortUrlCommandRepositoryInterface_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShortUrlCommandRepositoryInterface_Update_Call) RunAndReturn(run func(context.Context, *entities.ShortUrl) error) *MockShortUrlCommandRepositoryInterface_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockShortUrlCommandRepositoryInterface creates a new instance of MockShortUrlCommandRepositoryInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockShortUrlCommandRepositoryInterface(t interface {
@@ -9,6 +9,8 @@ import (
 	entities "short-url/domains/entities"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockShortUrlQueryRepositoryInterface is an autogenerated mock type for the ShortUrlQueryRepositoryInterface type
@@ -24,6 +26,126 @@ func (_m *MockShortUrlQueryRepositoryInterface) EXPECT() *MockShortUrlQueryRepos
 	return &MockShortUrlQueryRepositoryInterface_Expecter{mock: &_m.Mock}
 }
 
+// ExistsByShortCode provides a mock function with given fields: ctx, domainID, shortCode
+func (_m *MockShortUrlQueryRepositoryInterface) ExistsByShortCode(ctx context.Context, domainID uint, shortCode string) (bool, error) {
+	ret := _m.Called(ctx, domainID, shortCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsByShortCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (bool, error)); ok {
+		return rf(ctx, domainID, shortCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) bool); ok {
+		r0 = rf(ctx, domainID, shortCode)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, domainID, shortCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsByShortCode'
+type MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call struct {
+	*mock.Call
+}
+
+// ExistsByShortCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID uint
+//   - shortCode string
+func (_e *MockShortUrlQueryRepositoryInterface_Expecter) ExistsByShortCode(ctx interface{}, domainID interface{}, shortCode interface{}) *MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call {
+	return &MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call{Call: _e.mock.On("ExistsByShortCode", ctx, domainID, shortCode)}
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call) Run(run func(ctx context.Context, domainID uint, shortCode string)) *MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call) Return(_a0 bool, _a1 error) *MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call) RunAndReturn(run func(context.Context, uint, string) (bool, error)) *MockShortUrlQueryRepositoryInterface_ExistsByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindActiveByLongUrlHash provides a mock function with given fields: ctx, longUrlHash, userID, sameInstitution, now
+func (_m *MockShortUrlQueryRepositoryInterface) FindActiveByLongUrlHash(ctx context.Context, longUrlHash string, userID uint, sameInstitution bool, now time.Time) (*entities.ShortUrl, error) {
+	ret := _m.Called(ctx, longUrlHash, userID, sameInstitution, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveByLongUrlHash")
+	}
+
+	var r0 *entities.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint, bool, time.Time) (*entities.ShortUrl, error)); ok {
+		return rf(ctx, longUrlHash, userID, sameInstitution, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint, bool, time.Time) *entities.ShortUrl); ok {
+		r0 = rf(ctx, longUrlHash, userID, sameInstitution, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint, bool, time.Time) error); ok {
+		r1 = rf(ctx, longUrlHash, userID, sameInstitution, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindActiveByLongUrlHash'
+type MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call struct {
+	*mock.Call
+}
+
+// FindActiveByLongUrlHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - longUrlHash string
+//   - userID uint
+//   - sameInstitution bool
+//   - now time.Time
+func (_e *MockShortUrlQueryRepositoryInterface_Expecter) FindActiveByLongUrlHash(ctx interface{}, longUrlHash interface{}, userID interface{}, sameInstitution interface{}, now interface{}) *MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call {
+	return &MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call{Call: _e.mock.On("FindActiveByLongUrlHash", ctx, longUrlHash, userID, sameInstitution, now)}
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call) Run(run func(ctx context.Context, longUrlHash string, userID uint, sameInstitution bool, now time.Time)) *MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint), args[3].(bool), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call) Return(_a0 *entities.ShortUrl, _a1 error) *MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call) RunAndReturn(run func(context.Context, string, uint, bool, time.Time) (*entities.ShortUrl, error)) *MockShortUrlQueryRepositoryInterface_FindActiveByLongUrlHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindByFilter provides a mock function with given fields: ctx, filter, pagination
 func (_m *MockShortUrlQueryRepositoryInterface) FindByFilter(ctx context.Context, filter dto.ShortUrlQueryFilter, pagination dto.Pagination) ([]entities.ShortUrl, *dto.PaginationResponse, error) {
 	ret := _m.Called(ctx, filter, pagination)
@@ -152,9 +274,9 @@ func (_c *MockShortUrlQueryRepositoryInterface_FindByID_Call) RunAndReturn(run f
 	return _c
 }
 
-// FindByShortCode provides a mock function with given fields: ctx, shortCode
-func (_m *MockShortUrlQueryRepositoryInterface) FindByShortCode(ctx context.Context, shortCode string) (*entities.ShortUrl, error) {
-	ret := _m.Called(ctx, shortCode)
+// FindByShortCode provides a mock function with given fields: ctx, domainID, shortCode
+func (_m *MockShortUrlQueryRepositoryInterface) FindByShortCode(ctx context.Context, domainID uint, shortCode string) (*entities.ShortUrl, error) {
+	ret := _m.Called(ctx, domainID, shortCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByShortCode")
@@ -162,19 +284,19 @@ func (_m *MockShortUrlQueryRepositoryInterface) FindByShortCode(ctx context.Cont
 
 	var r0 *entities.ShortUrl
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.ShortUrl, error)); ok {
-		return rf(ctx, shortCode)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (*entities.ShortUrl, error)); ok {
+		return rf(ctx, domainID, shortCode)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entities.ShortUrl); ok {
-		r0 = rf(ctx, shortCode)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) *entities.ShortUrl); ok {
+		r0 = rf(ctx, domainID, shortCode)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.ShortUrl)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, shortCode)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, domainID, shortCode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -189,14 +311,15 @@ type MockShortUrlQueryRepositoryInterface_FindByShortCode_Call struct {
 
 // FindByShortCode is a helper method to define mock.On call
 //   - ctx context.Context
+//   - domainID uint
 //   - shortCode string
-func (_e *MockShortUrlQueryRepositoryInterface_Expecter) FindByShortCode(ctx interface{}, shortCode interface{}) *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call {
-	return &MockShortUrlQueryRepositoryInterface_FindByShortCode_Call{Call: _e.mock.On("FindByShortCode", ctx, shortCode)}
+func (_e *MockShortUrlQueryRepositoryInterface_Expecter) FindByShortCode(ctx interface{}, domainID interface{}, shortCode interface{}) *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call {
+	return &MockShortUrlQueryRepositoryInterface_FindByShortCode_Call{Call: _e.mock.On("FindByShortCode", ctx, domainID, shortCode)}
 }
 
-func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call) Run(run func(ctx context.Context, shortCode string)) *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call {
+func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call) Run(run func(ctx context.Context, domainID uint, shortCode string)) *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uint), args[2].(string))
 	})
 	return _c
 }
@@ -206,7 +329,189 @@ func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call) Return(_a0
 	return _c
 }
 
-func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call) RunAndReturn(run func(context.Context, string) (*entities.ShortUrl, error)) *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call {
+func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call) RunAndReturn(run func(context.Context, uint, string) (*entities.ShortUrl, error)) *MockShortUrlQueryRepositoryInterface_FindByShortCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByShortCodeAndUserID provides a mock function with given fields: ctx, domainID, shortCode, userID
+func (_m *MockShortUrlQueryRepositoryInterface) FindByShortCodeAndUserID(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error) {
+	ret := _m.Called(ctx, domainID, shortCode, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByShortCodeAndUserID")
+	}
+
+	var r0 *entities.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, uint) (*entities.ShortUrl, error)); ok {
+		return rf(ctx, domainID, shortCode, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, uint) *entities.ShortUrl); ok {
+		r0 = rf(ctx, domainID, shortCode, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, uint) error); ok {
+		r1 = rf(ctx, domainID, shortCode, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByShortCodeAndUserID'
+type MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call struct {
+	*mock.Call
+}
+
+// FindByShortCodeAndUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID uint
+//   - shortCode string
+//   - userID uint
+func (_e *MockShortUrlQueryRepositoryInterface_Expecter) FindByShortCodeAndUserID(ctx interface{}, domainID interface{}, shortCode interface{}, userID interface{}) *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call {
+	return &MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call{Call: _e.mock.On("FindByShortCodeAndUserID", ctx, domainID, shortCode, userID)}
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call) Run(run func(ctx context.Context, domainID uint, shortCode string, userID uint)) *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(uint))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call) Return(_a0 *entities.ShortUrl, _a1 error) *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call) RunAndReturn(run func(context.Context, uint, string, uint) (*entities.ShortUrl, error)) *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByShortCodeAndUserIDAnyStatus provides a mock function with given fields: ctx, domainID, shortCode, userID
+func (_m *MockShortUrlQueryRepositoryInterface) FindByShortCodeAndUserIDAnyStatus(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error) {
+	ret := _m.Called(ctx, domainID, shortCode, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByShortCodeAndUserIDAnyStatus")
+	}
+
+	var r0 *entities.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, uint) (*entities.ShortUrl, error)); ok {
+		return rf(ctx, domainID, shortCode, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, uint) *entities.ShortUrl); ok {
+		r0 = rf(ctx, domainID, shortCode, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, uint) error); ok {
+		r1 = rf(ctx, domainID, shortCode, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByShortCodeAndUserIDAnyStatus'
+type MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call struct {
+	*mock.Call
+}
+
+// FindByShortCodeAndUserIDAnyStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID uint
+//   - shortCode string
+//   - userID uint
+func (_e *MockShortUrlQueryRepositoryInterface_Expecter) FindByShortCodeAndUserIDAnyStatus(ctx interface{}, domainID interface{}, shortCode interface{}, userID interface{}) *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call {
+	return &MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call{Call: _e.mock.On("FindByShortCodeAndUserIDAnyStatus", ctx, domainID, shortCode, userID)}
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call) Run(run func(ctx context.Context, domainID uint, shortCode string, userID uint)) *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(uint))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call) Return(_a0 *entities.ShortUrl, _a1 error) *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call) RunAndReturn(run func(context.Context, uint, string, uint) (*entities.ShortUrl, error)) *MockShortUrlQueryRepositoryInterface_FindByShortCodeAndUserIDAnyStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindExistingShortCodes provides a mock function with given fields: ctx, domainID, shortCodes
+func (_m *MockShortUrlQueryRepositoryInterface) FindExistingShortCodes(ctx context.Context, domainID uint, shortCodes []string) ([]string, error) {
+	ret := _m.Called(ctx, domainID, shortCodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExistingShortCodes")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) ([]string, error)); ok {
+		return rf(ctx, domainID, shortCodes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) []string); ok {
+		r0 = rf(ctx, domainID, shortCodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string) error); ok {
+		r1 = rf(ctx, domainID, shortCodes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindExistingShortCodes'
+type MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call struct {
+	*mock.Call
+}
+
+// FindExistingShortCodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID uint
+//   - shortCodes []string
+func (_e *MockShortUrlQueryRepositoryInterface_Expecter) FindExistingShortCodes(ctx interface{}, domainID interface{}, shortCodes interface{}) *MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call {
+	return &MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call{Call: _e.mock.On("FindExistingShortCodes", ctx, domainID, shortCodes)}
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call) Run(run func(ctx context.Context, domainID uint, shortCodes []string)) *MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call) Return(_a0 []string, _a1 error) *MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call) RunAndReturn(run func(context.Context, uint, []string) ([]string, error)) *MockShortUrlQueryRepositoryInterface_FindExistingShortCodes_Call {
 	_c.Call.Return(run)
 	return _c
 }
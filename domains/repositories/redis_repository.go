@@ -7,11 +7,23 @@ import (
 
 type RedisRepositoryInterface interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	// SetNX sets key only if it does not exist yet and reports whether it did.
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	// SetIfEqual sets key only while it still holds expected, in one step,
+	// and reports whether it did.
+	SetIfEqual(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration) (bool, error)
 	Get(ctx context.Context, key string) (string, error)
 	GetInt(ctx context.Context, key string) (int, error)
 	Delete(ctx context.Context, key string) error
+	// DeleteIfEqual deletes key only while it still holds value, in one step,
+	// so a lock is never released by anyone but its owner.
+	DeleteIfEqual(ctx context.Context, key string, value string) error
 	Exists(ctx context.Context, key string) (bool, error)
 	Increment(ctx context.Context, key string) (int64, error)
 	Expire(ctx context.Context, key string, expiration time.Duration) error
 	TTL(ctx context.Context, key string) (time.Duration, error)
+	Publish(ctx context.Context, channel string, message interface{}) error
+	// Subscribe passes every message published on channel to handle until ctx
+	// is done or the subscription fails.
+	Subscribe(ctx context.Context, channel string, handle func(message string)) error
 }
\ No newline at end of file
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"short-url/domains/dto"
+)
+
+type ShortClickDailyCommandRepositoryInterface interface {
+	ApplyBatch(ctx context.Context, batchID string, counts []dto.DailyClickCount) error
+	DeleteBatchMarkersBefore(ctx context.Context, before time.Time) error
+}
+
+type ShortClickDailyQueryRepositoryInterface interface {
+	SumByShortUrlID(ctx context.Context, shortUrlID uint) (int64, error)
+	FindByShortUrlIDAndDateRange(ctx context.Context, shortUrlID uint, from, to time.Time) ([]dto.DailyClickCount, error)
+}
@@ -0,0 +1,7 @@
+package repositories
+
+import "context"
+
+type ShortCodeSequenceRepositoryInterface interface {
+	NextValue(ctx context.Context) (uint64, error)
+}
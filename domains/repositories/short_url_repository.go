@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"short-url/domains/dto"
 	"short-url/domains/entities"
@@ -9,11 +10,21 @@ import (
 
 type ShortUrlCommandRepositoryInterface interface {
 	Save(ctx context.Context, shortUrl *entities.ShortUrl) error
+	SaveAll(ctx context.Context, shortUrls []*entities.ShortUrl) error
+	Update(ctx context.Context, shortUrl *entities.ShortUrl) error
+	ReplaceDestinations(ctx context.Context, shortUrlID uint, destinations []entities.ShortUrlDestination) ([]entities.ShortUrlDestination, error)
+	ConsumeClick(ctx context.Context, id uint) (bool, error)
+	ResetClickBudget(ctx context.Context, id uint, maxClicks *int64) error
+	Delete(ctx context.Context, id uint) error
 }
 
 type ShortUrlQueryRepositoryInterface interface {
 	FindByID(ctx context.Context, id uint) (*entities.ShortUrl, error)
-	FindByShortCode(ctx context.Context, shortCode string) (*entities.ShortUrl, error)
-	FindByShortCodeAndUserID(ctx context.Context, shortCode string, userID uint) (*entities.ShortUrl, error)
+	FindByShortCode(ctx context.Context, domainID uint, shortCode string) (*entities.ShortUrl, error)
+	ExistsByShortCode(ctx context.Context, domainID uint, shortCode string) (bool, error)
+	FindExistingShortCodes(ctx context.Context, domainID uint, shortCodes []string) ([]string, error)
+	FindActiveByLongUrlHash(ctx context.Context, longUrlHash string, userID uint, sameInstitution bool, now time.Time) (*entities.ShortUrl, error)
+	FindByShortCodeAndUserID(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error)
+	FindByShortCodeAndUserIDAnyStatus(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error)
 	FindByFilter(ctx context.Context, filter dto.ShortUrlQueryFilter, pagination dto.Pagination) ([]entities.ShortUrl, *dto.PaginationResponse, error)
 }
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"short-url/domains/entities"
+)
+
+type UrlSafetyCommandRepositoryInterface interface {
+	Upsert(ctx context.Context, safety *entities.UrlSafety) error
+}
+
+type UrlSafetyQueryRepositoryInterface interface {
+	FindByShortUrlID(ctx context.Context, shortUrlID uint) (*entities.UrlSafety, error)
+	FindShortUrlsDueForCheck(ctx context.Context, checkedBefore time.Time, afterID uint, limit int) ([]entities.ShortUrl, error)
+}
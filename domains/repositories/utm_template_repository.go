@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"short-url/domains/entities"
+)
+
+type UtmTemplateCommandRepositoryInterface interface {
+	Save(ctx context.Context, template *entities.UtmTemplate) error
+	Update(ctx context.Context, template *entities.UtmTemplate) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type UtmTemplateQueryRepositoryInterface interface {
+	FindByIDAndUserID(ctx context.Context, id uint, userID uint) (*entities.UtmTemplate, error)
+	FindByUserID(ctx context.Context, userID uint) ([]entities.UtmTemplate, error)
+}
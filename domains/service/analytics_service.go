@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"short-url/domains/dto"
+)
+
+type AnalyticsServiceInterface interface {
+	GetShortUrlStats(ctx context.Context, domainID uint, shortCode string, userID uint, query dto.ShortUrlStatsQuery) (*dto.ShortUrlStatsResponse, error)
+	GetClickBreakdown(ctx context.Context, domainID uint, shortCode string, userID uint, dimension string, query dto.ClickBreakdownQuery) (*dto.ClickBreakdownResponse, error)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"short-url/domains/dto"
+)
+
+type ClickEventRecorderServiceInterface interface {
+	Record(input dto.ClickEventInput)
+	Run(ctx context.Context)
+}
@@ -0,0 +1,8 @@
+package service
+
+import "context"
+
+type ClickFlusherServiceInterface interface {
+	Run(ctx context.Context)
+	Flush(ctx context.Context) error
+}
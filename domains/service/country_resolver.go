@@ -0,0 +1,8 @@
+package service
+
+// CountryResolver maps a client IP to an upper-case ISO 3166-1 alpha-2
+// country code for targeting rules. It returns "" when the country is
+// unknown; lookups sit in the redirect path and must not block.
+type CountryResolver interface {
+	ResolveCountry(ip string) string
+}
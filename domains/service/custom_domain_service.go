@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+)
+
+type CustomDomainServiceInterface interface {
+	CreateCustomDomain(ctx context.Context, req *dto.CreateCustomDomainRequest, userID uint) (*entities.CustomDomain, error)
+	ListCustomDomains(ctx context.Context, userID uint) ([]entities.CustomDomain, error)
+	GetCustomDomain(ctx context.Context, id uint, userID uint) (*entities.CustomDomain, error)
+	VerifyCustomDomain(ctx context.Context, id uint, userID uint) (*entities.CustomDomain, error)
+	DeleteCustomDomain(ctx context.Context, id uint, userID uint) error
+}
+
+// DomainProofResolver fetches what the owner of a host published to prove
+// control of it: the TXT records of a DNS name and the body of a file under
+// /.well-known/ on the host.
+type DomainProofResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	FetchWellKnown(ctx context.Context, host string, path string) (string, error)
+}
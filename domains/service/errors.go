@@ -0,0 +1,85 @@
+package service
+
+import "errors"
+
+var (
+	ErrInvalidAlias  = errors.New("alias must be 3-10 characters of letters, digits, '-' or '_'")
+	ErrReservedAlias = errors.New("alias is reserved")
+	ErrAliasTaken    = errors.New("alias is already in use")
+
+	// ErrShortCodeExhausted means every generated candidate collided with an
+	// existing code. It is rare enough that retrying the request is the fix.
+	ErrShortCodeExhausted = errors.New("could not generate a unique short code, please try again")
+
+	ErrInvalidExpiry     = errors.New("expire_at must be in the future and ttl must be between 1 and 315360000 seconds")
+	ErrConflictingExpiry = errors.New("only one of expire_at, ttl or clear_expiry may be set")
+
+	ErrInvalidActiveFrom   = errors.New("active_from must be before the link expires")
+	ErrInvalidAvailability = errors.New("availability needs start and end as HH:MM, days from mon to sun and an IANA timezone")
+	ErrConflictingSchedule = errors.New("active_from and clear_active_from, or availability and clear_availability, may not be set together")
+
+	ErrInvalidDedupeScope  = errors.New("dedupe must be user or institution")
+	ErrInvalidLinkPassword = errors.New("password must be between 4 and 72 bytes")
+	ErrInvalidMaxClicks    = errors.New("max_clicks must be a positive number of clicks")
+	ErrInvalidUtm          = errors.New("utm values must be at most 255 characters")
+	ErrUtmTemplateNotFound = errors.New("utm template not found")
+	ErrInvalidRedirectType = errors.New("redirect_type must be 301, 302, 307 or 308")
+
+	ErrInvalidDestinations   = errors.New("destinations takes 2 to 10 different http(s) urls, each with a weight between 1 and 1000")
+	ErrInvalidTargetingRules = errors.New("targeting_rules takes at most 20 rules, each with an http(s) destination and at least one of os (ios, android, desktop), languages or countries (two letter codes)")
+
+	ErrInvalidUtmTemplateName = errors.New("name is required and must be at most 100 characters")
+
+	ErrInvalidCustomDomainHost        = errors.New("host must be a domain name such as go.example.edu, without scheme, port or path")
+	ErrCustomDomainTaken              = errors.New("host is already registered")
+	ErrCustomDomainNotFound           = errors.New("custom domain not found")
+	ErrCustomDomainNotVerified        = errors.New("custom domain is not verified yet")
+	ErrCustomDomainVerificationFailed = errors.New("verification token was found neither in the TXT record nor in the well-known file")
+	ErrCustomDomainInUse              = errors.New("custom domain still has short urls")
+	ErrCustomDomainInBulk             = errors.New("domain_id is not supported in bulk creation")
+
+	// ErrDuplicateLongUrl is not a failure: it is returned together with the
+	// existing link when a create with dedupe matched one.
+	ErrDuplicateLongUrl = errors.New("an active short url already exists for this long url")
+
+	ErrInvalidLongUrl     = errors.New("long_url must be an http(s) url")
+	ErrInvalidFallbackUrl = errors.New("fallback_url must be an http(s) url")
+
+	ErrLongUrlRequired   = errors.New("long_url is required")
+	ErrInvalidBulkSize   = errors.New("bulk requests must contain between 1 and 1000 rows")
+	ErrBulkCreateAborted = errors.New("bulk creation aborted: at least one row is invalid, nothing was created")
+
+	// ErrShortUrlExpired is returned together with the expired link so callers
+	// can still honour its fallback URL.
+	ErrShortUrlExpired = errors.New("short url has expired")
+
+	// ErrShortUrlClickLimitReached is returned together with a link that has
+	// used up its max_clicks. Callers treat it like an expired link.
+	ErrShortUrlClickLimitReached = errors.New("short url has reached its click limit")
+
+	// ErrShortUrlUnsafe is returned together with a link whose destination
+	// was flagged by the safety scan.
+	ErrShortUrlUnsafe = errors.New("short url destination was flagged as unsafe")
+
+	// ErrShortUrlNotAvailable is returned together with a link that has not
+	// reached its active_from yet or is outside its availability window.
+	ErrShortUrlNotAvailable = errors.New("short url is not available at this time")
+
+	// ErrShortUrlPasswordRequired is returned together with a password
+	// protected link; the caller decides whether the visitor has unlocked it.
+	ErrShortUrlPasswordRequired = errors.New("short url is password protected")
+	ErrIncorrectLinkPassword    = errors.New("incorrect password")
+	ErrTooManyPasswordAttempts  = errors.New("too many password attempts, please try again later")
+
+	ErrInvalidTimezone   = errors.New("tz must be a valid IANA time zone name")
+	ErrInvalidStatsRange = errors.New("from and to must be dates (YYYY-MM-DD) with from <= to and a range of at most 366 days")
+	ErrInvalidTrendDays  = errors.New("days must be between 1 and 365")
+	ErrInvalidLimit      = errors.New("limit must be between 1 and 100")
+	ErrInvalidDimension  = errors.New("dimension must be referrer, browser, language or destination")
+
+	ErrInvalidQrFormat = errors.New("format must be png or svg")
+	ErrInvalidQrSize   = errors.New("size must be between 64 and 2048 pixels")
+	ErrInvalidQrMargin = errors.New("margin must be between 0 and 16 modules")
+	ErrInvalidQrLevel  = errors.New("ecl must be L, M, Q or H")
+	ErrInvalidQrColor  = errors.New("fg and bg must be hex colours such as #000 or #1a2b3c, with an optional alpha byte")
+)
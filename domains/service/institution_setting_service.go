@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+)
+
+type InstitutionSettingServiceInterface interface {
+	GetInstitutionSettings(ctx context.Context, userID uint) (*entities.InstitutionSetting, error)
+	UpdateInstitutionSettings(ctx context.Context, req *dto.UpdateInstitutionSettingsRequest, userID uint) (*entities.InstitutionSetting, error)
+}
@@ -24,9 +24,195 @@ func (_m *MockShortUrlServiceInterface) EXPECT() *MockShortUrlServiceInterface_E
 	return &MockShortUrlServiceInterface_Expecter{mock: &_m.Mock}
 }
 
-// CreateShortUrl provides a mock function with given fields: ctx, longUrl, userID
-func (_m *MockShortUrlServiceInterface) CreateShortUrl(ctx context.Context, longUrl string, userID uint) (*entities.ShortUrl, error) {
-	ret := _m.Called(ctx, longUrl, userID)
+// BulkCreateShortUrls provides a mock function with given fields: ctx, reqs, userID, atomic
+func (_m *MockShortUrlServiceInterface) BulkCreateShortUrls(ctx context.Context, reqs []dto.CreateShortUrlRequest, userID uint, atomic bool) (*dto.BulkCreateShortUrlResponse, error) {
+	ret := _m.Called(ctx, reqs, userID, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreateShortUrls")
+	}
+
+	var r0 *dto.BulkCreateShortUrlResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.CreateShortUrlRequest, uint, bool) (*dto.BulkCreateShortUrlResponse, error)); ok {
+		return rf(ctx, reqs, userID, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []dto.CreateShortUrlRequest, uint, bool) *dto.BulkCreateShortUrlResponse); ok {
+		r0 = rf(ctx, reqs, userID, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BulkCreateShortUrlResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []dto.CreateShortUrlRequest, uint, bool) error); ok {
+		r1 = rf(ctx, reqs, userID, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlServiceInterface_BulkCreateShortUrls_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkCreateShortUrls'
+type MockShortUrlServiceInterface_BulkCreateShortUrls_Call struct {
+	*mock.Call
+}
+
+// BulkCreateShortUrls is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqs []dto.CreateShortUrlRequest
+//   - userID uint
+//   - atomic bool
+func (_e *MockShortUrlServiceInterface_Expecter) BulkCreateShortUrls(ctx interface{}, reqs interface{}, userID interface{}, atomic interface{}) *MockShortUrlServiceInterface_BulkCreateShortUrls_Call {
+	return &MockShortUrlServiceInterface_BulkCreateShortUrls_Call{Call: _e.mock.On("BulkCreateShortUrls", ctx, reqs, userID, atomic)}
+}
+
+func (_c *MockShortUrlServiceInterface_BulkCreateShortUrls_Call) Run(run func(ctx context.Context, reqs []dto.CreateShortUrlRequest, userID uint, atomic bool)) *MockShortUrlServiceInterface_BulkCreateShortUrls_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]dto.CreateShortUrlRequest), args[2].(uint), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_BulkCreateShortUrls_Call) Return(_a0 *dto.BulkCreateShortUrlResponse, _a1 error) *MockShortUrlServiceInterface_BulkCreateShortUrls_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_BulkCreateShortUrls_Call) RunAndReturn(run func(context.Context, []dto.CreateShortUrlRequest, uint, bool) (*dto.BulkCreateShortUrlResponse, error)) *MockShortUrlServiceInterface_BulkCreateShortUrls_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CacheStats provides a mock function with no fields
+func (_m *MockShortUrlServiceInterface) CacheStats() dto.LinkCacheStats {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CacheStats")
+	}
+
+	var r0 dto.LinkCacheStats
+	if rf, ok := ret.Get(0).(func() dto.LinkCacheStats); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(dto.LinkCacheStats)
+	}
+
+	return r0
+}
+
+// MockShortUrlServiceInterface_CacheStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CacheStats'
+type MockShortUrlServiceInterface_CacheStats_Call struct {
+	*mock.Call
+}
+
+// CacheStats is a helper method to define mock.On call
+func (_e *MockShortUrlServiceInterface_Expecter) CacheStats() *MockShortUrlServiceInterface_CacheStats_Call {
+	return &MockShortUrlServiceInterface_CacheStats_Call{Call: _e.mock.On("CacheStats")}
+}
+
+func (_c *MockShortUrlServiceInterface_CacheStats_Call) Run(run func()) *MockShortUrlServiceInterface_CacheStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_CacheStats_Call) Return(_a0 dto.LinkCacheStats) *MockShortUrlServiceInterface_CacheStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_CacheStats_Call) RunAndReturn(run func() dto.LinkCacheStats) *MockShortUrlServiceInterface_CacheStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsumeClick provides a mock function with given fields: ctx, shortUrl
+func (_m *MockShortUrlServiceInterface) ConsumeClick(ctx context.Context, shortUrl *entities.ShortUrl) error {
+	ret := _m.Called(ctx, shortUrl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeClick")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.ShortUrl) error); ok {
+		r0 = rf(ctx, shortUrl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShortUrlServiceInterface_ConsumeClick_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsumeClick'
+type MockShortUrlServiceInterface_ConsumeClick_Call struct {
+	*mock.Call
+}
+
+// ConsumeClick is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortUrl *entities.ShortUrl
+func (_e *MockShortUrlServiceInterface_Expecter) ConsumeClick(ctx interface{}, shortUrl interface{}) *MockShortUrlServiceInterface_ConsumeClick_Call {
+	return &MockShortUrlServiceInterface_ConsumeClick_Call{Call: _e.mock.On("ConsumeClick", ctx, shortUrl)}
+}
+
+func (_c *MockShortUrlServiceInterface_ConsumeClick_Call) Run(run func(ctx context.Context, shortUrl *entities.ShortUrl)) *MockShortUrlServiceInterface_ConsumeClick_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entities.ShortUrl))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_ConsumeClick_Call) Return(_a0 error) *MockShortUrlServiceInterface_ConsumeClick_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_ConsumeClick_Call) RunAndReturn(run func(context.Context, *entities.ShortUrl) error) *MockShortUrlServiceInterface_ConsumeClick_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountClicks provides a mock function with given fields: ctx
+func (_m *MockShortUrlServiceInterface) CountClicks(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockShortUrlServiceInterface_CountClicks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountClicks'
+type MockShortUrlServiceInterface_CountClicks_Call struct {
+	*mock.Call
+}
+
+// CountClicks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockShortUrlServiceInterface_Expecter) CountClicks(ctx interface{}) *MockShortUrlServiceInterface_CountClicks_Call {
+	return &MockShortUrlServiceInterface_CountClicks_Call{Call: _e.mock.On("CountClicks", ctx)}
+}
+
+func (_c *MockShortUrlServiceInterface_CountClicks_Call) Run(run func(ctx context.Context)) *MockShortUrlServiceInterface_CountClicks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_CountClicks_Call) Return() *MockShortUrlServiceInterface_CountClicks_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_CountClicks_Call) RunAndReturn(run func(context.Context)) *MockShortUrlServiceInterface_CountClicks_Call {
+	_c.Run(run)
+	return _c
+}
+
+// CreateShortUrl provides a mock function with given fields: ctx, req, userID
+func (_m *MockShortUrlServiceInterface) CreateShortUrl(ctx context.Context, req *dto.CreateShortUrlRequest, userID uint) (*entities.ShortUrl, error) {
+	ret := _m.Called(ctx, req, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateShortUrl")
@@ -34,19 +220,19 @@ func (_m *MockShortUrlServiceInterface) CreateShortUrl(ctx context.Context, long
 
 	var r0 *entities.ShortUrl
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, uint) (*entities.ShortUrl, error)); ok {
-		return rf(ctx, longUrl, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.CreateShortUrlRequest, uint) (*entities.ShortUrl, error)); ok {
+		return rf(ctx, req, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, uint) *entities.ShortUrl); ok {
-		r0 = rf(ctx, longUrl, userID)
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.CreateShortUrlRequest, uint) *entities.ShortUrl); ok {
+		r0 = rf(ctx, req, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.ShortUrl)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
-		r1 = rf(ctx, longUrl, userID)
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.CreateShortUrlRequest, uint) error); ok {
+		r1 = rf(ctx, req, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,15 +247,15 @@ type MockShortUrlServiceInterface_CreateShortUrl_Call struct {
 
 // CreateShortUrl is a helper method to define mock.On call
 //   - ctx context.Context
-//   - longUrl string
+//   - req *dto.CreateShortUrlRequest
 //   - userID uint
-func (_e *MockShortUrlServiceInterface_Expecter) CreateShortUrl(ctx interface{}, longUrl interface{}, userID interface{}) *MockShortUrlServiceInterface_CreateShortUrl_Call {
-	return &MockShortUrlServiceInterface_CreateShortUrl_Call{Call: _e.mock.On("CreateShortUrl", ctx, longUrl, userID)}
+func (_e *MockShortUrlServiceInterface_Expecter) CreateShortUrl(ctx interface{}, req interface{}, userID interface{}) *MockShortUrlServiceInterface_CreateShortUrl_Call {
+	return &MockShortUrlServiceInterface_CreateShortUrl_Call{Call: _e.mock.On("CreateShortUrl", ctx, req, userID)}
 }
 
-func (_c *MockShortUrlServiceInterface_CreateShortUrl_Call) Run(run func(ctx context.Context, longUrl string, userID uint)) *MockShortUrlServiceInterface_CreateShortUrl_Call {
+func (_c *MockShortUrlServiceInterface_CreateShortUrl_Call) Run(run func(ctx context.Context, req *dto.CreateShortUrlRequest, userID uint)) *MockShortUrlServiceInterface_CreateShortUrl_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(uint))
+		run(args[0].(context.Context), args[1].(*dto.CreateShortUrlRequest), args[2].(uint))
 	})
 	return _c
 }
@@ -79,7 +265,56 @@ func (_c *MockShortUrlServiceInterface_CreateShortUrl_Call) Return(_a0 *entities
 	return _c
 }
 
-func (_c *MockShortUrlServiceInterface_CreateShortUrl_Call) RunAndReturn(run func(context.Context, string, uint) (*entities.ShortUrl, error)) *MockShortUrlServiceInterface_CreateShortUrl_Call {
+func (_c *MockShortUrlServiceInterface_CreateShortUrl_Call) RunAndReturn(run func(context.Context, *dto.CreateShortUrlRequest, uint) (*entities.ShortUrl, error)) *MockShortUrlServiceInterface_CreateShortUrl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteShortUrl provides a mock function with given fields: ctx, domainID, shortCode, userID
+func (_m *MockShortUrlServiceInterface) DeleteShortUrl(ctx context.Context, domainID uint, shortCode string, userID uint) error {
+	ret := _m.Called(ctx, domainID, shortCode, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteShortUrl")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, uint) error); ok {
+		r0 = rf(ctx, domainID, shortCode, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockShortUrlServiceInterface_DeleteShortUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteShortUrl'
+type MockShortUrlServiceInterface_DeleteShortUrl_Call struct {
+	*mock.Call
+}
+
+// DeleteShortUrl is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID uint
+//   - shortCode string
+//   - userID uint
+func (_e *MockShortUrlServiceInterface_Expecter) DeleteShortUrl(ctx interface{}, domainID interface{}, shortCode interface{}, userID interface{}) *MockShortUrlServiceInterface_DeleteShortUrl_Call {
+	return &MockShortUrlServiceInterface_DeleteShortUrl_Call{Call: _e.mock.On("DeleteShortUrl", ctx, domainID, shortCode, userID)}
+}
+
+func (_c *MockShortUrlServiceInterface_DeleteShortUrl_Call) Run(run func(ctx context.Context, domainID uint, shortCode string, userID uint)) *MockShortUrlServiceInterface_DeleteShortUrl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(uint))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_DeleteShortUrl_Call) Return(_a0 error) *MockShortUrlServiceInterface_DeleteShortUrl_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_DeleteShortUrl_Call) RunAndReturn(run func(context.Context, uint, string, uint) error) *MockShortUrlServiceInterface_DeleteShortUrl_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -153,9 +388,9 @@ func (_c *MockShortUrlServiceInterface_GetByFilter_Call) RunAndReturn(run func(c
 	return _c
 }
 
-// GetByShortCode provides a mock function with given fields: ctx, shortCode
-func (_m *MockShortUrlServiceInterface) GetByShortCode(ctx context.Context, shortCode string) (*entities.ShortUrl, error) {
-	ret := _m.Called(ctx, shortCode)
+// GetByShortCode provides a mock function with given fields: ctx, domainID, shortCode, userID
+func (_m *MockShortUrlServiceInterface) GetByShortCode(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error) {
+	ret := _m.Called(ctx, domainID, shortCode, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByShortCode")
@@ -163,19 +398,19 @@ func (_m *MockShortUrlServiceInterface) GetByShortCode(ctx context.Context, shor
 
 	var r0 *entities.ShortUrl
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*entities.ShortUrl, error)); ok {
-		return rf(ctx, shortCode)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, uint) (*entities.ShortUrl, error)); ok {
+		return rf(ctx, domainID, shortCode, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *entities.ShortUrl); ok {
-		r0 = rf(ctx, shortCode)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, uint) *entities.ShortUrl); ok {
+		r0 = rf(ctx, domainID, shortCode, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.ShortUrl)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, shortCode)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, uint) error); ok {
+		r1 = rf(ctx, domainID, shortCode, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -190,14 +425,16 @@ type MockShortUrlServiceInterface_GetByShortCode_Call struct {
 
 // GetByShortCode is a helper method to define mock.On call
 //   - ctx context.Context
+//   - domainID uint
 //   - shortCode string
-func (_e *MockShortUrlServiceInterface_Expecter) GetByShortCode(ctx interface{}, shortCode interface{}) *MockShortUrlServiceInterface_GetByShortCode_Call {
-	return &MockShortUrlServiceInterface_GetByShortCode_Call{Call: _e.mock.On("GetByShortCode", ctx, shortCode)}
+//   - userID uint
+func (_e *MockShortUrlServiceInterface_Expecter) GetByShortCode(ctx interface{}, domainID interface{}, shortCode interface{}, userID interface{}) *MockShortUrlServiceInterface_GetByShortCode_Call {
+	return &MockShortUrlServiceInterface_GetByShortCode_Call{Call: _e.mock.On("GetByShortCode", ctx, domainID, shortCode, userID)}
 }
 
-func (_c *MockShortUrlServiceInterface_GetByShortCode_Call) Run(run func(ctx context.Context, shortCode string)) *MockShortUrlServiceInterface_GetByShortCode_Call {
+func (_c *MockShortUrlServiceInterface_GetByShortCode_Call) Run(run func(ctx context.Context, domainID uint, shortCode string, userID uint)) *MockShortUrlServiceInterface_GetByShortCode_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(uint))
 	})
 	return _c
 }
@@ -207,54 +444,243 @@ func (_c *MockShortUrlServiceInterface_GetByShortCode_Call) Return(_a0 *entities
 	return _c
 }
 
-func (_c *MockShortUrlServiceInterface_GetByShortCode_Call) RunAndReturn(run func(context.Context, string) (*entities.ShortUrl, error)) *MockShortUrlServiceInterface_GetByShortCode_Call {
+func (_c *MockShortUrlServiceInterface_GetByShortCode_Call) RunAndReturn(run func(context.Context, uint, string, uint) (*entities.ShortUrl, error)) *MockShortUrlServiceInterface_GetByShortCode_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IncrementClickCount provides a mock function with given fields: ctx, shortCode
-func (_m *MockShortUrlServiceInterface) IncrementClickCount(ctx context.Context, shortCode string) error {
-	ret := _m.Called(ctx, shortCode)
+// GetByShortCodePublic provides a mock function with given fields: ctx, host, shortCode
+func (_m *MockShortUrlServiceInterface) GetByShortCodePublic(ctx context.Context, host string, shortCode string) (*entities.ShortUrl, error) {
+	ret := _m.Called(ctx, host, shortCode)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementClickCount")
+		panic("no return value specified for GetByShortCodePublic")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, shortCode)
+	var r0 *entities.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entities.ShortUrl, error)); ok {
+		return rf(ctx, host, shortCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entities.ShortUrl); ok {
+		r0 = rf(ctx, host, shortCode)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ShortUrl)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, host, shortCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// MockShortUrlServiceInterface_IncrementClickCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementClickCount'
-type MockShortUrlServiceInterface_IncrementClickCount_Call struct {
+// MockShortUrlServiceInterface_GetByShortCodePublic_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByShortCodePublic'
+type MockShortUrlServiceInterface_GetByShortCodePublic_Call struct {
 	*mock.Call
 }
 
-// IncrementClickCount is a helper method to define mock.On call
+// GetByShortCodePublic is a helper method to define mock.On call
 //   - ctx context.Context
+//   - host string
 //   - shortCode string
-func (_e *MockShortUrlServiceInterface_Expecter) IncrementClickCount(ctx interface{}, shortCode interface{}) *MockShortUrlServiceInterface_IncrementClickCount_Call {
-	return &MockShortUrlServiceInterface_IncrementClickCount_Call{Call: _e.mock.On("IncrementClickCount", ctx, shortCode)}
+func (_e *MockShortUrlServiceInterface_Expecter) GetByShortCodePublic(ctx interface{}, host interface{}, shortCode interface{}) *MockShortUrlServiceInterface_GetByShortCodePublic_Call {
+	return &MockShortUrlServiceInterface_GetByShortCodePublic_Call{Call: _e.mock.On("GetByShortCodePublic", ctx, host, shortCode)}
+}
+
+func (_c *MockShortUrlServiceInterface_GetByShortCodePublic_Call) Run(run func(ctx context.Context, host string, shortCode string)) *MockShortUrlServiceInterface_GetByShortCodePublic_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_GetByShortCodePublic_Call) Return(_a0 *entities.ShortUrl, _a1 error) *MockShortUrlServiceInterface_GetByShortCodePublic_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_GetByShortCodePublic_Call) RunAndReturn(run func(context.Context, string, string) (*entities.ShortUrl, error)) *MockShortUrlServiceInterface_GetByShortCodePublic_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordClick provides a mock function with given fields: shortUrlID
+func (_m *MockShortUrlServiceInterface) RecordClick(shortUrlID uint) {
+	_m.Called(shortUrlID)
 }
 
-func (_c *MockShortUrlServiceInterface_IncrementClickCount_Call) Run(run func(ctx context.Context, shortCode string)) *MockShortUrlServiceInterface_IncrementClickCount_Call {
+// MockShortUrlServiceInterface_RecordClick_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordClick'
+type MockShortUrlServiceInterface_RecordClick_Call struct {
+	*mock.Call
+}
+
+// RecordClick is a helper method to define mock.On call
+//   - shortUrlID uint
+func (_e *MockShortUrlServiceInterface_Expecter) RecordClick(shortUrlID interface{}) *MockShortUrlServiceInterface_RecordClick_Call {
+	return &MockShortUrlServiceInterface_RecordClick_Call{Call: _e.mock.On("RecordClick", shortUrlID)}
+}
+
+func (_c *MockShortUrlServiceInterface_RecordClick_Call) Run(run func(shortUrlID uint)) *MockShortUrlServiceInterface_RecordClick_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(uint))
 	})
 	return _c
 }
 
-func (_c *MockShortUrlServiceInterface_IncrementClickCount_Call) Return(_a0 error) *MockShortUrlServiceInterface_IncrementClickCount_Call {
+func (_c *MockShortUrlServiceInterface_RecordClick_Call) Return() *MockShortUrlServiceInterface_RecordClick_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_RecordClick_Call) RunAndReturn(run func(uint)) *MockShortUrlServiceInterface_RecordClick_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SelectDestination provides a mock function with given fields: ctx, shortUrl, visitor
+func (_m *MockShortUrlServiceInterface) SelectDestination(ctx context.Context, shortUrl *entities.ShortUrl, visitor dto.RedirectVisitor) dto.RedirectDestination {
+	ret := _m.Called(ctx, shortUrl, visitor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectDestination")
+	}
+
+	var r0 dto.RedirectDestination
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.ShortUrl, dto.RedirectVisitor) dto.RedirectDestination); ok {
+		r0 = rf(ctx, shortUrl, visitor)
+	} else {
+		r0 = ret.Get(0).(dto.RedirectDestination)
+	}
+
+	return r0
+}
+
+// MockShortUrlServiceInterface_SelectDestination_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectDestination'
+type MockShortUrlServiceInterface_SelectDestination_Call struct {
+	*mock.Call
+}
+
+// SelectDestination is a helper method to define mock.On call
+//   - ctx context.Context
+//   - shortUrl *entities.ShortUrl
+//   - visitor dto.RedirectVisitor
+func (_e *MockShortUrlServiceInterface_Expecter) SelectDestination(ctx interface{}, shortUrl interface{}, visitor interface{}) *MockShortUrlServiceInterface_SelectDestination_Call {
+	return &MockShortUrlServiceInterface_SelectDestination_Call{Call: _e.mock.On("SelectDestination", ctx, shortUrl, visitor)}
+}
+
+func (_c *MockShortUrlServiceInterface_SelectDestination_Call) Run(run func(ctx context.Context, shortUrl *entities.ShortUrl, visitor dto.RedirectVisitor)) *MockShortUrlServiceInterface_SelectDestination_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entities.ShortUrl), args[2].(dto.RedirectVisitor))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_SelectDestination_Call) Return(_a0 dto.RedirectDestination) *MockShortUrlServiceInterface_SelectDestination_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockShortUrlServiceInterface_IncrementClickCount_Call) RunAndReturn(run func(context.Context, string) error) *MockShortUrlServiceInterface_IncrementClickCount_Call {
+func (_c *MockShortUrlServiceInterface_SelectDestination_Call) RunAndReturn(run func(context.Context, *entities.ShortUrl, dto.RedirectVisitor) dto.RedirectDestination) *MockShortUrlServiceInterface_SelectDestination_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncCache provides a mock function with given fields: ctx
+func (_m *MockShortUrlServiceInterface) SyncCache(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// MockShortUrlServiceInterface_SyncCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncCache'
+type MockShortUrlServiceInterface_SyncCache_Call struct {
+	*mock.Call
+}
+
+// SyncCache is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockShortUrlServiceInterface_Expecter) SyncCache(ctx interface{}) *MockShortUrlServiceInterface_SyncCache_Call {
+	return &MockShortUrlServiceInterface_SyncCache_Call{Call: _e.mock.On("SyncCache", ctx)}
+}
+
+func (_c *MockShortUrlServiceInterface_SyncCache_Call) Run(run func(ctx context.Context)) *MockShortUrlServiceInterface_SyncCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_SyncCache_Call) Return() *MockShortUrlServiceInterface_SyncCache_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_SyncCache_Call) RunAndReturn(run func(context.Context)) *MockShortUrlServiceInterface_SyncCache_Call {
+	_c.Run(run)
+	return _c
+}
+
+// UpdateShortUrl provides a mock function with given fields: ctx, domainID, shortCode, req, userID
+func (_m *MockShortUrlServiceInterface) UpdateShortUrl(ctx context.Context, domainID uint, shortCode string, req *dto.UpdateShortUrlRequest, userID uint) (*entities.ShortUrl, error) {
+	ret := _m.Called(ctx, domainID, shortCode, req, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateShortUrl")
+	}
+
+	var r0 *entities.ShortUrl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, *dto.UpdateShortUrlRequest, uint) (*entities.ShortUrl, error)); ok {
+		return rf(ctx, domainID, shortCode, req, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, *dto.UpdateShortUrlRequest, uint) *entities.ShortUrl); ok {
+		r0 = rf(ctx, domainID, shortCode, req, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ShortUrl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string, *dto.UpdateShortUrlRequest, uint) error); ok {
+		r1 = rf(ctx, domainID, shortCode, req, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockShortUrlServiceInterface_UpdateShortUrl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateShortUrl'
+type MockShortUrlServiceInterface_UpdateShortUrl_Call struct {
+	*mock.Call
+}
+
+// UpdateShortUrl is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainID uint
+//   - shortCode string
+//   - req *dto.UpdateShortUrlRequest
+//   - userID uint
+func (_e *MockShortUrlServiceInterface_Expecter) UpdateShortUrl(ctx interface{}, domainID interface{}, shortCode interface{}, req interface{}, userID interface{}) *MockShortUrlServiceInterface_UpdateShortUrl_Call {
+	return &MockShortUrlServiceInterface_UpdateShortUrl_Call{Call: _e.mock.On("UpdateShortUrl", ctx, domainID, shortCode, req, userID)}
+}
+
+func (_c *MockShortUrlServiceInterface_UpdateShortUrl_Call) Run(run func(ctx context.Context, domainID uint, shortCode string, req *dto.UpdateShortUrlRequest, userID uint)) *MockShortUrlServiceInterface_UpdateShortUrl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(string), args[3].(*dto.UpdateShortUrlRequest), args[4].(uint))
+	})
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_UpdateShortUrl_Call) Return(_a0 *entities.ShortUrl, _a1 error) *MockShortUrlServiceInterface_UpdateShortUrl_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockShortUrlServiceInterface_UpdateShortUrl_Call) RunAndReturn(run func(context.Context, uint, string, *dto.UpdateShortUrlRequest, uint) (*entities.ShortUrl, error)) *MockShortUrlServiceInterface_UpdateShortUrl_Call {
 	_c.Call.Return(run)
 	return _c
 }
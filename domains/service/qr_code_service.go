@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"short-url/domains/dto"
+)
+
+type QrCodeServiceInterface interface {
+	GetQrCode(ctx context.Context, domainID uint, shortCode string, userID uint, opts dto.QrCodeOptions) (*dto.QrCodeImage, error)
+}
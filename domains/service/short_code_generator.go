@@ -0,0 +1,10 @@
+package service
+
+import "context"
+
+// ShortCodeGenerator produces candidate short codes. A candidate is not
+// guaranteed to be free; the short URL service retries on unique violations.
+type ShortCodeGenerator interface {
+	Name() string
+	Generate(ctx context.Context) (string, error)
+}
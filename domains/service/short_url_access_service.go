@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"short-url/domains/entities"
+)
+
+// ShortUrlAccessServiceInterface guards password protected links. Unlock
+// checks a password and returns a signed access token and its expiry;
+// HasAccess validates such a token against the link.
+type ShortUrlAccessServiceInterface interface {
+	Unlock(ctx context.Context, shortUrl *entities.ShortUrl, password string, clientIP string) (string, time.Time, error)
+	HasAccess(shortUrl *entities.ShortUrl, token string) bool
+}
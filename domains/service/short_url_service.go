@@ -8,9 +8,18 @@ import (
 )
 
 type ShortUrlServiceInterface interface {
-	CreateShortUrl(ctx context.Context, longUrl string, userID uint) (*entities.ShortUrl, error)
-	GetByShortCode(ctx context.Context, shortCode string, userID uint) (*entities.ShortUrl, error)
-	GetByShortCodePublic(ctx context.Context, shortCode string) (*entities.ShortUrl, error)
+	CreateShortUrl(ctx context.Context, req *dto.CreateShortUrlRequest, userID uint) (*entities.ShortUrl, error)
+	BulkCreateShortUrls(ctx context.Context, reqs []dto.CreateShortUrlRequest, userID uint, atomic bool) (*dto.BulkCreateShortUrlResponse, error)
+	GetByShortCode(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error)
+	UpdateShortUrl(ctx context.Context, domainID uint, shortCode string, req *dto.UpdateShortUrlRequest, userID uint) (*entities.ShortUrl, error)
+	DeleteShortUrl(ctx context.Context, domainID uint, shortCode string, userID uint) error
+	GetByShortCodePublic(ctx context.Context, host string, shortCode string) (*entities.ShortUrl, error)
 	GetByFilter(ctx context.Context, filter dto.ShortUrlQueryFilter, pagination dto.Pagination) ([]entities.ShortUrl, *dto.PaginationResponse, error)
-	IncrementClickCount(ctx context.Context, shortCode string) error
+	// RecordClick queues a redirect's click for CountClicks without blocking.
+	RecordClick(shortUrlID uint)
+	CountClicks(ctx context.Context)
+	ConsumeClick(ctx context.Context, shortUrl *entities.ShortUrl) error
+	SelectDestination(ctx context.Context, shortUrl *entities.ShortUrl, visitor dto.RedirectVisitor) dto.RedirectDestination
+	SyncCache(ctx context.Context)
+	CacheStats() dto.LinkCacheStats
 }
\ No newline at end of file
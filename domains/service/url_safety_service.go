@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+)
+
+// UrlSafetyChecker inspects a destination URL. A checker that cannot decide,
+// for example because a remote lookup failed, returns an error and is skipped.
+type UrlSafetyChecker interface {
+	Name() string
+	Check(ctx context.Context, rawUrl string) (dto.UrlSafetyVerdict, error)
+}
+
+type UrlSafetyServiceInterface interface {
+	Evaluate(ctx context.Context, rawUrl string) dto.UrlSafetyVerdict
+	Scan(ctx context.Context, shortUrl *entities.ShortUrl) (dto.UrlSafetyVerdict, error)
+	Recheck(ctx context.Context) error
+	Run(ctx context.Context)
+}
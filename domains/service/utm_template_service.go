@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+)
+
+type UtmTemplateServiceInterface interface {
+	CreateUtmTemplate(ctx context.Context, req *dto.CreateUtmTemplateRequest, userID uint) (*entities.UtmTemplate, error)
+	ListUtmTemplates(ctx context.Context, userID uint) ([]entities.UtmTemplate, error)
+	GetUtmTemplate(ctx context.Context, id uint, userID uint) (*entities.UtmTemplate, error)
+	UpdateUtmTemplate(ctx context.Context, id uint, req *dto.UpdateUtmTemplateRequest, userID uint) (*entities.UtmTemplate, error)
+	DeleteUtmTemplate(ctx context.Context, id uint, userID uint) error
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"log"
+	"net/http"
+	"time"
 
 	"short-url/domains/config"
 	"short-url/domains/database"
@@ -65,17 +68,90 @@ func main() {
 	userSessionQueryRepo := userRepo.NewUserSessionQueryRepository(db)
 	userQueryRepo := userRepo.NewUserQueryRepository(db)
 
+	location, err := time.LoadLocation(cfg.DBTimezone)
+	if err != nil {
+		log.Fatal("Failed to load timezone:", err)
+	}
+
 	// Short URL repositories
 	shortUrlCommandRepo := shortUrlRepo.NewShortUrlCommandRepository(db)
 	shortUrlQueryRepo := shortUrlRepo.NewShortUrlQueryRepository(db)
 	redisRepo := shortUrlRepo.NewRedisRepository(redisClient)
+	clickCounterRepo := shortUrlRepo.NewClickCounterRepository(redisClient, location)
+	clickDailyCommandRepo := shortUrlRepo.NewShortClickDailyCommandRepository(db)
+	clickDailyQueryRepo := shortUrlRepo.NewShortClickDailyQueryRepository(db)
+	clickEventCommandRepo := shortUrlRepo.NewClickEventCommandRepository(db)
+	clickEventQueryRepo := shortUrlRepo.NewClickEventQueryRepository(db)
+	urlSafetyCommandRepo := shortUrlRepo.NewUrlSafetyCommandRepository(db)
+	urlSafetyQueryRepo := shortUrlRepo.NewUrlSafetyQueryRepository(db)
+	utmTemplateCommandRepo := shortUrlRepo.NewUtmTemplateCommandRepository(db)
+	utmTemplateQueryRepo := shortUrlRepo.NewUtmTemplateQueryRepository(db)
+	customDomainCommandRepo := shortUrlRepo.NewCustomDomainCommandRepository(db)
+	customDomainQueryRepo := shortUrlRepo.NewCustomDomainQueryRepository(db)
+	institutionSettingCommandRepo := shortUrlRepo.NewInstitutionSettingCommandRepository(db)
+	institutionSettingQueryRepo := shortUrlRepo.NewInstitutionSettingQueryRepository(db)
+
+	shortCodeSequenceRepo := shortUrlRepo.NewShortCodeSequenceRepository(db, database.ShortCodeSequence)
+
+	urlSafetyCheckers, err := shortUrlService.NewDefaultUrlSafetyCheckers(cfg.UrlBlocklistFile, cfg.UrlAllowedSchemes)
+	if err != nil {
+		log.Fatal("Failed to load url safety checkers:", err)
+	}
+
+	countryResolver, err := shortUrlService.NewCountryResolver(cfg.GeoIPCountryFile)
+	if err != nil {
+		log.Fatal("Failed to load ip country file:", err)
+	}
+
+	shortCodeGenerator, err := shortUrlService.NewShortCodeGenerator(cfg.ShortCodeStrategy, cfg.ShortCodeAlphabet, cfg.ShortCodeLength, cfg.ShortCodeSalt, shortCodeSequenceRepo)
+	if err != nil {
+		log.Fatal("Failed to configure short code generator:", err)
+	}
 
 	// Initialize services
 	userSessionService := userService.NewUserSessionService(userSessionCommandRepo, userSessionQueryRepo, userQueryRepo)
-	shortUrlSvc := shortUrlService.NewShortUrlService(shortUrlCommandRepo, shortUrlQueryRepo, redisRepo)
+	urlSafetySvc := shortUrlService.NewUrlSafetyService(urlSafetyCheckers, urlSafetyCommandRepo, urlSafetyQueryRepo, redisRepo, cfg.UrlSafetyRecheckInterval)
+	shortUrlSvc := shortUrlService.NewShortUrlService(shortUrlCommandRepo, shortUrlQueryRepo, redisRepo, clickCounterRepo, urlSafetySvc, shortCodeGenerator, utmTemplateQueryRepo, countryResolver, customDomainQueryRepo, institutionSettingQueryRepo, dto.LinkCacheConfig{
+		LocalSize: cfg.LinkCacheLocalSize,
+		LocalTTL:  cfg.LinkCacheLocalTTL,
+	})
+	analyticsSvc := shortUrlService.NewAnalyticsService(shortUrlQueryRepo, clickDailyQueryRepo, clickEventQueryRepo, location)
+	qrCodeSvc := shortUrlService.NewQrCodeService(shortUrlQueryRepo, redisRepo, cfg.PublicBaseUrl)
+	utmTemplateSvc := shortUrlService.NewUtmTemplateService(utmTemplateCommandRepo, utmTemplateQueryRepo)
+	customDomainSvc := shortUrlService.NewCustomDomainService(customDomainCommandRepo, customDomainQueryRepo, shortUrlService.NewDomainProofResolver(), redisRepo)
+	institutionSettingSvc := shortUrlService.NewInstitutionSettingService(institutionSettingCommandRepo, institutionSettingQueryRepo)
+	shortUrlAccessSvc := shortUrlService.NewShortUrlAccessService(redisRepo, cfg.JWTSecret, cfg.LinkAccessTTL)
+	clickFlusherSvc := shortUrlService.NewClickFlusherService(clickCounterRepo, clickDailyCommandRepo, cfg.ClickFlushInterval)
+	clickEventRecorderSvc := shortUrlService.NewClickEventRecorderService(clickEventCommandRepo)
+
+	// Background jobs
+	flushCtx, stopFlusher := context.WithCancel(ctx)
+	defer stopFlusher()
+	go clickFlusherSvc.Run(flushCtx)
+	go clickEventRecorderSvc.Run(flushCtx)
+	go urlSafetySvc.Run(flushCtx)
+	go shortUrlSvc.SyncCache(flushCtx)
+	go shortUrlSvc.CountClicks(flushCtx)
+	expvar.Publish("link_cache", expvar.Func(func() any { return shortUrlSvc.CacheStats() }))
+	// The counters are served on their own listener, never on the public host.
+	if cfg.MetricsAddr != "" {
+		go func() {
+			if err := http.ListenAndServe(cfg.MetricsAddr, expvar.Handler()); err != nil {
+				log.Printf("Metrics listener stopped: %v", err)
+			}
+		}()
+	}
 
 	userCtrl := userController.NewUserController(userSessionService)
-	shortUrlCtrl := shortUrlController.NewShortUrlController(shortUrlSvc)
+	shortUrlCtrl := shortUrlController.NewShortUrlController(shortUrlSvc, clickEventRecorderSvc, shortUrlAccessSvc, dto.UnavailableLinkConfig{
+		Status:      cfg.LinkUnavailableStatus,
+		RedirectUrl: cfg.LinkUnavailableUrl,
+	})
+	analyticsCtrl := shortUrlController.NewAnalyticsController(analyticsSvc)
+	qrCodeCtrl := shortUrlController.NewQrCodeController(qrCodeSvc)
+	utmTemplateCtrl := shortUrlController.NewUtmTemplateController(utmTemplateSvc)
+	customDomainCtrl := shortUrlController.NewCustomDomainController(customDomainSvc)
+	institutionSettingCtrl := shortUrlController.NewInstitutionSettingController(institutionSettingSvc)
 
 	app := fiber.New(fiber.Config{
 		AppName: "Short URL Monolith v1.0",
@@ -99,7 +175,7 @@ func main() {
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: cfg.AllowedOrigins,
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
+		AllowMethods: "GET,POST,PUT,PATCH,DELETE,OPTIONS",
 		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
 	}))
 
@@ -137,7 +213,37 @@ func main() {
 	// Short URL service routes
 	url := v1.Group("/url")
 	url.Post("/", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), shortUrlCtrl.CreateShortUrl)
+	url.Post("/bulk", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), shortUrlCtrl.BulkCreateShortUrls)
+	url.Get("/", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), shortUrlCtrl.ListShortUrls)
+	url.Get("/:shortCode/stats", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), analyticsCtrl.GetShortUrlStats)
+	url.Get("/:shortCode/stats/referrers", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), analyticsCtrl.GetTopReferrers)
+	url.Get("/:shortCode/stats/browsers", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), analyticsCtrl.GetTopBrowsers)
+	url.Get("/:shortCode/stats/languages", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), analyticsCtrl.GetTopLanguages)
+	url.Get("/:shortCode/stats/destinations", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), analyticsCtrl.GetTopDestinations)
+	url.Get("/:shortCode/qr", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), qrCodeCtrl.GetQrCode)
 	url.Get("/:shortCode", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), shortUrlCtrl.GetLongUrl)
+	url.Patch("/:shortCode", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), shortUrlCtrl.UpdateShortUrl)
+	url.Delete("/:shortCode", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), shortUrlCtrl.DeleteShortUrl)
+
+	// UTM template routes
+	utmTemplates := v1.Group("/utm-templates")
+	utmTemplates.Post("/", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), utmTemplateCtrl.CreateUtmTemplate)
+	utmTemplates.Get("/", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), utmTemplateCtrl.ListUtmTemplates)
+	utmTemplates.Get("/:id", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), utmTemplateCtrl.GetUtmTemplate)
+	utmTemplates.Patch("/:id", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), utmTemplateCtrl.UpdateUtmTemplate)
+	utmTemplates.Delete("/:id", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), utmTemplateCtrl.DeleteUtmTemplate)
+
+	// Custom domain routes
+	domains := v1.Group("/domains")
+	domains.Post("/", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), customDomainCtrl.CreateCustomDomain)
+	domains.Get("/", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), customDomainCtrl.ListCustomDomains)
+	domains.Get("/:id", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), customDomainCtrl.GetCustomDomain)
+	domains.Post("/:id/verify", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), customDomainCtrl.VerifyCustomDomain)
+	domains.Delete("/:id", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), customDomainCtrl.DeleteCustomDomain)
+
+	institution := v1.Group("/institution")
+	institution.Get("/settings", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), institutionSettingCtrl.GetInstitutionSettings)
+	institution.Patch("/settings", flexibleLimiter, shortUrlMiddleware.JWTAuth(userSessionQueryRepo), institutionSettingCtrl.UpdateInstitutionSettings)
 
 	// Start server
 	port := cfg.Port
@@ -147,7 +253,14 @@ func main() {
 
 	// Direct redirect routes (no auth required for public access) - MUST be absolutely last
 	app.Get("/url/:shortCode", shortUrlCtrl.PublicRedirect) // Temporary: keep old route
+	app.Post("/url/:shortCode", flexibleLimiter, shortUrlCtrl.UnlockShortUrl)
 	app.Get("/:shortCode", shortUrlCtrl.PublicRedirect)
+	app.Post("/:shortCode", flexibleLimiter, shortUrlCtrl.UnlockShortUrl)
+	// Path segments after the code, for links that forward them
+	app.Get("/url/:shortCode/*", shortUrlCtrl.PublicRedirect)
+	app.Post("/url/:shortCode/*", flexibleLimiter, shortUrlCtrl.UnlockShortUrl)
+	app.Get("/:shortCode/*", shortUrlCtrl.PublicRedirect)
+	app.Post("/:shortCode/*", flexibleLimiter, shortUrlCtrl.UnlockShortUrl)
 
 	log.Printf("Monolith server starting on port %s", port)
 	log.Printf("Health check available at: http://localhost:%s/health", port)
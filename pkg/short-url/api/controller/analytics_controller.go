@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"short-url/domains/dto"
+	"short-url/domains/service"
+	"short-url-service/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type AnalyticsController struct {
+	service service.AnalyticsServiceInterface
+}
+
+func NewAnalyticsController(service service.AnalyticsServiceInterface) *AnalyticsController {
+	return &AnalyticsController{
+		service: service,
+	}
+}
+
+func (c *AnalyticsController) GetShortUrlStats(ctx *fiber.Ctx) error {
+	shortCode := ctx.Params("shortCode")
+	if shortCode == "" {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Short code is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	domainID, ok := linkDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "domain_id must be a number")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	query := dto.ShortUrlStatsQuery{
+		From:     ctx.Query("from"),
+		To:       ctx.Query("to"),
+		Timezone: ctx.Query("tz"),
+	}
+	if daysStr := ctx.Query("days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 1 {
+			response := dto.NewErrorResponse(fiber.StatusBadRequest, service.ErrInvalidTrendDays.Error())
+			return ctx.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		query.TrendDays = days
+	}
+
+	stats, err := c.service.GetShortUrlStats(ctx.Context(), domainID, shortCode, userID, query)
+	if err != nil {
+		return c.handleStatsError(ctx, err)
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Short URL stats retrieved successfully", stats)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *AnalyticsController) GetTopReferrers(ctx *fiber.Ctx) error {
+	return c.getClickBreakdown(ctx, dto.ClickDimensionReferrer)
+}
+
+func (c *AnalyticsController) GetTopBrowsers(ctx *fiber.Ctx) error {
+	return c.getClickBreakdown(ctx, dto.ClickDimensionBrowser)
+}
+
+func (c *AnalyticsController) GetTopLanguages(ctx *fiber.Ctx) error {
+	return c.getClickBreakdown(ctx, dto.ClickDimensionLanguage)
+}
+
+// GetTopDestinations compares the split destinations of an A/B test link.
+func (c *AnalyticsController) GetTopDestinations(ctx *fiber.Ctx) error {
+	return c.getClickBreakdown(ctx, dto.ClickDimensionDestination)
+}
+
+func (c *AnalyticsController) getClickBreakdown(ctx *fiber.Ctx, dimension string) error {
+	shortCode := ctx.Params("shortCode")
+	if shortCode == "" {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Short code is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	domainID, ok := linkDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "domain_id must be a number")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	query := dto.ClickBreakdownQuery{
+		From:     ctx.Query("from"),
+		To:       ctx.Query("to"),
+		Timezone: ctx.Query("tz"),
+	}
+	if limitStr := ctx.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			response := dto.NewErrorResponse(fiber.StatusBadRequest, service.ErrInvalidLimit.Error())
+			return ctx.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		query.Limit = limit
+	}
+
+	breakdown, err := c.service.GetClickBreakdown(ctx.Context(), domainID, shortCode, userID, dimension, query)
+	if err != nil {
+		return c.handleStatsError(ctx, err)
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Short URL click breakdown retrieved successfully", breakdown)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *AnalyticsController) handleStatsError(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to retrieve short URL stats"
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status = fiber.StatusNotFound
+		message = "Short URL not found or access denied"
+	case errors.Is(err, service.ErrInvalidTimezone),
+		errors.Is(err, service.ErrInvalidStatsRange),
+		errors.Is(err, service.ErrInvalidTrendDays),
+		errors.Is(err, service.ErrInvalidLimit),
+		errors.Is(err, service.ErrInvalidDimension):
+		status = fiber.StatusBadRequest
+		message = err.Error()
+	}
+
+	response := dto.NewErrorResponse(status, message)
+	return ctx.Status(status).JSON(response)
+}
+
+func (c *AnalyticsController) RegisterRoutes(api fiber.Router) {
+	api.Get("/url/:shortCode/stats", c.GetShortUrlStats)
+	api.Get("/url/:shortCode/stats/referrers", c.GetTopReferrers)
+	api.Get("/url/:shortCode/stats/browsers", c.GetTopBrowsers)
+	api.Get("/url/:shortCode/stats/languages", c.GetTopLanguages)
+	api.Get("/url/:shortCode/stats/destinations", c.GetTopDestinations)
+}
@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"short-url/domains/dto"
+)
+
+const (
+	csvColumnLongUrl      = "long_url"
+	csvColumnAlias        = "alias"
+	csvColumnExpireAt     = "expire_at"
+	csvColumnTTL          = "ttl"
+	csvColumnFallbackUrl  = "fallback_url"
+	csvColumnMaxClicks    = "max_clicks"
+	csvColumnActiveFrom   = "active_from"
+	csvColumnRedirectType = "redirect_type"
+	csvColumnShortCode    = "short_code"
+	csvColumnError        = "error"
+
+	csvColumnUtmSource     = "utm_source"
+	csvColumnUtmMedium     = "utm_medium"
+	csvColumnUtmCampaign   = "utm_campaign"
+	csvColumnUtmTerm       = "utm_term"
+	csvColumnUtmContent    = "utm_content"
+	csvColumnUtmTemplateID = "utm_template_id"
+)
+
+// bulkCsv keeps the uploaded table as-is so the response can echo every
+// original column back with the results appended.
+type bulkCsv struct {
+	header  []string
+	records [][]string
+	columns map[string]int
+}
+
+func parseBulkCsv(r io.Reader) (*bulkCsv, []dto.CreateShortUrlRequest, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("CSV must start with a header row")
+	}
+
+	table := &bulkCsv{
+		header:  rows[0],
+		columns: make(map[string]int, len(rows[0])),
+	}
+	for i, name := range table.header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		table.columns[name] = i
+	}
+	if _, ok := table.columns[csvColumnLongUrl]; !ok {
+		return nil, nil, fmt.Errorf("CSV header must contain a long_url column")
+	}
+
+	reqs := make([]dto.CreateShortUrlRequest, 0, len(rows)-1)
+	for i, record := range rows[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+
+		req, err := table.toRequest(record)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		table.records = append(table.records, record)
+		reqs = append(reqs, req)
+	}
+
+	return table, reqs, nil
+}
+
+func (t *bulkCsv) value(record []string, column string) string {
+	i, ok := t.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func (t *bulkCsv) toRequest(record []string) (dto.CreateShortUrlRequest, error) {
+	req := dto.CreateShortUrlRequest{
+		LongUrl:     t.value(record, csvColumnLongUrl),
+		Alias:       t.value(record, csvColumnAlias),
+		FallbackUrl: t.value(record, csvColumnFallbackUrl),
+	}
+
+	if expireAt := t.value(record, csvColumnExpireAt); expireAt != "" {
+		parsed, err := time.Parse(time.RFC3339, expireAt)
+		if err != nil {
+			return req, fmt.Errorf("expire_at must be an RFC 3339 timestamp")
+		}
+		req.ExpireAt = &parsed
+	}
+
+	if ttl := t.value(record, csvColumnTTL); ttl != "" {
+		parsed, err := strconv.ParseInt(ttl, 10, 64)
+		if err != nil {
+			return req, fmt.Errorf("ttl must be a whole number of seconds")
+		}
+		req.TTL = parsed
+	}
+
+	if activeFrom := t.value(record, csvColumnActiveFrom); activeFrom != "" {
+		parsed, err := time.Parse(time.RFC3339, activeFrom)
+		if err != nil {
+			return req, fmt.Errorf("active_from must be an RFC 3339 timestamp")
+		}
+		req.ActiveFrom = &parsed
+	}
+
+	if maxClicks := t.value(record, csvColumnMaxClicks); maxClicks != "" {
+		parsed, err := strconv.ParseInt(maxClicks, 10, 64)
+		if err != nil {
+			return req, fmt.Errorf("max_clicks must be a whole number")
+		}
+		req.MaxClicks = parsed
+	}
+
+	if redirectType := t.value(record, csvColumnRedirectType); redirectType != "" {
+		parsed, err := strconv.Atoi(redirectType)
+		if err != nil {
+			return req, fmt.Errorf("redirect_type must be a whole number")
+		}
+		req.RedirectType = parsed
+	}
+
+	utm := dto.UtmParams{
+		Source:   t.value(record, csvColumnUtmSource),
+		Medium:   t.value(record, csvColumnUtmMedium),
+		Campaign: t.value(record, csvColumnUtmCampaign),
+		Term:     t.value(record, csvColumnUtmTerm),
+		Content:  t.value(record, csvColumnUtmContent),
+	}
+	if utm != (dto.UtmParams{}) {
+		req.Utm = &utm
+	}
+
+	if templateID := t.value(record, csvColumnUtmTemplateID); templateID != "" {
+		parsed, err := strconv.ParseUint(templateID, 10, 32)
+		if err != nil {
+			return req, fmt.Errorf("utm_template_id must be a whole number")
+		}
+		req.UtmTemplateID = uint(parsed)
+	}
+
+	return req, nil
+}
+
+// render writes the original table back with short_code and error columns
+// filled from results. Existing columns with those names are overwritten.
+func (t *bulkCsv) render(results []dto.BulkCreateShortUrlResult) ([]byte, error) {
+	header := append([]string{}, t.header...)
+	shortCodeColumn := t.ensureColumn(&header, csvColumnShortCode)
+	errorColumn := t.ensureColumn(&header, csvColumnError)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for i, record := range t.records {
+		row := make([]string, len(header))
+		copy(row, record)
+		if i < len(results) {
+			row[shortCodeColumn] = results[i].ShortCode
+			row[errorColumn] = results[i].Error
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func (t *bulkCsv) ensureColumn(header *[]string, name string) int {
+	if i, ok := t.columns[name]; ok {
+		return i
+	}
+	*header = append(*header, name)
+	return len(*header) - 1
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/service"
+	"short-url-service/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type CustomDomainController struct {
+	service service.CustomDomainServiceInterface
+}
+
+func NewCustomDomainController(service service.CustomDomainServiceInterface) *CustomDomainController {
+	return &CustomDomainController{
+		service: service,
+	}
+}
+
+func (c *CustomDomainController) CreateCustomDomain(ctx *fiber.Ctx) error {
+	var req dto.CreateCustomDomainRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid request body")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	domain, err := c.service.CreateCustomDomain(ctx.Context(), &req, userID)
+	if err != nil {
+		return c.handleCustomDomainError(ctx, err, "Failed to create custom domain")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusCreated, "Custom domain created successfully", toCustomDomainResponse(domain))
+	return ctx.Status(fiber.StatusCreated).JSON(response)
+}
+
+func (c *CustomDomainController) ListCustomDomains(ctx *fiber.Ctx) error {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	domains, err := c.service.ListCustomDomains(ctx.Context(), userID)
+	if err != nil {
+		return c.handleCustomDomainError(ctx, err, "Failed to retrieve custom domains")
+	}
+
+	responseData := make([]dto.CustomDomainResponse, len(domains))
+	for i := range domains {
+		responseData[i] = toCustomDomainResponse(&domains[i])
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Custom domains retrieved successfully", responseData)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *CustomDomainController) GetCustomDomain(ctx *fiber.Ctx) error {
+	id, ok := parseCustomDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid custom domain ID")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	domain, err := c.service.GetCustomDomain(ctx.Context(), id, userID)
+	if err != nil {
+		return c.handleCustomDomainError(ctx, err, "Failed to retrieve custom domain")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Custom domain retrieved successfully", toCustomDomainResponse(domain))
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *CustomDomainController) VerifyCustomDomain(ctx *fiber.Ctx) error {
+	id, ok := parseCustomDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid custom domain ID")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	domain, err := c.service.VerifyCustomDomain(ctx.Context(), id, userID)
+	if err != nil {
+		return c.handleCustomDomainError(ctx, err, "Failed to verify custom domain")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Custom domain verified successfully", toCustomDomainResponse(domain))
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *CustomDomainController) DeleteCustomDomain(ctx *fiber.Ctx) error {
+	id, ok := parseCustomDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid custom domain ID")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	if err := c.service.DeleteCustomDomain(ctx.Context(), id, userID); err != nil {
+		return c.handleCustomDomainError(ctx, err, "Failed to delete custom domain")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Custom domain deleted successfully", nil)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func parseCustomDomainID(ctx *fiber.Ctx) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (c *CustomDomainController) handleCustomDomainError(ctx *fiber.Ctx, err error, fallbackMessage string) error {
+	status := fiber.StatusInternalServerError
+	message := fallbackMessage
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status = fiber.StatusNotFound
+		message = "Custom domain not found"
+	case errors.Is(err, service.ErrInvalidCustomDomainHost),
+		errors.Is(err, service.ErrCustomDomainVerificationFailed):
+		status = fiber.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, service.ErrCustomDomainTaken),
+		errors.Is(err, service.ErrCustomDomainInUse):
+		status = fiber.StatusConflict
+		message = err.Error()
+	}
+
+	response := dto.NewErrorResponse(status, message)
+	return ctx.Status(status).JSON(response)
+}
+
+func (c *CustomDomainController) RegisterRoutes(api fiber.Router) {
+	api.Post("/domains", c.CreateCustomDomain)
+	api.Get("/domains", c.ListCustomDomains)
+	api.Get("/domains/:id", c.GetCustomDomain)
+	api.Post("/domains/:id/verify", c.VerifyCustomDomain)
+	api.Delete("/domains/:id", c.DeleteCustomDomain)
+}
+
+func toCustomDomainResponse(domain *entities.CustomDomain) dto.CustomDomainResponse {
+	return dto.CustomDomainResponse{
+		ID:         domain.ID,
+		Host:       domain.Host,
+		Verified:   domain.IsVerified(),
+		VerifiedAt: domain.VerifiedAt,
+		Verification: dto.CustomDomainVerification{
+			Token:        domain.VerificationToken,
+			TxtRecord:    domain.VerificationTxtName(),
+			WellKnownUrl: domain.VerificationWellKnownUrl(),
+		},
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+	}
+}
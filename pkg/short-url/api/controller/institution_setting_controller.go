@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/service"
+	"short-url-service/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type InstitutionSettingController struct {
+	service service.InstitutionSettingServiceInterface
+}
+
+func NewInstitutionSettingController(service service.InstitutionSettingServiceInterface) *InstitutionSettingController {
+	return &InstitutionSettingController{
+		service: service,
+	}
+}
+
+func (c *InstitutionSettingController) GetInstitutionSettings(ctx *fiber.Ctx) error {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	setting, err := c.service.GetInstitutionSettings(ctx.Context(), userID)
+	if err != nil {
+		return c.handleInstitutionSettingError(ctx, err, "Failed to retrieve institution settings")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Institution settings retrieved successfully", toInstitutionSettingsResponse(setting))
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *InstitutionSettingController) UpdateInstitutionSettings(ctx *fiber.Ctx) error {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	var req dto.UpdateInstitutionSettingsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid request body")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	setting, err := c.service.UpdateInstitutionSettings(ctx.Context(), &req, userID)
+	if err != nil {
+		return c.handleInstitutionSettingError(ctx, err, "Failed to update institution settings")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Institution settings updated successfully", toInstitutionSettingsResponse(setting))
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *InstitutionSettingController) handleInstitutionSettingError(ctx *fiber.Ctx, err error, fallbackMessage string) error {
+	status := fiber.StatusInternalServerError
+	message := fallbackMessage
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status = fiber.StatusNotFound
+		message = "User not found"
+	case errors.Is(err, service.ErrInvalidRedirectType):
+		status = fiber.StatusBadRequest
+		message = err.Error()
+	}
+
+	response := dto.NewErrorResponse(status, message)
+	return ctx.Status(status).JSON(response)
+}
+
+func (c *InstitutionSettingController) RegisterRoutes(api fiber.Router) {
+	api.Get("/institution/settings", c.GetInstitutionSettings)
+	api.Patch("/institution/settings", c.UpdateInstitutionSettings)
+}
+
+func toInstitutionSettingsResponse(setting *entities.InstitutionSetting) dto.InstitutionSettingsResponse {
+	return dto.InstitutionSettingsResponse{
+		RedirectType: setting.EffectiveRedirectType(),
+	}
+}
@@ -0,0 +1,30 @@
+package controller
+
+import "html/template"
+
+var passwordPage = template.Must(template.New("password").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+<style>
+body { font-family: sans-serif; max-width: 24rem; margin: 4rem auto; padding: 0 1rem; color: #222; }
+input, button { font-size: 1rem; padding: .5rem; }
+input { width: 100%; box-sizing: border-box; margin: .5rem 0 1rem; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>Password required</h1>
+<p>The short link <strong>{{.ShortCode}}</strong> is protected. Enter its password to continue.</p>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<form method="post">
+<label for="password">Password</label>
+<input id="password" name="password" type="password" autocomplete="current-password" required autofocus>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
@@ -0,0 +1,37 @@
+package controller
+
+import "html/template"
+
+var previewPage = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link preview</title>
+<style>
+body { font-family: sans-serif; max-width: 40rem; margin: 4rem auto; padding: 0 1rem; color: #222; }
+dt { font-weight: bold; margin-top: .75rem; }
+dd { margin: .25rem 0 0; word-break: break-all; }
+button { font-size: 1rem; padding: .5rem 1rem; margin-top: 1.5rem; }
+.notice { padding: .75rem; background: #fff4e5; border-left: 4px solid #e67e00; }
+</style>
+</head>
+<body>
+<h1>Where this link goes</h1>
+<p>The short link <strong>{{.ShortCode}}</strong> will take you to the site below. Check it before you continue.</p>
+{{if .IsNew}}<p class="notice">This link was created less than a day ago. Only continue if you trust whoever sent it to you.</p>{{end}}
+<dl>
+<dt>Destination</dt>
+<dd>{{.Host}}</dd>
+<dt>Safety check</dt>
+<dd>{{.Safety}}</dd>
+<dt>Created</dt>
+<dd>{{.CreatedAt}}</dd>
+</dl>
+<form method="post" action="{{.ContinueUrl}}">
+<button type="submit">Continue to {{.Host}}</button>
+</form>
+</body>
+</html>
+`))
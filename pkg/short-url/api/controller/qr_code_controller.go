@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"short-url/domains/dto"
+	"short-url/domains/service"
+	"short-url-service/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// qrCacheControl lets browsers and print tooling reuse an image for a day;
+// the ETag covers anything served after that.
+const qrCacheControl = "private, max-age=86400"
+
+type QrCodeController struct {
+	service service.QrCodeServiceInterface
+}
+
+func NewQrCodeController(service service.QrCodeServiceInterface) *QrCodeController {
+	return &QrCodeController{
+		service: service,
+	}
+}
+
+// GetQrCode renders the public short URL as a PNG or SVG image. The format
+// comes from ?format= and falls back to the Accept header.
+func (c *QrCodeController) GetQrCode(ctx *fiber.Ctx) error {
+	shortCode := ctx.Params("shortCode")
+	if shortCode == "" {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Short code is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	domainID, ok := linkDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "domain_id must be a number")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	opts := dto.QrCodeOptions{
+		Format:          ctx.Query("format"),
+		ErrorCorrection: ctx.Query("ecl"),
+		Foreground:      ctx.Query("fg"),
+		Background:      ctx.Query("bg"),
+	}
+	if opts.Format == "" && strings.Contains(ctx.Get(fiber.HeaderAccept), "image/svg+xml") {
+		opts.Format = dto.QrCodeFormatSVG
+	}
+	if sizeStr := ctx.Query("size"); sizeStr != "" {
+		size, err := strconv.Atoi(sizeStr)
+		if err != nil {
+			response := dto.NewErrorResponse(fiber.StatusBadRequest, service.ErrInvalidQrSize.Error())
+			return ctx.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		opts.Size = size
+	}
+	if marginStr := ctx.Query("margin"); marginStr != "" {
+		margin, err := strconv.Atoi(marginStr)
+		if err != nil {
+			response := dto.NewErrorResponse(fiber.StatusBadRequest, service.ErrInvalidQrMargin.Error())
+			return ctx.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		opts.Margin = &margin
+	}
+
+	image, err := c.service.GetQrCode(ctx.Context(), domainID, shortCode, userID, opts)
+	if err != nil {
+		return c.handleQrCodeError(ctx, err)
+	}
+
+	sum := sha256.Sum256(image.Data)
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:16]))
+	ctx.Set(fiber.HeaderCacheControl, qrCacheControl)
+	ctx.Set(fiber.HeaderETag, etag)
+	if ctx.Get(fiber.HeaderIfNoneMatch) == etag {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
+
+	if download, _ := strconv.ParseBool(ctx.Query("download")); download {
+		extension := dto.QrCodeFormatPNG
+		if image.ContentType == "image/svg+xml" {
+			extension = dto.QrCodeFormatSVG
+		}
+		ctx.Attachment(fmt.Sprintf("%s.%s", shortCode, extension))
+	}
+
+	ctx.Set(fiber.HeaderContentType, image.ContentType)
+	return ctx.Status(fiber.StatusOK).Send(image.Data)
+}
+
+func (c *QrCodeController) handleQrCodeError(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to generate QR code"
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status = fiber.StatusNotFound
+		message = "Short URL not found or access denied"
+	case errors.Is(err, service.ErrInvalidQrFormat),
+		errors.Is(err, service.ErrInvalidQrSize),
+		errors.Is(err, service.ErrInvalidQrMargin),
+		errors.Is(err, service.ErrInvalidQrLevel),
+		errors.Is(err, service.ErrInvalidQrColor):
+		status = fiber.StatusBadRequest
+		message = err.Error()
+	}
+
+	response := dto.NewErrorResponse(status, message)
+	return ctx.Status(status).JSON(response)
+}
+
+func (c *QrCodeController) RegisterRoutes(api fiber.Router) {
+	api.Get("/url/:shortCode/qr", c.GetQrCode)
+}
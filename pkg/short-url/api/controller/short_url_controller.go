@@ -1,20 +1,68 @@
 package controller
 
 import (
+	"bytes"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
 	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/helper"
 	"short-url/domains/service"
 	"short-url-service/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// linkAccessCookiePrefix names the cookie holding the access token of an
+	// unlocked password protected link; the short code is appended.
+	linkAccessCookiePrefix = "link_access_"
+
+	// linkDestinationCookiePrefix names the cookie remembering which split
+	// destination a visitor of a sticky link was sent to.
+	linkDestinationCookiePrefix = "link_destination_"
+	linkDestinationCookieMaxAge = 30 * 24 * time.Hour
+
+	// previewSuffix after a short code asks for the interstitial preview page
+	// instead of the redirect.
+	previewSuffix = "+"
+	// newLinkAge is how long a link counts as new on the preview page.
+	newLinkAge = 24 * time.Hour
+
+	// permanentRedirectMaxAge bounds how long clients keep a permanent
+	// redirect, so an edited link is picked up again within that time.
+	permanentRedirectMaxAge = time.Hour
 )
 
 type ShortUrlController struct {
-	service service.ShortUrlServiceInterface
+	service            service.ShortUrlServiceInterface
+	clickEventRecorder service.ClickEventRecorderServiceInterface
+	accessService      service.ShortUrlAccessServiceInterface
+	unavailable        dto.UnavailableLinkConfig
 }
 
-func NewShortUrlController(service service.ShortUrlServiceInterface) *ShortUrlController {
+// NewShortUrlController wires the link endpoints. unavailable sets how the
+// public redirect answers outside a link's schedule; a zero Status means 403.
+func NewShortUrlController(
+	service service.ShortUrlServiceInterface,
+	clickEventRecorder service.ClickEventRecorderServiceInterface,
+	accessService service.ShortUrlAccessServiceInterface,
+	unavailable dto.UnavailableLinkConfig,
+) *ShortUrlController {
+	if unavailable.Status == 0 {
+		unavailable.Status = fiber.StatusForbidden
+	}
 	return &ShortUrlController{
-		service: service,
+		service:            service,
+		clickEventRecorder: clickEventRecorder,
+		accessService:      accessService,
+		unavailable:        unavailable,
 	}
 }
 
@@ -32,23 +80,176 @@ func (c *ShortUrlController) CreateShortUrl(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
 	}
 
-	shortUrl, err := c.service.CreateShortUrl(ctx.Context(), req.LongUrl, userID)
-	if err != nil {
-		response := dto.NewErrorResponse(fiber.StatusInternalServerError, "Failed to create short URL")
-		return ctx.Status(fiber.StatusInternalServerError).JSON(response)
+	shortUrl, err := c.service.CreateShortUrl(ctx.Context(), &req, userID)
+	deduplicated := errors.Is(err, service.ErrDuplicateLongUrl)
+	if err != nil && !deduplicated {
+		return c.handleCreateError(ctx, err)
 	}
 
 	responseData := dto.CreateShortUrlResponse{
-		ID:        shortUrl.ID,
-		ShortCode: shortUrl.ShortCode,
-		LongUrl:   shortUrl.LongUrl,
-		UserID:    shortUrl.UserID,
+		ID:                 shortUrl.ID,
+		ShortCode:          shortUrl.ShortCode,
+		LongUrl:            shortUrl.LongUrl,
+		UserID:             shortUrl.UserID,
+		DomainID:           shortUrl.DomainID,
+		Domain:             domainHost(shortUrl),
+		ExpireAt:           shortUrl.ExpireAt,
+		FallbackUrl:        shortUrl.FallbackUrl,
+		ActiveFrom:         shortUrl.ActiveFrom,
+		Availability:       toAvailabilityWindowResponse(shortUrl.Availability),
+		Safety:             toUrlSafetyVerdict(shortUrl.UrlSafety),
+		PasswordProtected:  shortUrl.IsPasswordProtected(),
+		MaxClicks:          shortUrl.MaxClicks,
+		RemainingClicks:    shortUrl.RemainingClicks,
+		ForwardQuery:       shortUrl.ForwardQuery,
+		ForwardPath:        shortUrl.ForwardPath,
+		Utm:                toUtmParamsResponse(shortUrl.Utm),
+		TargetingRules:     toTargetingRulesResponse(shortUrl.TargetingRules),
+		Destinations:       toDestinationsResponse(shortUrl.Destinations),
+		StickyDestinations: shortUrl.StickyDestinations,
+		Preview:            shortUrl.Preview,
+		RedirectType:       shortUrl.RedirectStatus(),
+	}
+
+	if deduplicated {
+		response := dto.NewSuccessResponse(fiber.StatusOK, "Existing short URL returned", responseData)
+		return ctx.Status(fiber.StatusOK).JSON(response)
 	}
 
 	response := dto.NewSuccessResponse(fiber.StatusCreated, "Short URL created successfully", responseData)
 	return ctx.Status(fiber.StatusCreated).JSON(response)
 }
 
+// BulkCreateShortUrls accepts a JSON array, a text/csv body or a multipart
+// upload with a "file" field, and answers in the same format it was sent.
+func (c *ShortUrlController) BulkCreateShortUrls(ctx *fiber.Ctx) error {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	atomic := false
+	if atomicStr := ctx.Query("atomic"); atomicStr != "" {
+		parsed, err := strconv.ParseBool(atomicStr)
+		if err != nil {
+			response := dto.NewErrorResponse(fiber.StatusBadRequest, "atomic must be true or false")
+			return ctx.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		atomic = parsed
+	}
+
+	var table *bulkCsv
+	var reqs []dto.CreateShortUrlRequest
+	var err error
+
+	contentType := strings.ToLower(ctx.Get(fiber.HeaderContentType))
+	switch {
+	case strings.HasPrefix(contentType, fiber.MIMEMultipartForm):
+		fileHeader, formErr := ctx.FormFile("file")
+		if formErr != nil {
+			response := dto.NewErrorResponse(fiber.StatusBadRequest, "A CSV file must be uploaded in the \"file\" field")
+			return ctx.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		file, openErr := fileHeader.Open()
+		if openErr != nil {
+			response := dto.NewErrorResponse(fiber.StatusBadRequest, "Failed to read uploaded file")
+			return ctx.Status(fiber.StatusBadRequest).JSON(response)
+		}
+		defer file.Close()
+		table, reqs, err = parseBulkCsv(file)
+	case strings.HasPrefix(contentType, "text/csv"):
+		table, reqs, err = parseBulkCsv(bytes.NewReader(ctx.Body()))
+	default:
+		if parseErr := ctx.BodyParser(&reqs); parseErr != nil {
+			response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid request body, expected a JSON array of short URLs")
+			return ctx.Status(fiber.StatusBadRequest).JSON(response)
+		}
+	}
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, err.Error())
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	result, err := c.service.BulkCreateShortUrls(ctx.Context(), reqs, userID, atomic)
+
+	status := fiber.StatusCreated
+	message := "Short URLs created successfully"
+	switch {
+	case errors.Is(err, service.ErrBulkCreateAborted):
+		status = fiber.StatusUnprocessableEntity
+		message = err.Error()
+	case errors.Is(err, service.ErrInvalidBulkSize):
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, err.Error())
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	case errors.Is(err, service.ErrAliasTaken):
+		response := dto.NewErrorResponse(fiber.StatusConflict, err.Error())
+		return ctx.Status(fiber.StatusConflict).JSON(response)
+	case errors.Is(err, service.ErrShortCodeExhausted):
+		response := dto.NewErrorResponse(fiber.StatusServiceUnavailable, err.Error())
+		return ctx.Status(fiber.StatusServiceUnavailable).JSON(response)
+	case err != nil:
+		response := dto.NewErrorResponse(fiber.StatusInternalServerError, "Failed to create short URLs")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(response)
+	case result.Failed > 0:
+		status = fiber.StatusMultiStatus
+		message = "Some short URLs could not be created"
+	}
+
+	if table != nil {
+		body, renderErr := table.render(result.Results)
+		if renderErr != nil {
+			response := dto.NewErrorResponse(fiber.StatusInternalServerError, "Failed to render CSV response")
+			return ctx.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return ctx.Status(status).Send(body)
+	}
+
+	response := dto.NewSuccessResponse(status, message, result)
+	if status == fiber.StatusUnprocessableEntity {
+		response = dto.NewErrorResponse(status, message)
+		response.Data = result
+	}
+	return ctx.Status(status).JSON(response)
+}
+
+func (c *ShortUrlController) ListShortUrls(ctx *fiber.Ctx) error {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	filter, err := c.parseListFilter(ctx)
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, err.Error())
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	filter.UserID = &userID
+
+	pagination := c.parsePagination(ctx)
+
+	shortUrls, paginationResponse, err := c.service.GetByFilter(ctx.Context(), filter, pagination)
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusInternalServerError, "Failed to list short URLs")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	items := make([]dto.ShortUrlResponse, 0, len(shortUrls))
+	for i := range shortUrls {
+		items = append(items, toShortUrlResponse(&shortUrls[i]))
+	}
+
+	responseData := map[string]interface{}{
+		"short_urls": items,
+		"pagination": paginationResponse,
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Short URLs retrieved successfully", responseData)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
 func (c *ShortUrlController) GetLongUrl(ctx *fiber.Ctx) error {
 	shortCode := ctx.Params("shortCode")
 	if shortCode == "" {
@@ -56,13 +257,19 @@ func (c *ShortUrlController) GetLongUrl(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
+	domainID, ok := linkDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "domain_id must be a number")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
 	userID := middleware.GetUserIDFromContext(ctx)
 	if userID == 0 {
 		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
 		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
 	}
 
-	shortUrl, err := c.service.GetByShortCode(ctx.Context(), shortCode, userID)
+	shortUrl, err := c.service.GetByShortCode(ctx.Context(), domainID, shortCode, userID)
 	if err != nil {
 		response := dto.NewErrorResponse(fiber.StatusNotFound, "Short URL not found or access denied")
 		return ctx.Status(fiber.StatusNotFound).JSON(response)
@@ -80,22 +287,198 @@ func (c *ShortUrlController) GetLongUrl(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusOK).JSON(response)
 	}
 
-	return ctx.Redirect(shortUrl.LongUrl, fiber.StatusFound)
+	status := shortUrl.RedirectStatus()
+	c.recordClick(shortUrl.ID)
+	ctx.Set(fiber.HeaderCacheControl, redirectCacheControl(shortUrl, status, "private", time.Now()))
+	return ctx.Redirect(shortUrl.LongUrl, status)
 }
 
-func (c *ShortUrlController) PublicRedirect(ctx *fiber.Ctx) error {
+func (c *ShortUrlController) UpdateShortUrl(ctx *fiber.Ctx) error {
+	shortCode := ctx.Params("shortCode")
+	if shortCode == "" {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Short code is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	domainID, ok := linkDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "domain_id must be a number")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	var req dto.UpdateShortUrlRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid request body")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	shortUrl, err := c.service.UpdateShortUrl(ctx.Context(), domainID, shortCode, &req, userID)
+	if err != nil {
+		return c.handleMutationError(ctx, err, "Failed to update short URL")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Short URL updated successfully", toShortUrlResponse(shortUrl))
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *ShortUrlController) DeleteShortUrl(ctx *fiber.Ctx) error {
 	shortCode := ctx.Params("shortCode")
 	if shortCode == "" {
 		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Short code is required")
 		return ctx.Status(fiber.StatusBadRequest).JSON(response)
 	}
 
-	shortUrl, err := c.service.GetByShortCodePublic(ctx.Context(), shortCode)
+	domainID, ok := linkDomainID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "domain_id must be a number")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	if err := c.service.DeleteShortUrl(ctx.Context(), domainID, shortCode, userID); err != nil {
+		return c.handleMutationError(ctx, err, "Failed to delete short URL")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "Short URL deleted successfully", nil)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *ShortUrlController) PublicRedirect(ctx *fiber.Ctx) error {
+	shortCode, previewRequested := strings.CutSuffix(ctx.Params("shortCode"), previewSuffix)
+	if shortCode == "" {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Short code is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	shortUrl, err := c.service.GetByShortCodePublic(ctx.Context(), ctx.Hostname(), shortCode)
+	if unforwardedPath(ctx, shortUrl) {
+		response := dto.NewErrorResponse(fiber.StatusNotFound, "Short URL not found")
+		return ctx.Status(fiber.StatusNotFound).JSON(response)
+	}
+	if errors.Is(err, service.ErrShortUrlPasswordRequired) {
+		if !c.accessService.HasAccess(shortUrl, ctx.Cookies(linkAccessCookieName(shortUrl.ShortCode))) {
+			return c.handlePasswordRequired(ctx, shortUrl, fiber.StatusUnauthorized, "")
+		}
+		err = unlockedLinkError(shortUrl)
+	}
+	if errors.Is(err, service.ErrShortUrlExpired) {
+		return c.handleExpired(ctx, shortUrl, "Short URL has expired")
+	}
+	if errors.Is(err, service.ErrShortUrlClickLimitReached) {
+		return c.handleExpired(ctx, shortUrl, "Short URL has reached its click limit")
+	}
+	if errors.Is(err, service.ErrShortUrlNotAvailable) {
+		return c.handleNotAvailable(ctx, shortUrl)
+	}
+	if errors.Is(err, service.ErrShortUrlUnsafe) {
+		return c.handleUnsafe(ctx, shortUrl)
+	}
 	if err != nil {
 		response := dto.NewErrorResponse(fiber.StatusNotFound, "Short URL not found")
 		return ctx.Status(fiber.StatusNotFound).JSON(response)
 	}
 
+	if (previewRequested || shortUrl.Preview) && ctx.Get("Accept") != "application/json" {
+		return c.handlePreview(ctx, shortUrl)
+	}
+	return c.redirectToDestination(ctx, shortUrl, shortUrl.RedirectStatus())
+}
+
+// UnlockShortUrl handles the password form of a protected link and the
+// continue button of the preview page. A correct password sets a signed
+// access cookie and redirects with 303 See Other, so the browser follows up
+// with a GET and reloading does not post again. That GET shows the preview
+// page first when one was asked for. The link's redirect type is not used
+// here: 307 and 308 would repeat the POST at the destination.
+func (c *ShortUrlController) UnlockShortUrl(ctx *fiber.Ctx) error {
+	shortCode, previewRequested := strings.CutSuffix(ctx.Params("shortCode"), previewSuffix)
+	if shortCode == "" {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Short code is required")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	shortUrl, err := c.service.GetByShortCodePublic(ctx.Context(), ctx.Hostname(), shortCode)
+	if unforwardedPath(ctx, shortUrl) {
+		response := dto.NewErrorResponse(fiber.StatusNotFound, "Short URL not found")
+		return ctx.Status(fiber.StatusNotFound).JSON(response)
+	}
+	if errors.Is(err, service.ErrShortUrlExpired) {
+		return c.handleExpired(ctx, shortUrl, "Short URL has expired")
+	}
+	if errors.Is(err, service.ErrShortUrlClickLimitReached) {
+		return c.handleExpired(ctx, shortUrl, "Short URL has reached its click limit")
+	}
+	if errors.Is(err, service.ErrShortUrlNotAvailable) {
+		return c.handleNotAvailable(ctx, shortUrl)
+	}
+	if errors.Is(err, service.ErrShortUrlUnsafe) {
+		return c.handleUnsafe(ctx, shortUrl)
+	}
+	if err == nil {
+		return c.redirectToDestination(ctx, shortUrl, fiber.StatusSeeOther)
+	}
+	if errors.Is(err, service.ErrShortUrlPasswordRequired) && c.accessService.HasAccess(shortUrl, ctx.Cookies(linkAccessCookieName(shortUrl.ShortCode))) {
+		if shortUrl.IsFlaggedUnsafe() {
+			return c.handleUnsafe(ctx, shortUrl)
+		}
+		return c.redirectToDestination(ctx, shortUrl, fiber.StatusSeeOther)
+	}
+	if !errors.Is(err, service.ErrShortUrlPasswordRequired) {
+		response := dto.NewErrorResponse(fiber.StatusNotFound, "Short URL not found")
+		return ctx.Status(fiber.StatusNotFound).JSON(response)
+	}
+
+	var req dto.UnlockShortUrlRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid request body")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	token, expiresAt, err := c.accessService.Unlock(ctx.Context(), shortUrl, req.Password, ctx.IP())
+	switch {
+	case errors.Is(err, service.ErrIncorrectLinkPassword):
+		return c.handlePasswordRequired(ctx, shortUrl, fiber.StatusUnauthorized, "Incorrect password")
+	case errors.Is(err, service.ErrTooManyPasswordAttempts):
+		return c.handlePasswordRequired(ctx, shortUrl, fiber.StatusTooManyRequests, "Too many password attempts, please try again later")
+	case err != nil:
+		response := dto.NewErrorResponse(fiber.StatusInternalServerError, "Failed to check password")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	ctx.Cookie(&fiber.Cookie{
+		Name:     linkAccessCookieName(shortUrl.ShortCode),
+		Value:    token,
+		Path:     "/",
+		Expires:  expiresAt,
+		Secure:   ctx.Protocol() == "https",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	if shortUrl.IsFlaggedUnsafe() {
+		return c.handleUnsafe(ctx, shortUrl)
+	}
+	if (previewRequested || shortUrl.Preview) && ctx.Get("Accept") != "application/json" {
+		return ctx.Redirect(ctx.OriginalURL(), fiber.StatusSeeOther)
+	}
+	return c.redirectToDestination(ctx, shortUrl, fiber.StatusSeeOther)
+}
+
+// redirectToDestination counts the click and redirects, or describes the link
+// instead when the client asked for JSON. Only a redirect spends a click of a
+// click-limited link.
+func (c *ShortUrlController) redirectToDestination(ctx *fiber.Ctx, shortUrl *entities.ShortUrl, status int) error {
 	acceptHeader := ctx.Get("Accept")
 	if acceptHeader == "application/json" {
 		responseData := map[string]interface{}{
@@ -108,9 +491,578 @@ func (c *ShortUrlController) PublicRedirect(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusOK).JSON(response)
 	}
 
-	return ctx.Redirect(shortUrl.LongUrl, fiber.StatusFound)
+	destination, err := c.forwardedDestination(ctx, shortUrl)
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid path or query string")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	err = c.service.ConsumeClick(ctx.Context(), shortUrl)
+	if errors.Is(err, service.ErrShortUrlClickLimitReached) {
+		return c.handleExpired(ctx, shortUrl, "Short URL has reached its click limit")
+	}
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusInternalServerError, "Failed to resolve short URL")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	c.rememberDestination(ctx, shortUrl, destination.DestinationID)
+	c.recordClick(shortUrl.ID)
+	c.recordClickEvent(ctx, shortUrl.ID, destination.DestinationID)
+	// The same URL answers with JSON for clients that ask for it.
+	ctx.Vary(fiber.HeaderAccept)
+	ctx.Set(fiber.HeaderCacheControl, redirectCacheControl(shortUrl, status, "public", time.Now()))
+	return ctx.Redirect(destination.Url, status)
+}
+
+// redirectCacheControl lets clients keep a permanent redirect for at most
+// permanentRedirectMaxAge and never past the link's expiry. Temporary
+// redirects, and links whose next visit may go elsewhere or has to be
+// checked, are not stored at all. Clicks served from a client's cache are
+// not counted.
+func redirectCacheControl(shortUrl *entities.ShortUrl, status int, scope string, now time.Time) string {
+	if !entities.IsPermanentRedirect(status) || !isStaticRedirect(shortUrl) {
+		return "no-store"
+	}
+
+	maxAge := permanentRedirectMaxAge
+	if shortUrl.ExpireAt != nil {
+		maxAge = min(maxAge, shortUrl.ExpireAt.Sub(now))
+	}
+	if maxAge < time.Second {
+		return "no-store"
+	}
+	return scope + ", max-age=" + strconv.Itoa(int(maxAge.Seconds()))
+}
+
+// isStaticRedirect reports whether every visit of the same URL is sent to the
+// same destination without a check that a cached redirect would skip.
+func isStaticRedirect(shortUrl *entities.ShortUrl) bool {
+	return len(shortUrl.TargetingRules) == 0 &&
+		!shortUrl.HasSplitDestinations() &&
+		!shortUrl.HasClickLimit() &&
+		!shortUrl.IsPasswordProtected() &&
+		!shortUrl.Preview &&
+		shortUrl.Availability == nil
+}
+
+// forwardedDestination is the destination the targeting rules or the traffic
+// split pick for the visitor with the path after the short code and the
+// query string of the request added, as far as the link forwards them, and
+// its UTM values on top. The stored destinations are never changed.
+func (c *ShortUrlController) forwardedDestination(ctx *fiber.Ctx, shortUrl *entities.ShortUrl) (dto.RedirectDestination, error) {
+	visitor := dto.RedirectVisitor{
+		UserAgent:      ctx.Get(fiber.HeaderUserAgent),
+		AcceptLanguage: ctx.Get(fiber.HeaderAcceptLanguage),
+		IP:             ctx.IP(),
+	}
+	if shortUrl.StickyDestinations {
+		if id, err := strconv.ParseUint(ctx.Cookies(linkDestinationCookieName(shortUrl.ShortCode)), 10, 64); err == nil {
+			visitor.DestinationID = uint(id)
+		}
+	}
+	destination := c.service.SelectDestination(ctx.Context(), shortUrl, visitor)
+
+	var err error
+	if shortUrl.ForwardPath {
+		destination.Url, err = helper.ForwardPath(destination.Url, ctx.Params("*"))
+		if err != nil {
+			return destination, err
+		}
+	}
+	if shortUrl.ForwardQuery {
+		destination.Url, err = helper.ForwardQuery(destination.Url, string(ctx.Request().URI().QueryString()))
+		if err != nil {
+			return destination, err
+		}
+	}
+	if !shortUrl.Utm.IsEmpty() {
+		destination.Url, err = helper.ForwardQuery(destination.Url, shortUrl.Utm.Encode())
+		if err != nil {
+			return destination, err
+		}
+	}
+	return destination, nil
+}
+
+// rememberDestination sets the cookie that keeps a visitor of a sticky link
+// on the split destination they were sent to.
+func (c *ShortUrlController) rememberDestination(ctx *fiber.Ctx, shortUrl *entities.ShortUrl, destinationID uint) {
+	if !shortUrl.StickyDestinations || destinationID == 0 {
+		return
+	}
+	ctx.Cookie(&fiber.Cookie{
+		Name:     linkDestinationCookieName(shortUrl.ShortCode),
+		Value:    strconv.FormatUint(uint64(destinationID), 10),
+		Path:     "/",
+		MaxAge:   int(linkDestinationCookieMaxAge.Seconds()),
+		Secure:   ctx.Protocol() == "https",
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+// unforwardedPath reports whether the request has path segments after the
+// short code that the link does not forward. Such requests are answered as
+// if the link did not exist.
+func unforwardedPath(ctx *fiber.Ctx, shortUrl *entities.ShortUrl) bool {
+	return shortUrl != nil && ctx.Params("*") != "" && !shortUrl.ForwardPath
+}
+
+// recordClick queues the hit for the click counter so a slow Redis never
+// delays the redirect itself.
+func (c *ShortUrlController) recordClick(shortUrlID uint) {
+	c.service.RecordClick(shortUrlID)
+}
+
+// recordClickEvent queues the request details for the raw click log. The
+// headers are copied because fiber reuses the request buffers.
+func (c *ShortUrlController) recordClickEvent(ctx *fiber.Ctx, shortUrlID uint, destinationID uint) {
+	if c.clickEventRecorder == nil {
+		return
+	}
+
+	c.clickEventRecorder.Record(dto.ClickEventInput{
+		ShortUrlID:     shortUrlID,
+		ClickedAt:      time.Now(),
+		Referrer:       utils.CopyString(ctx.Get(fiber.HeaderReferer)),
+		UserAgent:      utils.CopyString(ctx.Get(fiber.HeaderUserAgent)),
+		AcceptLanguage: utils.CopyString(ctx.Get(fiber.HeaderAcceptLanguage)),
+		IP:             utils.CopyString(ctx.IP()),
+		DestinationID:  destinationID,
+	})
+}
+
+// handleExpired answers for a link that no longer redirects, either because
+// it expired or because it used up its click limit. The fallback is scanned
+// with the link's other destinations, so a link flagged as unsafe does not
+// send visitors there.
+func (c *ShortUrlController) handleExpired(ctx *fiber.Ctx, shortUrl *entities.ShortUrl, message string) error {
+	if shortUrl.FallbackUrl != nil && !shortUrl.IsFlaggedUnsafe() && ctx.Get("Accept") != "application/json" {
+		return ctx.Redirect(*shortUrl.FallbackUrl, fiber.StatusFound)
+	}
+
+	response := dto.NewErrorResponse(fiber.StatusGone, message)
+	return ctx.Status(fiber.StatusGone).JSON(response)
+}
+
+// handleNotAvailable answers for a link outside its schedule. Retry-After and
+// available_at tell clients when it opens next, if that is known.
+func (c *ShortUrlController) handleNotAvailable(ctx *fiber.Ctx, shortUrl *entities.ShortUrl) error {
+	now := time.Now()
+	availableAt := shortUrl.NextAvailableAt(now)
+	if availableAt != nil {
+		ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(availableAt.Sub(now).Seconds())+1))
+	}
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+
+	if c.unavailable.RedirectUrl != "" && ctx.Get("Accept") != "application/json" {
+		return ctx.Redirect(c.unavailable.RedirectUrl, fiber.StatusFound)
+	}
+
+	message := "Short URL is not available right now"
+	if shortUrl.ActiveFrom != nil && now.Before(*shortUrl.ActiveFrom) {
+		message = "Short URL is not available yet"
+	}
+	response := dto.NewErrorResponse(c.unavailable.Status, message)
+	if availableAt != nil {
+		response.Data = map[string]interface{}{"available_at": availableAt}
+	}
+	return ctx.Status(c.unavailable.Status).JSON(response)
+}
+
+// handleUnsafe answers with a warning page instead of redirecting. The page
+// shows the destination as text only, so it cannot be followed by accident.
+func (c *ShortUrlController) handleUnsafe(ctx *fiber.Ctx, shortUrl *entities.ShortUrl) error {
+	if ctx.Get("Accept") == "application/json" {
+		response := dto.NewErrorResponse(fiber.StatusForbidden, "Short URL destination was flagged as unsafe")
+		return ctx.Status(fiber.StatusForbidden).JSON(response)
+	}
+
+	reason := ""
+	if shortUrl.UrlSafety != nil {
+		reason = shortUrl.UrlSafety.Reason
+	}
+
+	var page bytes.Buffer
+	err := unsafeWarningPage.Execute(&page, map[string]string{
+		"ShortCode":   shortUrl.ShortCode,
+		"Destination": shortUrl.LongUrl,
+		"Reason":      reason,
+	})
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusForbidden, "Short URL destination was flagged as unsafe")
+		return ctx.Status(fiber.StatusForbidden).JSON(response)
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+	ctx.Type("html", "utf-8")
+	return ctx.Status(fiber.StatusForbidden).Send(page.Bytes())
+}
+
+// handlePreview shows the interstitial page for a link instead of redirecting.
+// Its continue button posts to the link without the preview suffix, which
+// redirects as usual; only that redirect counts as a click.
+func (c *ShortUrlController) handlePreview(ctx *fiber.Ctx, shortUrl *entities.ShortUrl) error {
+	destination, err := c.forwardedDestination(ctx, shortUrl)
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid path or query string")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+	host := destination.Url
+	if parsed, err := url.Parse(destination.Url); err == nil && parsed.Host != "" {
+		host = parsed.Hostname()
+	}
+
+	safety := "Not checked yet"
+	if shortUrl.UrlSafety != nil && shortUrl.UrlSafety.IsSafe {
+		safety = "Passed our safety checks on " + shortUrl.UrlSafety.CheckedAt.UTC().Format("2 January 2006")
+	}
+
+	continueUrl := strings.Replace(ctx.Path(), shortUrl.ShortCode+previewSuffix, shortUrl.ShortCode, 1)
+	if query := ctx.Request().URI().QueryString(); len(query) > 0 {
+		continueUrl += "?" + string(query)
+	}
+
+	var page bytes.Buffer
+	err = previewPage.Execute(&page, map[string]interface{}{
+		"ShortCode":   shortUrl.ShortCode,
+		"Host":        host,
+		"Safety":      safety,
+		"CreatedAt":   shortUrl.CreatedAt.UTC().Format("2 January 2006"),
+		"IsNew":       time.Since(shortUrl.CreatedAt) < newLinkAge,
+		"ContinueUrl": continueUrl,
+	})
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusInternalServerError, "Failed to render preview page")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+	ctx.Type("html", "utf-8")
+	return ctx.Status(fiber.StatusOK).Send(page.Bytes())
+}
+
+// handlePasswordRequired shows the password form, with message as the error
+// from a previous attempt if there was one.
+func (c *ShortUrlController) handlePasswordRequired(ctx *fiber.Ctx, shortUrl *entities.ShortUrl, status int, message string) error {
+	if ctx.Get("Accept") == "application/json" {
+		if message == "" {
+			message = "Short URL is password protected"
+		}
+		response := dto.NewErrorResponse(status, message)
+		return ctx.Status(status).JSON(response)
+	}
+
+	var page bytes.Buffer
+	err := passwordPage.Execute(&page, map[string]string{
+		"ShortCode": shortUrl.ShortCode,
+		"Error":     message,
+	})
+	if err != nil {
+		response := dto.NewErrorResponse(fiber.StatusInternalServerError, "Failed to render password form")
+		return ctx.Status(fiber.StatusInternalServerError).JSON(response)
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+	ctx.Type("html", "utf-8")
+	return ctx.Status(status).Send(page.Bytes())
+}
+
+// unlockedLinkError is what GetByShortCodePublic would have returned for a
+// protected link had it not stopped at the password check.
+func unlockedLinkError(shortUrl *entities.ShortUrl) error {
+	if shortUrl.IsFlaggedUnsafe() {
+		return service.ErrShortUrlUnsafe
+	}
+	return nil
+}
+
+func linkAccessCookieName(shortCode string) string {
+	return linkAccessCookiePrefix + shortCode
+}
+
+func linkDestinationCookieName(shortCode string) string {
+	return linkDestinationCookiePrefix + shortCode
+}
+
+func (c *ShortUrlController) handleMutationError(ctx *fiber.Ctx, err error, fallbackMessage string) error {
+	status := fiber.StatusInternalServerError
+	message := fallbackMessage
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status = fiber.StatusNotFound
+		message = "Short URL not found or access denied"
+	case errors.Is(err, service.ErrInvalidExpiry),
+		errors.Is(err, service.ErrConflictingExpiry),
+		errors.Is(err, service.ErrInvalidLinkPassword),
+		errors.Is(err, service.ErrInvalidMaxClicks),
+		errors.Is(err, service.ErrInvalidActiveFrom),
+		errors.Is(err, service.ErrInvalidAvailability),
+		errors.Is(err, service.ErrConflictingSchedule),
+		errors.Is(err, service.ErrInvalidUtm),
+		errors.Is(err, service.ErrUtmTemplateNotFound),
+		errors.Is(err, service.ErrInvalidTargetingRules),
+		errors.Is(err, service.ErrInvalidDestinations),
+		errors.Is(err, service.ErrInvalidRedirectType),
+		errors.Is(err, service.ErrLongUrlRequired),
+		errors.Is(err, service.ErrInvalidLongUrl),
+		errors.Is(err, service.ErrInvalidFallbackUrl):
+		status = fiber.StatusBadRequest
+		message = err.Error()
+	}
+
+	response := dto.NewErrorResponse(status, message)
+	return ctx.Status(status).JSON(response)
+}
+
+func (c *ShortUrlController) parsePagination(ctx *fiber.Ctx) dto.Pagination {
+	pagination := dto.Pagination{
+		Page:     1,
+		PageSize: 10,
+	}
+
+	if pageStr := ctx.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			pagination.Page = page
+		}
+	}
+
+	if pageSizeStr := ctx.Query("page_size"); pageSizeStr != "" {
+		if pageSize, err := strconv.Atoi(pageSizeStr); err == nil && pageSize > 0 && pageSize <= 100 {
+			pagination.PageSize = pageSize
+		}
+	}
+
+	return pagination
+}
+
+// parseListFilter reads the listing filters from the query string. The user
+// is never taken from the query string; callers set it from the JWT.
+func (c *ShortUrlController) parseListFilter(ctx *fiber.Ctx) (dto.ShortUrlQueryFilter, error) {
+	var filter dto.ShortUrlQueryFilter
+
+	if isActiveStr := ctx.Query("is_active"); isActiveStr != "" {
+		isActive, err := strconv.ParseBool(isActiveStr)
+		if err != nil {
+			return filter, errors.New("is_active must be true or false")
+		}
+		filter.IsActive = &isActive
+	}
+
+	if expiredStr := ctx.Query("expired"); expiredStr != "" {
+		expired, err := strconv.ParseBool(expiredStr)
+		if err != nil {
+			return filter, errors.New("expired must be true or false")
+		}
+		filter.Expired = &expired
+	}
+
+	if createdFromStr := ctx.Query("created_from"); createdFromStr != "" {
+		createdFrom, err := parseDateParam(createdFromStr, false)
+		if err != nil {
+			return filter, errors.New("created_from must be a date (YYYY-MM-DD) or RFC 3339 timestamp")
+		}
+		filter.CreatedFrom = &createdFrom
+	}
+
+	if createdToStr := ctx.Query("created_to"); createdToStr != "" {
+		createdTo, err := parseDateParam(createdToStr, true)
+		if err != nil {
+			return filter, errors.New("created_to must be a date (YYYY-MM-DD) or RFC 3339 timestamp")
+		}
+		filter.CreatedTo = &createdTo
+	}
+
+	filter.LongUrlContains = ctx.Query("search")
+	filter.UtmCampaign = ctx.Query("utm_campaign")
+
+	switch sortBy := ctx.Query("sort_by", dto.ShortUrlSortByCreatedAt); sortBy {
+	case dto.ShortUrlSortByCreatedAt, dto.ShortUrlSortByClickCount:
+		filter.SortBy = sortBy
+	default:
+		return filter, errors.New("sort_by must be created_at or click_count")
+	}
+
+	switch sortOrder := ctx.Query("sort_order", dto.SortOrderDesc); sortOrder {
+	case dto.SortOrderAsc, dto.SortOrderDesc:
+		filter.SortOrder = sortOrder
+	default:
+		return filter, errors.New("sort_order must be asc or desc")
+	}
+
+	return filter, nil
+}
+
+// parseDateParam accepts either an RFC 3339 timestamp or a plain date. A plain
+// date used as an upper bound covers the whole day.
+func parseDateParam(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// linkDomainID reads ?domain_id=, which picks the domain of the link a code
+// refers to in the owner API. Without it the code is looked up on the
+// service's own host.
+func linkDomainID(ctx *fiber.Ctx) (uint, bool) {
+	value := ctx.Query("domain_id")
+	if value == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func toShortUrlResponse(shortUrl *entities.ShortUrl) dto.ShortUrlResponse {
+	return dto.ShortUrlResponse{
+		ID:                 shortUrl.ID,
+		ShortCode:          shortUrl.ShortCode,
+		LongUrl:            shortUrl.LongUrl,
+		UserID:             shortUrl.UserID,
+		DomainID:           shortUrl.DomainID,
+		Domain:             domainHost(shortUrl),
+		IsActive:           shortUrl.IsActive,
+		ExpireAt:           shortUrl.ExpireAt,
+		FallbackUrl:        shortUrl.FallbackUrl,
+		ActiveFrom:         shortUrl.ActiveFrom,
+		Availability:       toAvailabilityWindowResponse(shortUrl.Availability),
+		ClickCount:         shortUrl.ClickCount,
+		Safety:             toUrlSafetyVerdict(shortUrl.UrlSafety),
+		CreatedAt:          shortUrl.CreatedAt,
+		UpdatedAt:          shortUrl.UpdatedAt,
+		PasswordProtected:  shortUrl.IsPasswordProtected(),
+		MaxClicks:          shortUrl.MaxClicks,
+		RemainingClicks:    shortUrl.RemainingClicks,
+		ForwardQuery:       shortUrl.ForwardQuery,
+		ForwardPath:        shortUrl.ForwardPath,
+		Utm:                toUtmParamsResponse(shortUrl.Utm),
+		TargetingRules:     toTargetingRulesResponse(shortUrl.TargetingRules),
+		Destinations:       toDestinationsResponse(shortUrl.Destinations),
+		StickyDestinations: shortUrl.StickyDestinations,
+		Preview:            shortUrl.Preview,
+		RedirectType:       shortUrl.RedirectStatus(),
+	}
+}
+
+// domainHost is the custom domain a link is served on, or empty for the
+// service's own host.
+func domainHost(shortUrl *entities.ShortUrl) string {
+	if shortUrl.Domain == nil {
+		return ""
+	}
+	return shortUrl.Domain.Host
+}
+
+func toAvailabilityWindowResponse(window *entities.AvailabilityWindow) *dto.AvailabilityWindow {
+	if window == nil {
+		return nil
+	}
+	return &dto.AvailabilityWindow{
+		Days:     window.Days,
+		Start:    window.Start,
+		End:      window.End,
+		Timezone: window.Timezone,
+	}
+}
+
+func toUtmParamsResponse(utm entities.UtmParams) *dto.UtmParams {
+	if utm.IsEmpty() {
+		return nil
+	}
+	params := dto.UtmParams(utm)
+	return &params
+}
+
+func toTargetingRulesResponse(rules []entities.TargetingRule) []dto.TargetingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	response := make([]dto.TargetingRule, len(rules))
+	for i, rule := range rules {
+		response[i] = dto.TargetingRule(rule)
+	}
+	return response
+}
+
+func toDestinationsResponse(destinations []entities.ShortUrlDestination) []dto.ShortUrlDestination {
+	if len(destinations) == 0 {
+		return nil
+	}
+	response := make([]dto.ShortUrlDestination, len(destinations))
+	for i, destination := range destinations {
+		response[i] = dto.ShortUrlDestination{ID: destination.ID, Url: destination.Url, Weight: destination.Weight}
+	}
+	return response
+}
+
+func toUrlSafetyVerdict(safety *entities.UrlSafety) *dto.UrlSafetyVerdict {
+	if safety == nil {
+		return nil
+	}
+	return &dto.UrlSafetyVerdict{
+		Safe:    safety.IsSafe,
+		Checker: safety.Checker,
+		Reason:  safety.Reason,
+	}
+}
+
+func (c *ShortUrlController) handleCreateError(ctx *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	message := "Failed to create short URL"
+
+	switch {
+	case errors.Is(err, service.ErrInvalidAlias),
+		errors.Is(err, service.ErrReservedAlias),
+		errors.Is(err, service.ErrInvalidExpiry),
+		errors.Is(err, service.ErrConflictingExpiry),
+		errors.Is(err, service.ErrInvalidDedupeScope),
+		errors.Is(err, service.ErrInvalidLinkPassword),
+		errors.Is(err, service.ErrInvalidMaxClicks),
+		errors.Is(err, service.ErrInvalidActiveFrom),
+		errors.Is(err, service.ErrInvalidAvailability),
+		errors.Is(err, service.ErrConflictingSchedule),
+		errors.Is(err, service.ErrInvalidUtm),
+		errors.Is(err, service.ErrUtmTemplateNotFound),
+		errors.Is(err, service.ErrInvalidTargetingRules),
+		errors.Is(err, service.ErrInvalidDestinations),
+		errors.Is(err, service.ErrCustomDomainNotFound),
+		errors.Is(err, service.ErrCustomDomainNotVerified),
+		errors.Is(err, service.ErrInvalidRedirectType),
+		errors.Is(err, service.ErrLongUrlRequired),
+		errors.Is(err, service.ErrInvalidLongUrl),
+		errors.Is(err, service.ErrInvalidFallbackUrl):
+		status = fiber.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, service.ErrAliasTaken):
+		status = fiber.StatusConflict
+		message = err.Error()
+	case errors.Is(err, service.ErrShortCodeExhausted):
+		status = fiber.StatusServiceUnavailable
+		message = err.Error()
+	}
+
+	response := dto.NewErrorResponse(status, message)
+	return ctx.Status(status).JSON(response)
 }
 
 func (c *ShortUrlController) RegisterRoutes(api fiber.Router) {
 	api.Post("/url", c.CreateShortUrl)
+	api.Post("/url/bulk", c.BulkCreateShortUrls)
+	api.Get("/url", c.ListShortUrls)
+	api.Patch("/url/:shortCode", c.UpdateShortUrl)
+	api.Delete("/url/:shortCode", c.DeleteShortUrl)
 }
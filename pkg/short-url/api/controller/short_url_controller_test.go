@@ -3,9 +3,12 @@ package controller
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"short-url-service/api/repository"
 	"short-url-service/api/service"
@@ -70,9 +73,11 @@ func (suite *ShortUrlControllerIntegrationTestSuite) SetupSuite() {
 	commandRepo := repository.NewShortUrlCommandRepository(db)
 	queryRepo := repository.NewShortUrlQueryRepository(db)
 	redisRepo := repository.NewRedisRepository(redisClient)
+	clickCounterRepo := repository.NewClickCounterRepository(redisClient, time.UTC)
 
-	shortUrlService := service.NewShortUrlService(commandRepo, queryRepo, redisRepo)
-	suite.controller = NewShortUrlController(shortUrlService)
+	shortUrlService := service.NewShortUrlService(commandRepo, queryRepo, redisRepo, clickCounterRepo, nil, nil, repository.NewUtmTemplateQueryRepository(db), nil, repository.NewCustomDomainQueryRepository(db), repository.NewInstitutionSettingQueryRepository(db), dto.LinkCacheConfig{})
+	accessService := service.NewShortUrlAccessService(redisRepo, cfg.JWTSecret, time.Minute)
+	suite.controller = NewShortUrlController(shortUrlService, nil, accessService, dto.UnavailableLinkConfig{})
 
 	suite.app = fiber.New()
 
@@ -178,6 +183,64 @@ func (suite *ShortUrlControllerIntegrationTestSuite) TestCreateShortUrl_EmptyLon
 	assert.Equal(suite.T(), "v1", baseResponse.APIVersion)
 }
 
+func (suite *ShortUrlControllerIntegrationTestSuite) TestCreateShortUrl_WithAlias() {
+	requestBody := dto.CreateShortUrlRequest{
+		LongUrl: "https://example.com/campaign",
+		Alias:   "promo-2025",
+	}
+
+	userID := suite.getFirstUser()
+	token := suite.generateTestJWT(userID, "abcd1234567890abcd1234567890abcd1234567890abcd1234567890abcd1234")
+
+	resp := suite.postShortUrl(requestBody, token)
+	suite.Require().Equal(201, resp.StatusCode)
+
+	var baseResponse dto.BaseResponse
+	err := json.NewDecoder(resp.Body).Decode(&baseResponse)
+	suite.Require().NoError(err)
+
+	dataBytes, _ := json.Marshal(baseResponse.Data)
+	var responseData dto.CreateShortUrlResponse
+	err = json.Unmarshal(dataBytes, &responseData)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "promo-2025", responseData.ShortCode)
+
+	conflictResp := suite.postShortUrl(requestBody, token)
+	assert.Equal(suite.T(), 409, conflictResp.StatusCode)
+
+	var conflictResponse dto.BaseResponse
+	err = json.NewDecoder(conflictResp.Body).Decode(&conflictResponse)
+	suite.Require().NoError(err)
+	assert.False(suite.T(), conflictResponse.Success)
+	assert.Equal(suite.T(), "alias is already in use", conflictResponse.Message)
+}
+
+func (suite *ShortUrlControllerIntegrationTestSuite) TestCreateShortUrl_InvalidAlias() {
+	userID := suite.getFirstUser()
+	token := suite.generateTestJWT(userID, "abcd1234567890abcd1234567890abcd1234567890abcd1234567890abcd1234")
+
+	testCases := []struct {
+		alias   string
+		message string
+	}{
+		{"ab", "alias must be 3-10 characters of letters, digits, '-' or '_'"},
+		{"much-too-long", "alias must be 3-10 characters of letters, digits, '-' or '_'"},
+		{"bad/alias", "alias must be 3-10 characters of letters, digits, '-' or '_'"},
+		{"health", "alias is reserved"},
+		{"API", "alias is reserved"},
+	}
+
+	for _, tc := range testCases {
+		resp := suite.postShortUrl(dto.CreateShortUrlRequest{LongUrl: "https://example.com", Alias: tc.alias}, token)
+		assert.Equal(suite.T(), 400, resp.StatusCode, tc.alias)
+
+		var baseResponse dto.BaseResponse
+		err := json.NewDecoder(resp.Body).Decode(&baseResponse)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), tc.message, baseResponse.Message, tc.alias)
+	}
+}
+
 func (suite *ShortUrlControllerIntegrationTestSuite) TestCreateAndGetShortUrl_Integration() {
 	userID := suite.getFirstUser()
 	token := suite.generateTestJWT(userID, "abcd1234567890abcd1234567890abcd1234567890abcd1234567890abcd1234")
@@ -246,6 +309,101 @@ func (suite *ShortUrlControllerIntegrationTestSuite) TestCreateAndGetShortUrl_In
 	assert.Equal(suite.T(), "https://integration-test.example.com/very-long-url-for-testing", location)
 }
 
+func (suite *ShortUrlControllerIntegrationTestSuite) TestUpdateAndDeleteShortUrl_Integration() {
+	userID := suite.getFirstUser()
+	token := suite.generateTestJWT(userID, "abcd1234567890abcd1234567890abcd1234567890abcd1234567890abcd1234")
+
+	createResp := suite.postShortUrl(dto.CreateShortUrlRequest{LongUrl: "https://example.com/before", Alias: "upd-del"}, token)
+	suite.Require().Equal(201, createResp.StatusCode)
+
+	patchBody, _ := json.Marshal(map[string]interface{}{"long_url": "https://example.com/after"})
+	patchReq, _ := http.NewRequest("PATCH", "/api/v1/url/upd-del", bytes.NewBuffer(patchBody))
+	patchReq.Header.Set("Content-Type", "application/json")
+	patchReq.Header.Set("Authorization", "Bearer "+token)
+
+	patchResp, err := suite.app.Test(patchReq, 10000000)
+	suite.Require().NoError(err)
+	suite.Require().Equal(200, patchResp.StatusCode)
+
+	getReq, _ := http.NewRequest("GET", "/url/upd-del", nil)
+	getReq.Header.Set("Authorization", "Bearer "+token)
+	getResp, err := suite.app.Test(getReq, 10000000)
+	suite.Require().NoError(err)
+	suite.Require().Equal(302, getResp.StatusCode)
+	assert.Equal(suite.T(), "https://example.com/after", getResp.Header.Get("Location"))
+
+	deleteReq, _ := http.NewRequest("DELETE", "/api/v1/url/upd-del", nil)
+	deleteReq.Header.Set("Authorization", "Bearer "+token)
+	deleteResp, err := suite.app.Test(deleteReq, 10000000)
+	suite.Require().NoError(err)
+	suite.Require().Equal(200, deleteResp.StatusCode)
+
+	getAfterDeleteReq, _ := http.NewRequest("GET", "/url/upd-del", nil)
+	getAfterDeleteReq.Header.Set("Authorization", "Bearer "+token)
+	getAfterDeleteResp, err := suite.app.Test(getAfterDeleteReq, 10000000)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 404, getAfterDeleteResp.StatusCode)
+}
+
+func (suite *ShortUrlControllerIntegrationTestSuite) TestBulkCreateShortUrls_CSVRoundTrip() {
+	userID := suite.getFirstUser()
+	token := suite.generateTestJWT(userID, "abcd1234567890abcd1234567890abcd1234567890abcd1234567890abcd1234")
+
+	csvBody := "campaign,long_url,alias\n" +
+		"spring,https://example.com/spring,bulk-a\n" +
+		"summer,https://example.com/summer,bulk-a\n" +
+		"autumn,https://example.com/autumn,\n"
+
+	req, _ := http.NewRequest("POST", "/api/v1/url/bulk", strings.NewReader(csvBody))
+	req.Header.Set("Content-Type", "text/csv")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := suite.app.Test(req, 10000000)
+	suite.Require().NoError(err)
+	suite.Require().Equal(207, resp.StatusCode)
+	assert.Contains(suite.T(), resp.Header.Get("Content-Type"), "text/csv")
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 4)
+	assert.Equal(suite.T(), []string{"campaign", "long_url", "alias", "short_code", "error"}, rows[0])
+	assert.Equal(suite.T(), []string{"spring", "https://example.com/spring", "bulk-a", "bulk-a", ""}, rows[1])
+	assert.Equal(suite.T(), "", rows[2][3])
+	assert.Equal(suite.T(), "alias is already in use", rows[2][4])
+	assert.Len(suite.T(), rows[3][3], 8)
+}
+
+func (suite *ShortUrlControllerIntegrationTestSuite) TestBulkCreateShortUrls_AtomicAbort() {
+	userID := suite.getFirstUser()
+	token := suite.generateTestJWT(userID, "abcd1234567890abcd1234567890abcd1234567890abcd1234567890abcd1234")
+
+	body, _ := json.Marshal([]dto.CreateShortUrlRequest{
+		{LongUrl: "https://example.com/ok", Alias: "bulk-ok"},
+		{LongUrl: "https://example.com/bad", Alias: "api"},
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/url/bulk?atomic=true", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := suite.app.Test(req, 10000000)
+	suite.Require().NoError(err)
+	suite.Require().Equal(422, resp.StatusCode)
+
+	okResp := suite.postShortUrl(dto.CreateShortUrlRequest{LongUrl: "https://example.com/ok", Alias: "bulk-ok"}, token)
+	assert.Equal(suite.T(), 201, okResp.StatusCode)
+}
+
+func (suite *ShortUrlControllerIntegrationTestSuite) postShortUrl(requestBody dto.CreateShortUrlRequest, token string) *http.Response {
+	body, _ := json.Marshal(requestBody)
+	req, _ := http.NewRequest("POST", "/api/v1/url", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := suite.app.Test(req, 10000000)
+	suite.Require().NoError(err)
+	return resp
+}
+
 func (suite *ShortUrlControllerIntegrationTestSuite) generateTestJWT(userID uint, sessionCode string) string {
 	secretKey := suite.getSessionSecret(sessionCode)
 	tokenString, _, _ := jwthelper.GenerateJWTToken(userID, sessionCode, secretKey)
@@ -0,0 +1,378 @@
+package controller
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/service"
+	"short-url/domains/service/mocks"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedirectTestApp(t *testing.T, shortUrl *entities.ShortUrl) *fiber.App {
+	shortUrlService := mocks.NewMockShortUrlServiceInterface(t)
+	shortUrlService.EXPECT().GetByShortCodePublic(mock.Anything, mock.Anything, shortUrl.ShortCode).Return(shortUrl, nil).Maybe()
+	shortUrlService.EXPECT().ConsumeClick(mock.Anything, shortUrl).Return(nil).Maybe()
+	shortUrlService.EXPECT().RecordClick(shortUrl.ID).Return().Maybe()
+	shortUrlService.EXPECT().SelectDestination(mock.Anything, shortUrl, mock.Anything).Return(dto.RedirectDestination{Url: shortUrl.LongUrl}).Maybe()
+
+	controller := NewShortUrlController(shortUrlService, nil, nil, dto.UnavailableLinkConfig{})
+	app := fiber.New()
+	app.Get("/:shortCode", controller.PublicRedirect)
+	app.Post("/:shortCode", controller.UnlockShortUrl)
+	app.Get("/:shortCode/*", controller.PublicRedirect)
+	return app
+}
+
+func TestPublicRedirect_Forwarding(t *testing.T) {
+	cases := []struct {
+		name         string
+		longUrl      string
+		forwardQuery bool
+		forwardPath  bool
+		target       string
+		status       int
+		location     string
+	}{
+		{"no forwarding", "https://example.com/base?a=1", false, false, "/abc123?b=2", fiber.StatusFound, "https://example.com/base?a=1"},
+		{"query appended", "https://example.com/base?a=1", true, false, "/abc123?b=2&c=3", fiber.StatusFound, "https://example.com/base?a=1&b=2&c=3"},
+		{"query replaces in place", "https://example.com/?a=1&b=2&a=3&c=4", true, false, "/abc123?a=x&d=5&a=y", fiber.StatusFound, "https://example.com/?a=x&a=y&b=2&c=4&d=5"},
+		{"query values encoded", "https://example.com/", true, false, "/abc123?q=a%20b%26c&utm+source=x%2By", fiber.StatusFound, "https://example.com/?q=a+b%26c&utm+source=x%2By"},
+		{"query keeps fragment", "https://example.com/page#top", true, false, "/abc123?b=2", fiber.StatusFound, "https://example.com/page?b=2#top"},
+		{"invalid query", "https://example.com/", true, false, "/abc123?q=%zz", fiber.StatusBadRequest, ""},
+		{"path appended", "https://example.com/docs/", false, true, "/abc123/guide/intro", fiber.StatusFound, "https://example.com/docs/guide/intro"},
+		{"path and query", "https://example.com/docs?v=2", true, true, "/abc123/a%20b?lang=en", fiber.StatusFound, "https://example.com/docs/a%20b?v=2&lang=en"},
+		{"path not forwarded", "https://example.com/docs", false, false, "/abc123/guide", fiber.StatusNotFound, ""},
+		{"dot segments refused", "https://example.com/docs", false, true, "/abc123/%2E%2E/admin", fiber.StatusBadRequest, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := newRedirectTestApp(t, &entities.ShortUrl{
+				ID:           1,
+				ShortCode:    "abc123",
+				LongUrl:      tc.longUrl,
+				ForwardQuery: tc.forwardQuery,
+				ForwardPath:  tc.forwardPath,
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tc.target, nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Equal(t, tc.location, resp.Header.Get(fiber.HeaderLocation))
+		})
+	}
+}
+
+func TestPublicRedirect_AddsUtmParams(t *testing.T) {
+	app := newRedirectTestApp(t, &entities.ShortUrl{
+		ID:           1,
+		ShortCode:    "abc123",
+		LongUrl:      "https://example.com/?utm_source=old&page=2",
+		ForwardQuery: true,
+		Utm:          entities.UtmParams{Source: "newsletter", Campaign: "spring sale"},
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/abc123?utm_campaign=visitor&ref=x", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+	assert.Equal(t, "https://example.com/?utm_source=newsletter&page=2&utm_campaign=spring+sale&ref=x", resp.Header.Get(fiber.HeaderLocation))
+}
+
+func TestPublicRedirect_UsesTargetedDestination(t *testing.T) {
+	shortUrl := &entities.ShortUrl{
+		ID:          1,
+		ShortCode:   "abc123",
+		LongUrl:     "https://example.com/",
+		ForwardPath: true,
+		TargetingRules: []entities.TargetingRule{
+			{OS: []string{entities.TargetOSIOS}, Destination: "https://apps.apple.com/app/id1"},
+		},
+	}
+	visitor := dto.RedirectVisitor{
+		UserAgent:      "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)",
+		AcceptLanguage: "de-AT,de;q=0.9",
+		IP:             "0.0.0.0",
+	}
+
+	shortUrlService := mocks.NewMockShortUrlServiceInterface(t)
+	shortUrlService.EXPECT().GetByShortCodePublic(mock.Anything, mock.Anything, "abc123").Return(shortUrl, nil)
+	shortUrlService.EXPECT().SelectDestination(mock.Anything, shortUrl, visitor).Return(dto.RedirectDestination{Url: "https://apps.apple.com/app/id1"})
+	shortUrlService.EXPECT().ConsumeClick(mock.Anything, shortUrl).Return(nil)
+	shortUrlService.EXPECT().RecordClick(shortUrl.ID).Return().Maybe()
+
+	controller := NewShortUrlController(shortUrlService, nil, nil, dto.UnavailableLinkConfig{})
+	app := fiber.New()
+	app.Get("/:shortCode/*", controller.PublicRedirect)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/abc123/reviews", nil)
+	req.Header.Set(fiber.HeaderUserAgent, visitor.UserAgent)
+	req.Header.Set(fiber.HeaderAcceptLanguage, visitor.AcceptLanguage)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+	assert.Equal(t, "https://apps.apple.com/app/id1/reviews", resp.Header.Get(fiber.HeaderLocation))
+}
+
+func TestPublicRedirect_ExpiredFallback(t *testing.T) {
+	fallbackUrl := "https://example.com/ended"
+	cases := []struct {
+		name     string
+		safety   *entities.UrlSafety
+		status   int
+		location string
+	}{
+		{"not scanned yet", nil, fiber.StatusFound, fallbackUrl},
+		{"safe", &entities.UrlSafety{IsSafe: true}, fiber.StatusFound, fallbackUrl},
+		{"flagged unsafe", &entities.UrlSafety{IsSafe: false}, fiber.StatusGone, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			shortUrl := &entities.ShortUrl{ID: 1, ShortCode: "abc123", LongUrl: "https://example.com", FallbackUrl: &fallbackUrl, UrlSafety: tc.safety}
+			shortUrlService := mocks.NewMockShortUrlServiceInterface(t)
+			shortUrlService.EXPECT().GetByShortCodePublic(mock.Anything, mock.Anything, "abc123").Return(shortUrl, service.ErrShortUrlExpired)
+
+			controller := NewShortUrlController(shortUrlService, nil, nil, dto.UnavailableLinkConfig{})
+			app := fiber.New()
+			app.Get("/:shortCode", controller.PublicRedirect)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/abc123", nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Equal(t, tc.location, resp.Header.Get(fiber.HeaderLocation))
+		})
+	}
+}
+
+func TestPublicRedirect_ResolvesPerHost(t *testing.T) {
+	own := &entities.ShortUrl{ID: 1, ShortCode: "promo", LongUrl: "https://example.com/own", IsActive: true}
+	branded := &entities.ShortUrl{ID: 2, DomainID: 4, ShortCode: "promo", LongUrl: "https://example.com/branded", IsActive: true}
+
+	shortUrlService := mocks.NewMockShortUrlServiceInterface(t)
+	for host, shortUrl := range map[string]*entities.ShortUrl{"sho.rt": own, "go.example.com": branded} {
+		shortUrlService.EXPECT().GetByShortCodePublic(mock.Anything, host, "promo").Return(shortUrl, nil)
+		shortUrlService.EXPECT().SelectDestination(mock.Anything, shortUrl, mock.Anything).Return(dto.RedirectDestination{Url: shortUrl.LongUrl})
+		shortUrlService.EXPECT().ConsumeClick(mock.Anything, shortUrl).Return(nil)
+		shortUrlService.EXPECT().RecordClick(shortUrl.ID).Return().Maybe()
+	}
+
+	controller := NewShortUrlController(shortUrlService, nil, nil, dto.UnavailableLinkConfig{})
+	app := fiber.New()
+	app.Get("/:shortCode", controller.PublicRedirect)
+
+	for host, location := range map[string]string{"sho.rt": own.LongUrl, "go.example.com": branded.LongUrl} {
+		req := httptest.NewRequest(fiber.MethodGet, "/promo", nil)
+		req.Host = host
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, fiber.StatusFound, resp.StatusCode, host)
+		assert.Equal(t, location, resp.Header.Get(fiber.HeaderLocation), host)
+	}
+}
+
+func TestPublicRedirect_StickyDestination(t *testing.T) {
+	shortUrl := &entities.ShortUrl{
+		ID:                 7,
+		ShortCode:          "abc123",
+		LongUrl:            "https://example.com",
+		IsActive:           true,
+		StickyDestinations: true,
+		Destinations: []entities.ShortUrlDestination{
+			{ID: 11, Url: "https://example.com/a", Weight: 50},
+			{ID: 12, Url: "https://example.com/b", Weight: 50},
+		},
+	}
+
+	shortUrlService := mocks.NewMockShortUrlServiceInterface(t)
+	shortUrlService.EXPECT().GetByShortCodePublic(mock.Anything, mock.Anything, "abc123").Return(shortUrl, nil)
+	shortUrlService.EXPECT().SelectDestination(mock.Anything, shortUrl, mock.MatchedBy(func(visitor dto.RedirectVisitor) bool {
+		return visitor.DestinationID == 12
+	})).Return(dto.RedirectDestination{Url: "https://example.com/b", DestinationID: 12})
+	shortUrlService.EXPECT().ConsumeClick(mock.Anything, shortUrl).Return(nil)
+	shortUrlService.EXPECT().RecordClick(shortUrl.ID).Return().Maybe()
+	clickEventRecorder := mocks.NewMockClickEventRecorderServiceInterface(t)
+	clickEventRecorder.EXPECT().Record(mock.MatchedBy(func(input dto.ClickEventInput) bool {
+		return input.ShortUrlID == 7 && input.DestinationID == 12
+	})).Return()
+
+	controller := NewShortUrlController(shortUrlService, clickEventRecorder, nil, dto.UnavailableLinkConfig{})
+	app := fiber.New()
+	app.Get("/:shortCode", controller.PublicRedirect)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/abc123", nil)
+	req.Header.Set(fiber.HeaderCookie, "link_destination_abc123=12")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+	assert.Equal(t, "https://example.com/b", resp.Header.Get(fiber.HeaderLocation))
+	cookies := resp.Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "link_destination_abc123", cookies[0].Name)
+	assert.Equal(t, "12", cookies[0].Value)
+}
+
+func TestPublicRedirect_Preview(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		preview  bool
+		path     string
+		accept   string
+		wantPage bool
+	}{
+		{name: "plus suffix", path: "/abc123+?ref=mail", wantPage: true},
+		{name: "link flag", preview: true, path: "/abc123?ref=mail", wantPage: true},
+		{name: "plain redirect", path: "/abc123"},
+		{name: "json client", path: "/abc123+", accept: "application/json"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			shortUrl := &entities.ShortUrl{
+				ID:        1,
+				ShortCode: "abc123",
+				LongUrl:   "https://docs.example.com/guide",
+				IsActive:  true,
+				Preview:   tc.preview,
+				CreatedAt: createdAt,
+				UrlSafety: &entities.UrlSafety{IsSafe: true, CheckedAt: createdAt},
+			}
+			app := newRedirectTestApp(t, shortUrl)
+
+			req := httptest.NewRequest(fiber.MethodGet, tc.path, nil)
+			if tc.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tc.accept)
+			}
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+
+			if !tc.wantPage {
+				assert.NotEqual(t, fiber.MIMETextHTMLCharsetUTF8, resp.Header.Get(fiber.HeaderContentType))
+				return
+			}
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.Equal(t, "no-store", resp.Header.Get(fiber.HeaderCacheControl))
+			assert.Contains(t, string(body), "docs.example.com")
+			assert.NotContains(t, string(body), "/guide")
+			assert.Contains(t, string(body), "Passed our safety checks on 1 May 2024")
+			assert.Contains(t, string(body), "Created</dt>\n<dd>1 May 2024")
+			assert.Contains(t, string(body), `action="/abc123?ref=mail"`)
+			assert.NotContains(t, string(body), "less than a day ago")
+		})
+	}
+}
+
+func TestPublicRedirect_PreviewContinue(t *testing.T) {
+	shortUrl := &entities.ShortUrl{ID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, Preview: true, CreatedAt: time.Now()}
+	app := newRedirectTestApp(t, shortUrl)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/abc123", nil))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Not checked yet")
+	assert.Contains(t, string(body), "less than a day ago")
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodPost, "/abc123", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusSeeOther, resp.StatusCode)
+	assert.Equal(t, "https://example.com", resp.Header.Get(fiber.HeaderLocation))
+}
+
+func TestUnlockShortUrl_PreviewAfterPassword(t *testing.T) {
+	shortUrl := &entities.ShortUrl{ID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, PasswordHash: "hash"}
+
+	shortUrlService := mocks.NewMockShortUrlServiceInterface(t)
+	shortUrlService.EXPECT().GetByShortCodePublic(mock.Anything, mock.Anything, "abc123").Return(shortUrl, service.ErrShortUrlPasswordRequired)
+	shortUrlService.EXPECT().SelectDestination(mock.Anything, shortUrl, mock.Anything).Return(dto.RedirectDestination{Url: shortUrl.LongUrl})
+	shortUrlService.EXPECT().ConsumeClick(mock.Anything, shortUrl).Return(nil)
+	shortUrlService.EXPECT().RecordClick(shortUrl.ID).Return().Maybe()
+	accessService := mocks.NewMockShortUrlAccessServiceInterface(t)
+	accessService.EXPECT().HasAccess(shortUrl, "").Return(false)
+	accessService.EXPECT().Unlock(mock.Anything, shortUrl, "open sesame", mock.Anything).Return("token", time.Now().Add(time.Hour), nil)
+	accessService.EXPECT().HasAccess(shortUrl, "token").Return(true)
+
+	controller := NewShortUrlController(shortUrlService, nil, accessService, dto.UnavailableLinkConfig{})
+	app := fiber.New()
+	app.Post("/:shortCode", controller.UnlockShortUrl)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/abc123+", strings.NewReader("password=open+sesame"))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusSeeOther, resp.StatusCode)
+	assert.Equal(t, "/abc123+", resp.Header.Get(fiber.HeaderLocation))
+
+	// The continue button posts again, now with the access cookie.
+	req = httptest.NewRequest(fiber.MethodPost, "/abc123", nil)
+	req.Header.Set(fiber.HeaderCookie, "link_access_abc123=token")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusSeeOther, resp.StatusCode)
+	assert.Equal(t, "https://example.com", resp.Header.Get(fiber.HeaderLocation))
+}
+
+func TestPublicRedirect_RedirectType(t *testing.T) {
+	maxClicks := int64(10)
+	cases := []struct {
+		name         string
+		shortUrl     entities.ShortUrl
+		status       int
+		cacheControl string
+	}{
+		{"default", entities.ShortUrl{}, fiber.StatusFound, "no-store"},
+		{"temporary", entities.ShortUrl{RedirectType: entities.RedirectTypeTemporaryRedirect}, fiber.StatusTemporaryRedirect, "no-store"},
+		{"moved permanently", entities.ShortUrl{RedirectType: entities.RedirectTypeMovedPermanently}, fiber.StatusMovedPermanently, "public, max-age=3600"},
+		{"permanent redirect", entities.ShortUrl{RedirectType: entities.RedirectTypePermanentRedirect}, fiber.StatusPermanentRedirect, "public, max-age=3600"},
+		{"permanent with click limit", entities.ShortUrl{RedirectType: entities.RedirectTypeMovedPermanently, MaxClicks: &maxClicks}, fiber.StatusMovedPermanently, "no-store"},
+		{"permanent with targeting", entities.ShortUrl{
+			RedirectType:   entities.RedirectTypeMovedPermanently,
+			TargetingRules: []entities.TargetingRule{{OS: []string{entities.TargetOSIOS}, Destination: "https://apps.apple.com/app/id1"}},
+		}, fiber.StatusMovedPermanently, "no-store"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			shortUrl := tc.shortUrl
+			shortUrl.ID = 1
+			shortUrl.ShortCode = "abc123"
+			shortUrl.LongUrl = "https://example.com/"
+			app := newRedirectTestApp(t, &shortUrl)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/abc123", nil))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Equal(t, "https://example.com/", resp.Header.Get(fiber.HeaderLocation))
+			assert.Equal(t, tc.cacheControl, resp.Header.Get(fiber.HeaderCacheControl))
+		})
+	}
+}
+
+func TestRedirectCacheControl_CappedByExpiry(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(10 * time.Minute)
+	past := now.Add(-time.Second)
+
+	assert.Equal(t, "public, max-age=600", redirectCacheControl(&entities.ShortUrl{ExpireAt: &soon}, fiber.StatusMovedPermanently, "public", now))
+	assert.Equal(t, "private, max-age=600", redirectCacheControl(&entities.ShortUrl{ExpireAt: &soon}, fiber.StatusPermanentRedirect, "private", now))
+	assert.Equal(t, "no-store", redirectCacheControl(&entities.ShortUrl{ExpireAt: &past}, fiber.StatusMovedPermanently, "public", now))
+}
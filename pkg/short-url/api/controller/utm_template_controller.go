@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/service"
+	"short-url-service/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type UtmTemplateController struct {
+	service service.UtmTemplateServiceInterface
+}
+
+func NewUtmTemplateController(service service.UtmTemplateServiceInterface) *UtmTemplateController {
+	return &UtmTemplateController{
+		service: service,
+	}
+}
+
+func (c *UtmTemplateController) CreateUtmTemplate(ctx *fiber.Ctx) error {
+	var req dto.CreateUtmTemplateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid request body")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	template, err := c.service.CreateUtmTemplate(ctx.Context(), &req, userID)
+	if err != nil {
+		return c.handleUtmTemplateError(ctx, err, "Failed to create UTM template")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusCreated, "UTM template created successfully", toUtmTemplateResponse(template))
+	return ctx.Status(fiber.StatusCreated).JSON(response)
+}
+
+func (c *UtmTemplateController) ListUtmTemplates(ctx *fiber.Ctx) error {
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	templates, err := c.service.ListUtmTemplates(ctx.Context(), userID)
+	if err != nil {
+		return c.handleUtmTemplateError(ctx, err, "Failed to retrieve UTM templates")
+	}
+
+	responseData := make([]dto.UtmTemplateResponse, len(templates))
+	for i := range templates {
+		responseData[i] = toUtmTemplateResponse(&templates[i])
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "UTM templates retrieved successfully", responseData)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *UtmTemplateController) GetUtmTemplate(ctx *fiber.Ctx) error {
+	id, ok := parseUtmTemplateID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid UTM template ID")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	template, err := c.service.GetUtmTemplate(ctx.Context(), id, userID)
+	if err != nil {
+		return c.handleUtmTemplateError(ctx, err, "Failed to retrieve UTM template")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "UTM template retrieved successfully", toUtmTemplateResponse(template))
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *UtmTemplateController) UpdateUtmTemplate(ctx *fiber.Ctx) error {
+	id, ok := parseUtmTemplateID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid UTM template ID")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	var req dto.UpdateUtmTemplateRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid request body")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	template, err := c.service.UpdateUtmTemplate(ctx.Context(), id, &req, userID)
+	if err != nil {
+		return c.handleUtmTemplateError(ctx, err, "Failed to update UTM template")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "UTM template updated successfully", toUtmTemplateResponse(template))
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func (c *UtmTemplateController) DeleteUtmTemplate(ctx *fiber.Ctx) error {
+	id, ok := parseUtmTemplateID(ctx)
+	if !ok {
+		response := dto.NewErrorResponse(fiber.StatusBadRequest, "Invalid UTM template ID")
+		return ctx.Status(fiber.StatusBadRequest).JSON(response)
+	}
+
+	userID := middleware.GetUserIDFromContext(ctx)
+	if userID == 0 {
+		response := dto.NewErrorResponse(fiber.StatusUnauthorized, "User authentication required")
+		return ctx.Status(fiber.StatusUnauthorized).JSON(response)
+	}
+
+	if err := c.service.DeleteUtmTemplate(ctx.Context(), id, userID); err != nil {
+		return c.handleUtmTemplateError(ctx, err, "Failed to delete UTM template")
+	}
+
+	response := dto.NewSuccessResponse(fiber.StatusOK, "UTM template deleted successfully", nil)
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+func parseUtmTemplateID(ctx *fiber.Ctx) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (c *UtmTemplateController) handleUtmTemplateError(ctx *fiber.Ctx, err error, fallbackMessage string) error {
+	status := fiber.StatusInternalServerError
+	message := fallbackMessage
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		status = fiber.StatusNotFound
+		message = "UTM template not found"
+	case errors.Is(err, service.ErrInvalidUtmTemplateName),
+		errors.Is(err, service.ErrInvalidUtm):
+		status = fiber.StatusBadRequest
+		message = err.Error()
+	}
+
+	response := dto.NewErrorResponse(status, message)
+	return ctx.Status(status).JSON(response)
+}
+
+func (c *UtmTemplateController) RegisterRoutes(api fiber.Router) {
+	api.Post("/utm-templates", c.CreateUtmTemplate)
+	api.Get("/utm-templates", c.ListUtmTemplates)
+	api.Get("/utm-templates/:id", c.GetUtmTemplate)
+	api.Patch("/utm-templates/:id", c.UpdateUtmTemplate)
+	api.Delete("/utm-templates/:id", c.DeleteUtmTemplate)
+}
+
+func toUtmTemplateResponse(template *entities.UtmTemplate) dto.UtmTemplateResponse {
+	return dto.UtmTemplateResponse{
+		ID:        template.ID,
+		Name:      template.Name,
+		Utm:       dto.UtmParams(template.Utm),
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+}
@@ -0,0 +1,27 @@
+package controller
+
+import "html/template"
+
+var unsafeWarningPage = template.Must(template.New("unsafe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Warning: unsafe link</title>
+<style>
+body { font-family: sans-serif; max-width: 40rem; margin: 4rem auto; padding: 0 1rem; color: #222; }
+h1 { color: #b00020; }
+code { display: block; padding: .75rem; background: #f4f4f4; word-break: break-all; }
+</style>
+</head>
+<body>
+<h1>This link has been flagged as unsafe</h1>
+<p>The short link <strong>{{.ShortCode}}</strong> points to a destination that failed our safety checks, so we did not redirect you.</p>
+{{if .Reason}}<p>Reason: {{.Reason}}</p>{{end}}
+<p>Destination:</p>
+<code>{{.Destination}}</code>
+<p>If you trust this address, you can copy it into your browser yourself.</p>
+</body>
+</html>
+`))
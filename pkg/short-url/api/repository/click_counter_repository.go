@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/repositories"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	clickCountKey          = "click_count"
+	clickFlushPendingKey   = "click_flush:pending"
+	clickFlushBatchPrefix  = "click_flush:batch:"
+	clickCountFieldDateFmt = time.DateOnly
+)
+
+// drainScript moves the live counter hash into a batch hash and registers the
+// batch as pending in one atomic step, so increments arriving afterwards land
+// in a fresh hash and never in the batch being flushed.
+var drainScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('RENAME', KEYS[1], KEYS[2])
+redis.call('SADD', KEYS[3], ARGV[1])
+return 1
+`)
+
+type clickCounterRepository struct {
+	client   *redis.Client
+	location *time.Location
+}
+
+// NewClickCounterRepository buckets clicks into days in the given location,
+// which should match the database time zone.
+func NewClickCounterRepository(client *redis.Client, location *time.Location) repositories.ClickCounterRepositoryInterface {
+	if location == nil {
+		location = time.UTC
+	}
+	return &clickCounterRepository{
+		client:   client,
+		location: location,
+	}
+}
+
+func (r *clickCounterRepository) Increment(ctx context.Context, shortUrlID uint, at time.Time) error {
+	field := fmt.Sprintf("%s:%d", at.In(r.location).Format(clickCountFieldDateFmt), shortUrlID)
+	return r.client.HIncrBy(ctx, clickCountKey, field, 1).Err()
+}
+
+func (r *clickCounterRepository) Drain(ctx context.Context, batchID string) (bool, error) {
+	keys := []string{clickCountKey, clickFlushBatchPrefix + batchID, clickFlushPendingKey}
+	moved, err := drainScript.Run(ctx, r.client, keys, batchID).Int()
+	if err != nil {
+		return false, err
+	}
+	return moved == 1, nil
+}
+
+func (r *clickCounterRepository) PendingBatches(ctx context.Context) ([]string, error) {
+	return r.client.SMembers(ctx, clickFlushPendingKey).Result()
+}
+
+func (r *clickCounterRepository) BatchCounts(ctx context.Context, batchID string) ([]dto.DailyClickCount, error) {
+	fields, err := r.client.HGetAll(ctx, clickFlushBatchPrefix+batchID).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]dto.DailyClickCount, 0, len(fields))
+	for field, value := range fields {
+		dateStr, idStr, ok := strings.Cut(field, ":")
+		if !ok {
+			return nil, fmt.Errorf("malformed click counter field %q", field)
+		}
+
+		date, err := time.ParseInLocation(clickCountFieldDateFmt, dateStr, r.location)
+		if err != nil {
+			return nil, fmt.Errorf("malformed click counter date %q: %w", field, err)
+		}
+
+		shortUrlID, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed click counter id %q: %w", field, err)
+		}
+
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed click counter value %q: %w", value, err)
+		}
+
+		counts = append(counts, dto.DailyClickCount{
+			ShortUrlID: uint(shortUrlID),
+			Date:       date,
+			Count:      count,
+		})
+	}
+
+	return counts, nil
+}
+
+func (r *clickCounterRepository) CompleteBatch(ctx context.Context, batchID string) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, clickFlushBatchPrefix+batchID)
+		pipe.SRem(ctx, clickFlushPendingKey, batchID)
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+
+	"gorm.io/gorm"
+)
+
+const clickEventInsertBatchSize = 500
+
+// clickEventDimensionColumns whitelists the columns a breakdown may group by.
+// Destination IDs are grouped as text, clicks without one as "".
+var clickEventDimensionColumns = map[string]string{
+	dto.ClickDimensionReferrer:    "referrer_host",
+	dto.ClickDimensionBrowser:     "browser",
+	dto.ClickDimensionLanguage:    "language",
+	dto.ClickDimensionDestination: "COALESCE(CAST(destination_id AS VARCHAR(20)), '')",
+}
+
+type clickEventCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewClickEventCommandRepository(db *gorm.DB) repositories.ClickEventCommandRepositoryInterface {
+	return &clickEventCommandRepository{
+		db: db,
+	}
+}
+
+func (r *clickEventCommandRepository) SaveBatch(ctx context.Context, events []entities.ClickEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(events, clickEventInsertBatchSize).Error
+}
+
+type clickEventQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewClickEventQueryRepository(db *gorm.DB) repositories.ClickEventQueryRepositoryInterface {
+	return &clickEventQueryRepository{
+		db: db,
+	}
+}
+
+// CountByShortUrlID counts the events clicked in [from, to).
+func (r *clickEventQueryRepository) CountByShortUrlID(ctx context.Context, shortUrlID uint, from, to time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.ClickEvent{}).
+		Where("short_url_id = ? AND clicked_at >= ? AND clicked_at < ?", shortUrlID, from, to).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// TopValues groups the events clicked in [from, to) by dimension and returns
+// the most frequent values first.
+func (r *clickEventQueryRepository) TopValues(ctx context.Context, shortUrlID uint, dimension string, from, to time.Time, limit int) ([]dto.ClickBreakdownItem, error) {
+	column, ok := clickEventDimensionColumns[dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown click dimension %q", dimension)
+	}
+
+	var items []dto.ClickBreakdownItem
+	err := r.db.WithContext(ctx).
+		Model(&entities.ClickEvent{}).
+		Select(column+" AS value, COUNT(*) AS clicks").
+		Where("short_url_id = ? AND clicked_at >= ? AND clicked_at < ?", shortUrlID, from, to).
+		Group(column).
+		Order("clicks DESC, value ASC").
+		Limit(limit).
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
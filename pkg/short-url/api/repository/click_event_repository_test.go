@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type ClickEventRepositoryTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	commandRepo *clickEventCommandRepository
+	queryRepo   *clickEventQueryRepository
+	ctx         context.Context
+}
+
+func (suite *ClickEventRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&entities.ClickEvent{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.commandRepo = &clickEventCommandRepository{db: db}
+	suite.queryRepo = &clickEventQueryRepository{db: db}
+}
+
+func (suite *ClickEventRepositoryTestSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM click_events")
+}
+
+func (suite *ClickEventRepositoryTestSuite) TestTopValues_GroupsWithinRange() {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+
+	err := suite.commandRepo.SaveBatch(suite.ctx, []entities.ClickEvent{
+		{ShortUrlID: 1, ClickedAt: from.Add(time.Hour), ReferrerHost: "google.com", Browser: "Chrome"},
+		{ShortUrlID: 1, ClickedAt: from.Add(2 * time.Hour), ReferrerHost: "google.com", Browser: "Firefox"},
+		{ShortUrlID: 1, ClickedAt: from.Add(3 * time.Hour), ReferrerHost: "", Browser: "Chrome"},
+		{ShortUrlID: 1, ClickedAt: to, ReferrerHost: "bing.com", Browser: "Chrome"},
+		{ShortUrlID: 2, ClickedAt: from.Add(time.Hour), ReferrerHost: "bing.com", Browser: "Chrome"},
+	})
+	suite.Require().NoError(err)
+
+	count, err := suite.queryRepo.CountByShortUrlID(suite.ctx, 1, from, to)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(3), count)
+
+	items, err := suite.queryRepo.TopValues(suite.ctx, 1, dto.ClickDimensionReferrer, from, to, 10)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []dto.ClickBreakdownItem{
+		{Value: "google.com", Clicks: 2},
+		{Value: "", Clicks: 1},
+	}, items)
+
+	items, err = suite.queryRepo.TopValues(suite.ctx, 1, dto.ClickDimensionBrowser, from, to, 1)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []dto.ClickBreakdownItem{{Value: "Chrome", Clicks: 2}}, items)
+}
+
+func (suite *ClickEventRepositoryTestSuite) TestTopValues_Destination() {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	variantA, variantB := uint(11), uint(12)
+
+	err := suite.commandRepo.SaveBatch(suite.ctx, []entities.ClickEvent{
+		{ShortUrlID: 1, ClickedAt: from.Add(time.Hour), DestinationID: &variantA},
+		{ShortUrlID: 1, ClickedAt: from.Add(2 * time.Hour), DestinationID: &variantB},
+		{ShortUrlID: 1, ClickedAt: from.Add(3 * time.Hour), DestinationID: &variantB},
+		{ShortUrlID: 1, ClickedAt: from.Add(4 * time.Hour)},
+	})
+	suite.Require().NoError(err)
+
+	items, err := suite.queryRepo.TopValues(suite.ctx, 1, dto.ClickDimensionDestination, from, to, 10)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []dto.ClickBreakdownItem{
+		{Value: "12", Clicks: 2},
+		{Value: "", Clicks: 1},
+		{Value: "11", Clicks: 1},
+	}, items)
+}
+
+func (suite *ClickEventRepositoryTestSuite) TestTopValues_RejectsUnknownDimension() {
+	_, err := suite.queryRepo.TopValues(suite.ctx, 1, "ip_address", time.Now(), time.Now(), 10)
+	assert.Error(suite.T(), err)
+}
+
+func TestClickEventRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ClickEventRepositoryTestSuite))
+}
@@ -7,6 +7,7 @@ import (
 	"short-url/domains/repositories"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type shortUrlCommandRepository struct {
@@ -22,3 +23,91 @@ func NewShortUrlCommandRepository(db *gorm.DB) repositories.ShortUrlCommandRepos
 func (r *shortUrlCommandRepository) Save(ctx context.Context, shortUrl *entities.ShortUrl) error {
 	return r.db.WithContext(ctx).Create(shortUrl).Error
 }
+
+// SaveAll inserts every link in a single transaction; either all of them are
+// created or none are.
+func (r *shortUrlCommandRepository) SaveAll(ctx context.Context, shortUrls []*entities.ShortUrl) error {
+	if len(shortUrls) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(shortUrls, 200).Error
+	})
+}
+
+// Update saves every column except remaining_clicks, which only the click
+// budget queries below may change.
+func (r *shortUrlCommandRepository) Update(ctx context.Context, shortUrl *entities.ShortUrl) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations, "RemainingClicks").Save(shortUrl).Error
+}
+
+// ReplaceDestinations makes destinations the split destinations of a link. A
+// destination whose URL the link already splits to keeps that row and its ID;
+// the rows of URLs that are no longer listed are deleted. The stored rows are
+// returned in the given order.
+func (r *shortUrlCommandRepository) ReplaceDestinations(ctx context.Context, shortUrlID uint, destinations []entities.ShortUrlDestination) ([]entities.ShortUrlDestination, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []entities.ShortUrlDestination
+		if err := tx.Where("short_url_id = ?", shortUrlID).Find(&existing).Error; err != nil {
+			return err
+		}
+		existingByUrl := make(map[string]entities.ShortUrlDestination, len(existing))
+		for _, destination := range existing {
+			existingByUrl[destination.Url] = destination
+		}
+
+		for i := range destinations {
+			destination := &destinations[i]
+			destination.ShortUrlID = shortUrlID
+			destination.Position = i
+			if previous, ok := existingByUrl[destination.Url]; ok {
+				delete(existingByUrl, destination.Url)
+				destination.ID = previous.ID
+				destination.CreatedAt = previous.CreatedAt
+			}
+			if err := tx.Save(destination).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, removed := range existingByUrl {
+			if err := tx.Delete(&removed).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return destinations, nil
+}
+
+// ConsumeClick takes one click from a click-limited link's budget and reports
+// whether there was one left. The check and the decrement are a single
+// conditional UPDATE, so concurrent redirects from any number of instances
+// can never use more clicks than the budget holds.
+func (r *shortUrlCommandRepository) ConsumeClick(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.ShortUrl{}).
+		Where("id = ? AND remaining_clicks > 0", id).
+		UpdateColumn("remaining_clicks", gorm.Expr("remaining_clicks - 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ResetClickBudget sets a new click limit with a full budget; nil removes the
+// limit.
+func (r *shortUrlCommandRepository) ResetClickBudget(ctx context.Context, id uint, maxClicks *int64) error {
+	return r.db.WithContext(ctx).Model(&entities.ShortUrl{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"max_clicks":       maxClicks,
+			"remaining_clicks": maxClicks,
+		}).Error
+}
+
+func (r *shortUrlCommandRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entities.ShortUrl{}, id).Error
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/entities"
+	"short-url/domains/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type ShortUrlCommandRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *shortUrlCommandRepository
+	ctx  context.Context
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&entities.ShortUrl{}, &entities.ShortUrlDestination{}, &entities.UrlSafety{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.repo = &shortUrlCommandRepository{db: db}
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM short_url_destinations")
+	suite.db.Exec("DELETE FROM short_urls")
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) createShortUrl(shortCode string, maxClicks *int64) *entities.ShortUrl {
+	now := time.Now().UTC()
+	shortUrl := &entities.ShortUrl{
+		UserID:          1,
+		ShortCode:       shortCode,
+		LongUrl:         "https://example.com",
+		IsActive:        true,
+		MaxClicks:       maxClicks,
+		RemainingClicks: maxClicks,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	suite.Require().NoError(suite.repo.Save(suite.ctx, shortUrl))
+	return shortUrl
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) remainingClicks(id uint) *int64 {
+	var shortUrl entities.ShortUrl
+	suite.Require().NoError(suite.db.First(&shortUrl, id).Error)
+	return shortUrl.RemainingClicks
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) TestConsumeClick_StopsAtZero() {
+	shortUrl := suite.createShortUrl("limited1", helper.Int64Ptr(2))
+
+	for _, expected := range []bool{true, true, false, false} {
+		consumed, err := suite.repo.ConsumeClick(suite.ctx, shortUrl.ID)
+		suite.Require().NoError(err)
+		assert.Equal(suite.T(), expected, consumed)
+	}
+	assert.Equal(suite.T(), int64(0), *suite.remainingClicks(shortUrl.ID))
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) TestConsumeClick_UnlimitedLink() {
+	shortUrl := suite.createShortUrl("unlimit1", nil)
+
+	consumed, err := suite.repo.ConsumeClick(suite.ctx, shortUrl.ID)
+
+	suite.Require().NoError(err)
+	assert.False(suite.T(), consumed)
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) TestUpdate_KeepsRemainingClicks() {
+	shortUrl := suite.createShortUrl("onetime1", helper.Int64Ptr(1))
+	consumed, err := suite.repo.ConsumeClick(suite.ctx, shortUrl.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(consumed)
+
+	// shortUrl still holds the budget it was loaded with.
+	shortUrl.LongUrl = "https://example.com/edited"
+	suite.Require().NoError(suite.repo.Update(suite.ctx, shortUrl))
+
+	assert.Equal(suite.T(), int64(0), *suite.remainingClicks(shortUrl.ID))
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) TestResetClickBudget() {
+	shortUrl := suite.createShortUrl("onetime2", helper.Int64Ptr(1))
+	_, err := suite.repo.ConsumeClick(suite.ctx, shortUrl.ID)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.repo.ResetClickBudget(suite.ctx, shortUrl.ID, helper.Int64Ptr(3)))
+	assert.Equal(suite.T(), int64(3), *suite.remainingClicks(shortUrl.ID))
+
+	suite.Require().NoError(suite.repo.ResetClickBudget(suite.ctx, shortUrl.ID, nil))
+	assert.Nil(suite.T(), suite.remainingClicks(shortUrl.ID))
+}
+
+func (suite *ShortUrlCommandRepositoryTestSuite) TestReplaceDestinations_KeepsIDsByUrl() {
+	shortUrl := suite.createShortUrl("split001", nil)
+	first, err := suite.repo.ReplaceDestinations(suite.ctx, shortUrl.ID, []entities.ShortUrlDestination{
+		{Url: "https://example.com/a", Weight: 50},
+		{Url: "https://example.com/b", Weight: 50},
+	})
+	suite.Require().NoError(err)
+
+	second, err := suite.repo.ReplaceDestinations(suite.ctx, shortUrl.ID, []entities.ShortUrlDestination{
+		{Url: "https://example.com/c", Weight: 10},
+		{Url: "https://example.com/b", Weight: 90},
+	})
+	suite.Require().NoError(err)
+
+	assert.NotEqual(suite.T(), first[0].ID, second[0].ID)
+	assert.Equal(suite.T(), first[1].ID, second[1].ID)
+
+	var stored []entities.ShortUrlDestination
+	suite.Require().NoError(suite.db.Where("short_url_id = ?", shortUrl.ID).Order("position").Find(&stored).Error)
+	suite.Require().Len(stored, 2)
+	assert.Equal(suite.T(), "https://example.com/c", stored[0].Url)
+	assert.Equal(suite.T(), "https://example.com/b", stored[1].Url)
+	assert.Equal(suite.T(), 90, stored[1].Weight)
+
+	_, err = suite.repo.ReplaceDestinations(suite.ctx, shortUrl.ID, nil)
+	suite.Require().NoError(err)
+	var remaining int64
+	suite.Require().NoError(suite.db.Model(&entities.ShortUrlDestination{}).Where("short_url_id = ?", shortUrl.ID).Count(&remaining).Error)
+	assert.Zero(suite.T(), remaining)
+}
+
+func TestShortUrlCommandRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ShortUrlCommandRepositoryTestSuite))
+}
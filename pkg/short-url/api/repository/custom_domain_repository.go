@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+
+	"gorm.io/gorm"
+)
+
+type customDomainCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomDomainCommandRepository(db *gorm.DB) repositories.CustomDomainCommandRepositoryInterface {
+	return &customDomainCommandRepository{
+		db: db,
+	}
+}
+
+func (r *customDomainCommandRepository) Save(ctx context.Context, domain *entities.CustomDomain) error {
+	return r.db.WithContext(ctx).Create(domain).Error
+}
+
+func (r *customDomainCommandRepository) Update(ctx context.Context, domain *entities.CustomDomain) error {
+	return r.db.WithContext(ctx).Save(domain).Error
+}
+
+func (r *customDomainCommandRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entities.CustomDomain{}, id).Error
+}
+
+type customDomainQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomDomainQueryRepository(db *gorm.DB) repositories.CustomDomainQueryRepositoryInterface {
+	return &customDomainQueryRepository{
+		db: db,
+	}
+}
+
+// institutionOf selects the institution of userID, for use as a subquery.
+func (r *customDomainQueryRepository) institutionOf(userID uint) *gorm.DB {
+	return r.db.Model(&entities.User{}).Select("institution_id").Where("id = ?", userID)
+}
+
+// ownedBy limits a query to the domains of userID's institution. Institution
+// 0 means no institution, so its users only share the domains they created.
+func (r *customDomainQueryRepository) ownedBy(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("institution_id = (?) AND (institution_id <> 0 OR created_by = ?)", r.institutionOf(userID), userID)
+	}
+}
+
+func (r *customDomainQueryRepository) FindByIDAndUserID(ctx context.Context, id uint, userID uint) (*entities.CustomDomain, error) {
+	var domain entities.CustomDomain
+	err := r.db.WithContext(ctx).Scopes(r.ownedBy(userID)).Where("id = ?", id).First(&domain).Error
+	if err != nil {
+		return nil, err
+	}
+	return &domain, nil
+}
+
+// FindByUserID returns the domains of the user's institution ordered by host.
+func (r *customDomainQueryRepository) FindByUserID(ctx context.Context, userID uint) ([]entities.CustomDomain, error) {
+	var domains []entities.CustomDomain
+	err := r.db.WithContext(ctx).Scopes(r.ownedBy(userID)).Order("host ASC").Find(&domains).Error
+	if err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+func (r *customDomainQueryRepository) FindVerifiedByHost(ctx context.Context, host string) (*entities.CustomDomain, error) {
+	var domain entities.CustomDomain
+	err := r.db.WithContext(ctx).Where("host = ? AND verified_at IS NOT NULL", host).Take(&domain).Error
+	if err != nil {
+		return nil, err
+	}
+	return &domain, nil
+}
+
+func (r *customDomainQueryRepository) FindInstitutionIDByUserID(ctx context.Context, userID uint) (uint, error) {
+	var user entities.User
+	err := r.db.WithContext(ctx).Select("institution_id").Where("id = ?", userID).Take(&user).Error
+	if err != nil {
+		return 0, err
+	}
+	return user.InstitutionID, nil
+}
+
+// CountShortUrls counts the links on a domain that have not been deleted.
+func (r *customDomainQueryRepository) CountShortUrls(ctx context.Context, domainID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.ShortUrl{}).Where("domain_id = ?", domainID).Count(&count).Error
+	return count, err
+}
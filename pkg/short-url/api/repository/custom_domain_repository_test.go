@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type CustomDomainRepositoryTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	commandRepo  *customDomainCommandRepository
+	queryRepo    *customDomainQueryRepository
+	shortUrlRepo *shortUrlQueryRepository
+	ctx          context.Context
+}
+
+func (suite *CustomDomainRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&entities.User{}, &entities.CustomDomain{}, &entities.ShortUrl{}, &entities.ShortUrlDestination{}, &entities.UrlSafety{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.commandRepo = &customDomainCommandRepository{db: db}
+	suite.queryRepo = &customDomainQueryRepository{db: db}
+	suite.shortUrlRepo = &shortUrlQueryRepository{db: db}
+}
+
+func (suite *CustomDomainRepositoryTestSuite) SetupTest() {
+	suite.Require().NoError(suite.db.Create([]entities.User{
+		{ID: 1, InstitutionID: 10, Name: "a", Email: "a@example.com", PasswordHash: "x"},
+		{ID: 2, InstitutionID: 10, Name: "b", Email: "b@example.com", PasswordHash: "x"},
+		{ID: 3, InstitutionID: 20, Name: "c", Email: "c@example.com", PasswordHash: "x"},
+		{ID: 4, Name: "d", Email: "d@example.com", PasswordHash: "x"},
+		{ID: 5, Name: "e", Email: "e@example.com", PasswordHash: "x"},
+	}).Error)
+}
+
+func (suite *CustomDomainRepositoryTestSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM short_urls")
+	suite.db.Exec("DELETE FROM custom_domains")
+	suite.db.Exec("DELETE FROM users")
+}
+
+func (suite *CustomDomainRepositoryTestSuite) createDomain(institutionID uint, host string, verified bool) *entities.CustomDomain {
+	domain := &entities.CustomDomain{InstitutionID: institutionID, Host: host, VerificationToken: "token"}
+	if verified {
+		now := time.Now().UTC()
+		domain.VerifiedAt = &now
+	}
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, domain))
+	return domain
+}
+
+func (suite *CustomDomainRepositoryTestSuite) createShortUrl(userID uint, domainID uint, shortCode string, longUrl string) error {
+	now := time.Now().UTC()
+	return suite.db.Create(&entities.ShortUrl{
+		UserID:    userID,
+		DomainID:  domainID,
+		ShortCode: shortCode,
+		LongUrl:   longUrl,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}).Error
+}
+
+func (suite *CustomDomainRepositoryTestSuite) TestFindByUserID_ScopedToInstitution() {
+	suite.createDomain(10, "links.example.com", false)
+	suite.createDomain(10, "go.example.com", true)
+	suite.createDomain(20, "other.example.org", true)
+
+	domains, err := suite.queryRepo.FindByUserID(suite.ctx, 2)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(domains, 2)
+	assert.Equal(suite.T(), "go.example.com", domains[0].Host)
+	assert.Equal(suite.T(), "links.example.com", domains[1].Host)
+
+	_, err = suite.queryRepo.FindByIDAndUserID(suite.ctx, domains[0].ID, 3)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *CustomDomainRepositoryTestSuite) TestFindByUserID_WithoutInstitution() {
+	mine := &entities.CustomDomain{Host: "go.example.com", VerificationToken: "token", CreatedBy: 4}
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, mine))
+	// Another user without an institution can claim the same host.
+	theirs := &entities.CustomDomain{Host: "go.example.com", VerificationToken: "token", CreatedBy: 5}
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, theirs))
+
+	domains, err := suite.queryRepo.FindByUserID(suite.ctx, 4)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(domains, 1)
+	assert.Equal(suite.T(), mine.ID, domains[0].ID)
+
+	_, err = suite.queryRepo.FindByIDAndUserID(suite.ctx, mine.ID, 5)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	err = suite.commandRepo.Save(suite.ctx, &entities.CustomDomain{Host: "go.example.com", VerificationToken: "token", CreatedBy: 4})
+	assert.ErrorIs(suite.T(), err, gorm.ErrDuplicatedKey)
+}
+
+func (suite *CustomDomainRepositoryTestSuite) TestSave_HostTaken() {
+	suite.createDomain(10, "go.example.com", false)
+
+	err := suite.commandRepo.Save(suite.ctx, &entities.CustomDomain{InstitutionID: 10, Host: "go.example.com", VerificationToken: "token"})
+	assert.ErrorIs(suite.T(), err, gorm.ErrDuplicatedKey)
+
+	// An unverified claim does not keep another institution from the host,
+	// but only one of them can verify it.
+	other := suite.createDomain(20, "go.example.com", false)
+	suite.createDomain(30, "go.example.com", true)
+	now := time.Now().UTC()
+	other.VerifiedAt = &now
+	assert.ErrorIs(suite.T(), suite.commandRepo.Update(suite.ctx, other), gorm.ErrDuplicatedKey)
+}
+
+func (suite *CustomDomainRepositoryTestSuite) TestFindVerifiedByHost_SkipsUnverified() {
+	suite.createDomain(10, "links.example.com", false)
+	verified := suite.createDomain(10, "go.example.com", true)
+
+	_, err := suite.queryRepo.FindVerifiedByHost(suite.ctx, "links.example.com")
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	found, err := suite.queryRepo.FindVerifiedByHost(suite.ctx, "go.example.com")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), verified.ID, found.ID)
+}
+
+func (suite *CustomDomainRepositoryTestSuite) TestShortCode_UniquePerDomain() {
+	domain := suite.createDomain(10, "go.example.com", true)
+
+	suite.Require().NoError(suite.createShortUrl(1, 0, "promo", "https://example.com/own"))
+	suite.Require().NoError(suite.createShortUrl(2, domain.ID, "promo", "https://example.com/custom"))
+	assert.ErrorIs(suite.T(), suite.createShortUrl(3, domain.ID, "promo", "https://example.com/again"), gorm.ErrDuplicatedKey)
+
+	own, err := suite.shortUrlRepo.FindByShortCode(suite.ctx, 0, "promo")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "https://example.com/own", own.LongUrl)
+
+	custom, err := suite.shortUrlRepo.FindByShortCode(suite.ctx, domain.ID, "promo")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "https://example.com/custom", custom.LongUrl)
+
+	exists, err := suite.shortUrlRepo.ExistsByShortCode(suite.ctx, domain.ID, "other")
+	suite.Require().NoError(err)
+	assert.False(suite.T(), exists)
+
+	count, err := suite.queryRepo.CountShortUrls(suite.ctx, domain.ID)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func (suite *CustomDomainRepositoryTestSuite) TestDelete() {
+	domain := suite.createDomain(10, "go.example.com", true)
+
+	suite.Require().NoError(suite.commandRepo.Delete(suite.ctx, domain.ID))
+
+	_, err := suite.queryRepo.FindByIDAndUserID(suite.ctx, domain.ID, 1)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func TestCustomDomainRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CustomDomainRepositoryTestSuite))
+}
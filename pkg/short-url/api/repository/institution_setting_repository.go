@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+
+	"gorm.io/gorm"
+)
+
+type institutionSettingCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewInstitutionSettingCommandRepository(db *gorm.DB) repositories.InstitutionSettingCommandRepositoryInterface {
+	return &institutionSettingCommandRepository{
+		db: db,
+	}
+}
+
+// Save creates the institution's row on first use and updates it afterwards.
+func (r *institutionSettingCommandRepository) Save(ctx context.Context, setting *entities.InstitutionSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+type institutionSettingQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewInstitutionSettingQueryRepository(db *gorm.DB) repositories.InstitutionSettingQueryRepositoryInterface {
+	return &institutionSettingQueryRepository{
+		db: db,
+	}
+}
+
+func (r *institutionSettingQueryRepository) FindByUserID(ctx context.Context, userID uint) (*entities.InstitutionSetting, error) {
+	var setting entities.InstitutionSetting
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("users.institution_id, COALESCE(institution_settings.redirect_type, 0) AS redirect_type").
+		Joins("LEFT JOIN institution_settings ON institution_settings.institution_id = users.institution_id").
+		Where("users.id = ?", userID).
+		Take(&setting).Error
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
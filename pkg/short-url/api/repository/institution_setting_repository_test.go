@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type InstitutionSettingRepositoryTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	commandRepo *institutionSettingCommandRepository
+	queryRepo   *institutionSettingQueryRepository
+	ctx         context.Context
+}
+
+func (suite *InstitutionSettingRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&entities.User{}, &entities.InstitutionSetting{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.commandRepo = &institutionSettingCommandRepository{db: db}
+	suite.queryRepo = &institutionSettingQueryRepository{db: db}
+}
+
+func (suite *InstitutionSettingRepositoryTestSuite) SetupTest() {
+	suite.Require().NoError(suite.db.Create([]entities.User{
+		{ID: 1, InstitutionID: 10, Name: "a", Email: "a@example.com", PasswordHash: "x"},
+		{ID: 2, InstitutionID: 10, Name: "b", Email: "b@example.com", PasswordHash: "x"},
+		{ID: 3, InstitutionID: 20, Name: "c", Email: "c@example.com", PasswordHash: "x"},
+	}).Error)
+}
+
+func (suite *InstitutionSettingRepositoryTestSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM institution_settings")
+	suite.db.Exec("DELETE FROM users")
+}
+
+func (suite *InstitutionSettingRepositoryTestSuite) TestFindByUserID_WithoutRow() {
+	setting, err := suite.queryRepo.FindByUserID(suite.ctx, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), uint(10), setting.InstitutionID)
+	assert.Zero(suite.T(), setting.RedirectType)
+	assert.Equal(suite.T(), entities.DefaultRedirectType, setting.EffectiveRedirectType())
+}
+
+func (suite *InstitutionSettingRepositoryTestSuite) TestSave_SharedByInstitution() {
+	setting := &entities.InstitutionSetting{InstitutionID: 10, RedirectType: entities.RedirectTypeMovedPermanently, UpdatedAt: time.Now(), UpdatedBy: 1}
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, setting))
+
+	setting.RedirectType = entities.RedirectTypePermanentRedirect
+	setting.UpdatedBy = 2
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, setting))
+
+	colleague, err := suite.queryRepo.FindByUserID(suite.ctx, 2)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), entities.RedirectTypePermanentRedirect, colleague.RedirectType)
+
+	other, err := suite.queryRepo.FindByUserID(suite.ctx, 3)
+	suite.Require().NoError(err)
+	assert.Zero(suite.T(), other.RedirectType)
+
+	var count int64
+	suite.Require().NoError(suite.db.Model(&entities.InstitutionSetting{}).Count(&count).Error)
+	assert.Equal(suite.T(), int64(1), count)
+}
+
+func (suite *InstitutionSettingRepositoryTestSuite) TestFindByUserID_UnknownUser() {
+	_, err := suite.queryRepo.FindByUserID(suite.ctx, 99)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func TestInstitutionSettingRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(InstitutionSettingRepositoryTestSuite))
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"short-url/domains/dto"
 	"short-url/domains/entities"
@@ -29,18 +32,29 @@ func (r *shortUrlQueryRepository) FindByID(ctx context.Context, id uint) (*entit
 	return &shortUrl, nil
 }
 
-func (r *shortUrlQueryRepository) FindByShortCode(ctx context.Context, shortCode string) (*entities.ShortUrl, error) {
+// FindByShortCode looks a code up on one domain; domain 0 is the service's
+// own host.
+func (r *shortUrlQueryRepository) FindByShortCode(ctx context.Context, domainID uint, shortCode string) (*entities.ShortUrl, error) {
 	var shortUrl entities.ShortUrl
-	err := r.db.WithContext(ctx).Where("short_code = ? AND is_active = ?", shortCode, true).First(&shortUrl).Error
+	err := r.db.WithContext(ctx).Preload("UrlSafety").Preload("Destinations", orderDestinations).Where("domain_id = ? AND short_code = ? AND is_active = ?", domainID, shortCode, true).First(&shortUrl).Error
 	if err != nil {
 		return nil, err
 	}
 	return &shortUrl, nil
 }
 
-func (r *shortUrlQueryRepository) FindByShortCodeAndUserID(ctx context.Context, shortCode string, userID uint) (*entities.ShortUrl, error) {
+func (r *shortUrlQueryRepository) FindByShortCodeAndUserID(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error) {
 	var shortUrl entities.ShortUrl
-	err := r.db.WithContext(ctx).Where("short_code = ? AND user_id = ? AND is_active = ?", shortCode, userID, true).First(&shortUrl).Error
+	err := r.db.WithContext(ctx).Preload("Domain").Preload("Destinations", orderDestinations).Where("domain_id = ? AND short_code = ? AND user_id = ? AND is_active = ?", domainID, shortCode, userID, true).First(&shortUrl).Error
+	if err != nil {
+		return nil, err
+	}
+	return &shortUrl, nil
+}
+
+func (r *shortUrlQueryRepository) FindByShortCodeAndUserIDAnyStatus(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error) {
+	var shortUrl entities.ShortUrl
+	err := r.db.WithContext(ctx).Preload("Domain").Preload("Destinations", orderDestinations).Where("domain_id = ? AND short_code = ? AND user_id = ?", domainID, shortCode, userID).First(&shortUrl).Error
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +79,31 @@ func (r *shortUrlQueryRepository) FindByFilter(ctx context.Context, filter dto.S
 		query = query.Where("expire_at < ?", *filter.ExpiredAt)
 	}
 
+	if filter.Expired != nil {
+		now := time.Now()
+		if *filter.Expired {
+			query = query.Where("expire_at IS NOT NULL AND expire_at <= ?", now)
+		} else {
+			query = query.Where("(expire_at IS NULL OR expire_at > ?)", now)
+		}
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	if filter.LongUrlContains != "" {
+		query = query.Where("LOWER(long_url) LIKE ? ESCAPE '\\'", "%"+escapeLike(strings.ToLower(filter.LongUrlContains))+"%")
+	}
+
+	if filter.UtmCampaign != "" {
+		query = query.Where("utm_campaign = ?", filter.UtmCampaign)
+	}
+
 	if err := query.Count(&total).Error; err != nil {
 		return nil, nil, err
 	}
@@ -72,11 +111,111 @@ func (r *shortUrlQueryRepository) FindByFilter(ctx context.Context, filter dto.S
 	pagination.SetDefaults()
 	offset := pagination.GetOffset()
 
-	if err := query.Offset(offset).Limit(pagination.PageSize).Find(&shortUrls).Error; err != nil {
+	err := query.
+		Select("short_urls.*, (?) AS click_count", clickCountSubQuery(r.db)).
+		Preload("UrlSafety").
+		Preload("Domain").
+		Preload("Destinations", orderDestinations).
+		Order(shortUrlOrder(filter)).
+		Offset(offset).
+		Limit(pagination.PageSize).
+		Find(&shortUrls).Error
+	if err != nil {
 		return nil, nil, err
 	}
 
 	paginationResponse := dto.NewPaginationResponse(pagination.Page, pagination.PageSize, total)
 
 	return shortUrls, paginationResponse, nil
-}
\ No newline at end of file
+}
+
+func orderDestinations(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+func clickCountSubQuery(db *gorm.DB) *gorm.DB {
+	return db.Model(&entities.ShortClickDaily{}).
+		Select("COALESCE(SUM(num_request), 0)").
+		Where("short_click_dailies.short_url_id = short_urls.id")
+}
+
+func shortUrlOrder(filter dto.ShortUrlQueryFilter) string {
+	column := "short_urls.created_at"
+	if filter.SortBy == dto.ShortUrlSortByClickCount {
+		column = "click_count"
+	}
+
+	direction := "DESC"
+	if filter.SortOrder == dto.SortOrderAsc {
+		direction = "ASC"
+	}
+
+	return fmt.Sprintf("%s %s, short_urls.id %s", column, direction, direction)
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
+
+// FindExistingShortCodes returns which of shortCodes are already used on the
+// domain, including by soft-deleted links.
+func (r *shortUrlQueryRepository) FindExistingShortCodes(ctx context.Context, domainID uint, shortCodes []string) ([]string, error) {
+	existing := []string{}
+	if len(shortCodes) == 0 {
+		return existing, nil
+	}
+
+	err := r.db.WithContext(ctx).Unscoped().
+		Model(&entities.ShortUrl{}).
+		Where("domain_id = ? AND short_code IN ?", domainID, shortCodes).
+		Pluck("short_code", &existing).Error
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// FindActiveByLongUrlHash returns the oldest active link for a normalized URL.
+// Click-limited, password protected, scheduled, expiring, fallback,
+// forwarding, UTM tagged, targeted, split, preview and custom domain links
+// are never shared this way. With
+// sameInstitution, links of every user in the caller's institution qualify,
+// but the caller's own links are still preferred.
+func (r *shortUrlQueryRepository) FindActiveByLongUrlHash(ctx context.Context, longUrlHash string, userID uint, sameInstitution bool, now time.Time) (*entities.ShortUrl, error) {
+	query := r.db.WithContext(ctx).Preload("UrlSafety").
+		Where("short_urls.long_url_hash = ? AND short_urls.is_active = ?", longUrlHash, true).
+		Where("short_urls.expire_at IS NULL AND short_urls.fallback_url IS NULL").
+		Where("short_urls.max_clicks IS NULL AND short_urls.availability IS NULL").
+		Where("short_urls.password_hash = ''").
+		Where("short_urls.forward_query = ? AND short_urls.forward_path = ? AND short_urls.preview = ?", false, false, false).
+		Where("short_urls.domain_id = ?", 0).
+		Where("short_urls.targeting_rules IS NULL").
+		Where("NOT EXISTS (?)", r.db.Model(&entities.ShortUrlDestination{}).Select("1").Where("short_url_destinations.short_url_id = short_urls.id")).
+		Where("short_urls.utm_source = '' AND short_urls.utm_medium = '' AND short_urls.utm_campaign = '' AND short_urls.utm_term = '' AND short_urls.utm_content = ''").
+		Where("short_urls.active_from IS NULL OR short_urls.active_from <= ?", now)
+
+	if sameInstitution {
+		institutionID := r.db.Model(&entities.User{}).Select("institution_id").Where("id = ?", userID)
+		institutionUsers := r.db.Model(&entities.User{}).Select("id").Where("institution_id = (?)", institutionID)
+		query = query.Where("short_urls.user_id IN (?)", institutionUsers).
+			Order(fmt.Sprintf("CASE WHEN short_urls.user_id = %d THEN 0 ELSE 1 END", userID))
+	} else {
+		query = query.Where("short_urls.user_id = ?", userID)
+	}
+
+	var shortUrl entities.ShortUrl
+	if err := query.Order("short_urls.id").Take(&shortUrl).Error; err != nil {
+		return nil, err
+	}
+	return &shortUrl, nil
+}
+
+func (r *shortUrlQueryRepository) ExistsByShortCode(ctx context.Context, domainID uint, shortCode string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&entities.ShortUrl{}).Where("domain_id = ? AND short_code = ?", domainID, shortCode).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
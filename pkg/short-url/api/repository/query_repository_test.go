@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type ShortUrlQueryRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *shortUrlQueryRepository
+	ctx  context.Context
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&entities.User{}, &entities.CustomDomain{}, &entities.ShortUrl{}, &entities.ShortUrlDestination{}, &entities.ShortClickDaily{}, &entities.UrlSafety{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.repo = &shortUrlQueryRepository{db: db}
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM short_click_dailies")
+	suite.db.Exec("DELETE FROM url_safeties")
+	suite.db.Exec("DELETE FROM short_url_destinations")
+	suite.db.Exec("DELETE FROM short_urls")
+	suite.db.Exec("DELETE FROM users")
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) createShortUrl(userID uint, shortCode, longUrl string, createdAt time.Time, expireAt *time.Time) *entities.ShortUrl {
+	shortUrl := &entities.ShortUrl{
+		UserID:      userID,
+		ShortCode:   shortCode,
+		LongUrl:     longUrl,
+		LongUrlHash: helper.LongUrlHash(longUrl),
+		IsActive:    true,
+		ExpireAt:    expireAt,
+		CreatedAt:   createdAt,
+		CreatedBy:   userID,
+		UpdatedAt:   createdAt,
+		UpdatedBy:   userID,
+	}
+	suite.Require().NoError(suite.db.Create(shortUrl).Error)
+	return shortUrl
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindByFilter_ScopedToUser() {
+	now := time.Now().UTC()
+	suite.createShortUrl(1, "mine0001", "https://example.com/a", now, nil)
+	suite.createShortUrl(2, "other001", "https://example.com/b", now, nil)
+
+	userID := uint(1)
+	result, pagination, err := suite.repo.FindByFilter(suite.ctx, dto.ShortUrlQueryFilter{UserID: &userID}, dto.Pagination{Page: 1, PageSize: 10})
+
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "mine0001", result[0].ShortCode)
+	assert.Equal(suite.T(), int64(1), pagination.Total)
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindByFilter_Expired() {
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	suite.createShortUrl(1, "expired1", "https://example.com/a", now, &past)
+	suite.createShortUrl(1, "future01", "https://example.com/b", now, &future)
+	suite.createShortUrl(1, "forever1", "https://example.com/c", now, nil)
+
+	expired := true
+	result, _, err := suite.repo.FindByFilter(suite.ctx, dto.ShortUrlQueryFilter{Expired: &expired}, dto.Pagination{Page: 1, PageSize: 10})
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "expired1", result[0].ShortCode)
+
+	notExpired := false
+	result, _, err = suite.repo.FindByFilter(suite.ctx, dto.ShortUrlQueryFilter{Expired: &notExpired}, dto.Pagination{Page: 1, PageSize: 10})
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), result, 2)
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindByFilter_CreatedRangeAndSearch() {
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	suite.createShortUrl(1, "old00001", "https://shop.example.com/Sale", base.AddDate(0, 0, -5), nil)
+	suite.createShortUrl(1, "new00001", "https://shop.example.com/sale_2024", base, nil)
+	suite.createShortUrl(1, "new00002", "https://blog.example.com/post", base, nil)
+
+	from := base.AddDate(0, 0, -1)
+	result, _, err := suite.repo.FindByFilter(suite.ctx, dto.ShortUrlQueryFilter{CreatedFrom: &from, LongUrlContains: "SALE"}, dto.Pagination{Page: 1, PageSize: 10})
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "new00001", result[0].ShortCode)
+
+	result, _, err = suite.repo.FindByFilter(suite.ctx, dto.ShortUrlQueryFilter{LongUrlContains: "e_2"}, dto.Pagination{Page: 1, PageSize: 10})
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), "new00001", result[0].ShortCode)
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindByFilter_SortByClickCount() {
+	now := time.Now().UTC()
+	quiet := suite.createShortUrl(1, "quiet001", "https://example.com/a", now, nil)
+	busy := suite.createShortUrl(1, "busy0001", "https://example.com/b", now.Add(-time.Hour), nil)
+
+	suite.Require().NoError(suite.db.Create(&entities.ShortClickDaily{ShortUrlID: busy.ID, Date: now, NumRequest: 7}).Error)
+	suite.Require().NoError(suite.db.Create(&entities.ShortClickDaily{ShortUrlID: busy.ID, Date: now.AddDate(0, 0, -1), NumRequest: 3}).Error)
+	suite.Require().NoError(suite.db.Create(&entities.ShortClickDaily{ShortUrlID: quiet.ID, Date: now, NumRequest: 1}).Error)
+
+	result, _, err := suite.repo.FindByFilter(suite.ctx, dto.ShortUrlQueryFilter{SortBy: dto.ShortUrlSortByClickCount, SortOrder: dto.SortOrderDesc}, dto.Pagination{Page: 1, PageSize: 10})
+
+	suite.Require().NoError(err)
+	suite.Require().Len(result, 2)
+	assert.Equal(suite.T(), "busy0001", result[0].ShortCode)
+	assert.Equal(suite.T(), int64(10), result[0].ClickCount)
+	assert.Equal(suite.T(), int64(1), result[1].ClickCount)
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindByFilter_UtmCampaign() {
+	now := time.Now().UTC()
+	tagged := suite.createShortUrl(1, "spring01", "https://example.com/a", now, nil)
+	suite.Require().NoError(suite.db.Model(tagged).Update("utm_campaign", "spring").Error)
+	suite.createShortUrl(1, "untagged", "https://example.com/b", now, nil)
+
+	result, _, err := suite.repo.FindByFilter(suite.ctx, dto.ShortUrlQueryFilter{UtmCampaign: "spring"}, dto.Pagination{Page: 1, PageSize: 10})
+
+	suite.Require().NoError(err)
+	suite.Require().Len(result, 1)
+	assert.Equal(suite.T(), "spring01", result[0].ShortCode)
+	assert.Equal(suite.T(), "spring", result[0].Utm.Campaign)
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindExistingShortCodes_IncludesDeleted() {
+	now := time.Now().UTC()
+	suite.createShortUrl(1, "live0001", "https://example.com/a", now, nil)
+	deleted := suite.createShortUrl(1, "gone0001", "https://example.com/b", now, nil)
+	suite.Require().NoError(suite.db.Delete(deleted).Error)
+
+	existing, err := suite.repo.FindExistingShortCodes(suite.ctx, 0, []string{"live0001", "gone0001", "free0001"})
+
+	suite.Require().NoError(err)
+	assert.ElementsMatch(suite.T(), []string{"live0001", "gone0001"}, existing)
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindExistingShortCodes_ScopedToDomain() {
+	now := time.Now().UTC()
+	suite.createShortUrl(1, "camp0001", "https://example.com/a", now, nil)
+	onDomain := &entities.ShortUrl{UserID: 1, DomainID: 7, ShortCode: "camp0001", LongUrl: "https://example.com/b", IsActive: true}
+	suite.Require().NoError(suite.db.Create(onDomain).Error)
+
+	existing, err := suite.repo.FindExistingShortCodes(suite.ctx, 8, []string{"camp0001"})
+
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), existing)
+
+	found, err := suite.repo.FindByShortCodeAndUserIDAnyStatus(suite.ctx, 7, "camp0001", 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), onDomain.ID, found.ID)
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindActiveByLongUrlHash_Scopes() {
+	now := time.Now().UTC()
+	suite.Require().NoError(suite.db.Create([]entities.User{
+		{ID: 1, InstitutionID: 10, Name: "a", Email: "a@example.com", PasswordHash: "x"},
+		{ID: 2, InstitutionID: 10, Name: "b", Email: "b@example.com", PasswordHash: "x"},
+		{ID: 3, InstitutionID: 20, Name: "c", Email: "c@example.com", PasswordHash: "x"},
+	}).Error)
+
+	past := now.Add(-time.Hour)
+	suite.createShortUrl(1, "expired1", "https://example.com/page", now, &past)
+	colleague := suite.createShortUrl(2, "collea01", "https://EXAMPLE.com:443/page#top", now, nil)
+	suite.createShortUrl(3, "other001", "https://example.com/page", now, nil)
+	hash := helper.LongUrlHash("https://example.com/page")
+
+	_, err := suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, false, now)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	found, err := suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), colleague.ID, found.ID)
+
+	own := suite.createShortUrl(1, "own00001", "https://example.com/page", now, nil)
+	found, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), own.ID, found.ID)
+
+	suite.Require().NoError(suite.db.Model(own).Update("utm_source", "newsletter").Error)
+	found, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), colleague.ID, found.ID)
+
+	split := entities.ShortUrlDestination{ShortUrlID: colleague.ID, Url: "https://example.com/b", Weight: 1}
+	suite.Require().NoError(suite.db.Create(&split).Error)
+	_, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	suite.Require().NoError(suite.db.Delete(&split).Error)
+	found, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), colleague.ID, found.ID)
+
+	suite.Require().NoError(suite.db.Model(colleague).Update("preview", true).Error)
+	_, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	suite.Require().NoError(suite.db.Model(colleague).Update("preview", false).Error)
+
+	suite.Require().NoError(suite.db.Model(colleague).Update("password_hash", "$2a$10$hash").Error)
+	_, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	suite.Require().NoError(suite.db.Model(colleague).Update("password_hash", "").Error)
+
+	future := now.Add(time.Hour)
+	suite.Require().NoError(suite.db.Model(colleague).Update("expire_at", future).Error)
+	_, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	suite.Require().NoError(suite.db.Model(colleague).Updates(map[string]interface{}{"expire_at": nil, "fallback_url": "https://example.com/ended"}).Error)
+	_, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	suite.Require().NoError(suite.db.Model(colleague).Update("fallback_url", nil).Error)
+
+	colleague.TargetingRules = []entities.TargetingRule{{OS: []string{entities.TargetOSIOS}, Destination: "https://apps.example.com"}}
+	suite.Require().NoError(suite.db.Save(colleague).Error)
+	_, err = suite.repo.FindActiveByLongUrlHash(suite.ctx, hash, 1, true, now)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *ShortUrlQueryRepositoryTestSuite) TestFindByShortCode_PreloadsDestinationsInOrder() {
+	shortUrl := suite.createShortUrl(1, "split001", "https://example.com", time.Now().UTC(), nil)
+	suite.Require().NoError(suite.db.Create([]entities.ShortUrlDestination{
+		{ShortUrlID: shortUrl.ID, Url: "https://example.com/b", Weight: 30, Position: 1},
+		{ShortUrlID: shortUrl.ID, Url: "https://example.com/a", Weight: 70, Position: 0},
+	}).Error)
+
+	found, err := suite.repo.FindByShortCode(suite.ctx, 0, "split001")
+
+	suite.Require().NoError(err)
+	suite.Require().Len(found.Destinations, 2)
+	assert.Equal(suite.T(), "https://example.com/a", found.Destinations[0].Url)
+	assert.Equal(suite.T(), "https://example.com/b", found.Destinations[1].Url)
+}
+
+func TestShortUrlQueryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ShortUrlQueryRepositoryTestSuite))
+}
@@ -10,6 +10,25 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// setIfEqualScript compares and sets on the server, so the key cannot change
+// between the two. The expiration is passed in milliseconds.
+var setIfEqualScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+return 0
+`)
+
+// deleteIfEqualScript compares and deletes on the server, so the key cannot
+// change hands between the two.
+var deleteIfEqualScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
 type redisRepository struct {
 	client *redis.Client
 }
@@ -24,6 +43,18 @@ func (r *redisRepository) Set(ctx context.Context, key string, value interface{}
 	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
+func (r *redisRepository) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
+func (r *redisRepository) SetIfEqual(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration) (bool, error) {
+	set, err := setIfEqualScript.Run(ctx, r.client, []string{key}, expected, value, max(expiration.Milliseconds(), 1)).Int()
+	if err != nil {
+		return false, err
+	}
+	return set == 1, nil
+}
+
 func (r *redisRepository) Get(ctx context.Context, key string) (string, error) {
 	return r.client.Get(ctx, key).Result()
 }
@@ -40,6 +71,10 @@ func (r *redisRepository) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+func (r *redisRepository) DeleteIfEqual(ctx context.Context, key string, value string) error {
+	return deleteIfEqualScript.Run(ctx, r.client, []string{key}, value).Err()
+}
+
 func (r *redisRepository) Exists(ctx context.Context, key string) (bool, error) {
 	result, err := r.client.Exists(ctx, key).Result()
 	if err != nil {
@@ -58,4 +93,32 @@ func (r *redisRepository) Expire(ctx context.Context, key string, expiration tim
 
 func (r *redisRepository) TTL(ctx context.Context, key string) (time.Duration, error) {
 	return r.client.TTL(ctx, key).Result()
-}
\ No newline at end of file
+}
+
+func (r *redisRepository) Publish(ctx context.Context, channel string, message interface{}) error {
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+func (r *redisRepository) Subscribe(ctx context.Context, channel string, handle func(message string)) error {
+	pubsub := r.client.Subscribe(ctx, channel)
+	defer pubsub.Close()
+
+	// Wait for the confirmation, so a failing connection is reported here
+	// instead of being retried forever in the background.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			handle(message.Payload)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errClickBatchApplied = errors.New("click batch already applied")
+
+type shortClickDailyCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewShortClickDailyCommandRepository(db *gorm.DB) repositories.ShortClickDailyCommandRepositoryInterface {
+	return &shortClickDailyCommandRepository{
+		db: db,
+	}
+}
+
+// ApplyBatch upserts the batch into the daily rollups. The batch marker is
+// written in the same transaction, so applying a batch twice is a no-op.
+func (r *shortClickDailyCommandRepository) ApplyBatch(ctx context.Context, batchID string, counts []dto.DailyClickCount) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&entities.ClickFlushBatch{BatchID: batchID}).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errClickBatchApplied
+			}
+			return err
+		}
+
+		now := time.Now()
+		for _, count := range counts {
+			row := entities.ShortClickDaily{
+				ShortUrlID: count.ShortUrlID,
+				Date:       count.Date,
+				NumRequest: int(count.Count),
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			}
+
+			err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "short_url_id"}, {Name: "date"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"num_request": gorm.Expr("short_click_dailies.num_request + excluded.num_request"),
+					"updated_at":  now,
+				}),
+			}).Create(&row).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, errClickBatchApplied) {
+		return nil
+	}
+	return err
+}
+
+// DeleteBatchMarkersBefore removes the markers of batches applied before the
+// given time. A batch replayed after its marker is gone is counted again.
+func (r *shortClickDailyCommandRepository) DeleteBatchMarkersBefore(ctx context.Context, before time.Time) error {
+	return r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&entities.ClickFlushBatch{}).Error
+}
+
+type shortClickDailyQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewShortClickDailyQueryRepository(db *gorm.DB) repositories.ShortClickDailyQueryRepositoryInterface {
+	return &shortClickDailyQueryRepository{
+		db: db,
+	}
+}
+
+func (r *shortClickDailyQueryRepository) SumByShortUrlID(ctx context.Context, shortUrlID uint) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.ShortClickDaily{}).
+		Select("COALESCE(SUM(num_request), 0)").
+		Where("short_url_id = ?", shortUrlID).
+		Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// FindByShortUrlIDAndDateRange returns the rollups whose date falls between
+// from and to, both inclusive. Only the calendar date of each bound is used.
+func (r *shortClickDailyQueryRepository) FindByShortUrlIDAndDateRange(ctx context.Context, shortUrlID uint, from, to time.Time) ([]dto.DailyClickCount, error) {
+	var rows []entities.ShortClickDaily
+	err := r.db.WithContext(ctx).
+		Where("short_url_id = ?", shortUrlID).
+		Where("date >= ? AND date < ?", from.Format(time.DateOnly), to.AddDate(0, 0, 1).Format(time.DateOnly)).
+		Order("date ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]dto.DailyClickCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, dto.DailyClickCount{
+			ShortUrlID: row.ShortUrlID,
+			Date:       row.Date,
+			Count:      int64(row.NumRequest),
+		})
+	}
+	return counts, nil
+}
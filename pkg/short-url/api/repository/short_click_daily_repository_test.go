@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type ShortClickDailyCommandRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *shortClickDailyCommandRepository
+	ctx  context.Context
+}
+
+func (suite *ShortClickDailyCommandRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&entities.ShortClickDaily{}, &entities.ClickFlushBatch{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.repo = &shortClickDailyCommandRepository{db: db}
+}
+
+func (suite *ShortClickDailyCommandRepositoryTestSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM short_click_dailies")
+	suite.db.Exec("DELETE FROM click_flush_batches")
+}
+
+func (suite *ShortClickDailyCommandRepositoryTestSuite) TestApplyBatch_UpsertsPerDay() {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	err := suite.repo.ApplyBatch(suite.ctx, "batch-1", []dto.DailyClickCount{
+		{ShortUrlID: 1, Date: day, Count: 3},
+		{ShortUrlID: 2, Date: day, Count: 1},
+	})
+	suite.Require().NoError(err)
+
+	err = suite.repo.ApplyBatch(suite.ctx, "batch-2", []dto.DailyClickCount{
+		{ShortUrlID: 1, Date: day, Count: 4},
+		{ShortUrlID: 1, Date: day.AddDate(0, 0, 1), Count: 2},
+	})
+	suite.Require().NoError(err)
+
+	var rows []entities.ShortClickDaily
+	suite.Require().NoError(suite.db.Order("short_url_id, date").Find(&rows).Error)
+	suite.Require().Len(rows, 3)
+	assert.Equal(suite.T(), 7, rows[0].NumRequest)
+	assert.Equal(suite.T(), 2, rows[1].NumRequest)
+	assert.Equal(suite.T(), 1, rows[2].NumRequest)
+}
+
+func (suite *ShortClickDailyCommandRepositoryTestSuite) TestApplyBatch_ReplayIsNoop() {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	counts := []dto.DailyClickCount{{ShortUrlID: 1, Date: day, Count: 5}}
+
+	suite.Require().NoError(suite.repo.ApplyBatch(suite.ctx, "batch-1", counts))
+	suite.Require().NoError(suite.repo.ApplyBatch(suite.ctx, "batch-1", counts))
+
+	var row entities.ShortClickDaily
+	suite.Require().NoError(suite.db.Where("short_url_id = ?", 1).First(&row).Error)
+	assert.Equal(suite.T(), 5, row.NumRequest)
+}
+
+func (suite *ShortClickDailyCommandRepositoryTestSuite) TestDeleteBatchMarkersBefore() {
+	suite.Require().NoError(suite.repo.ApplyBatch(suite.ctx, "old", nil))
+	suite.Require().NoError(suite.repo.ApplyBatch(suite.ctx, "recent", nil))
+	suite.Require().NoError(suite.db.Model(&entities.ClickFlushBatch{}).Where("batch_id = ?", "old").Update("created_at", time.Now().Add(-48*time.Hour)).Error)
+
+	suite.Require().NoError(suite.repo.DeleteBatchMarkersBefore(suite.ctx, time.Now().Add(-24*time.Hour)))
+
+	var batchIDs []string
+	suite.Require().NoError(suite.db.Model(&entities.ClickFlushBatch{}).Pluck("batch_id", &batchIDs).Error)
+	assert.Equal(suite.T(), []string{"recent"}, batchIDs)
+}
+
+func (suite *ShortClickDailyCommandRepositoryTestSuite) TestQuery_SumAndDateRange() {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	suite.Require().NoError(suite.repo.ApplyBatch(suite.ctx, "batch-1", []dto.DailyClickCount{
+		{ShortUrlID: 1, Date: day, Count: 3},
+		{ShortUrlID: 1, Date: day.AddDate(0, 0, 2), Count: 4},
+		{ShortUrlID: 1, Date: day.AddDate(0, 0, 5), Count: 5},
+		{ShortUrlID: 2, Date: day.AddDate(0, 0, 2), Count: 9},
+	}))
+
+	queryRepo := NewShortClickDailyQueryRepository(suite.db)
+
+	total, err := queryRepo.SumByShortUrlID(suite.ctx, 1)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(12), total)
+
+	counts, err := queryRepo.FindByShortUrlIDAndDateRange(suite.ctx, 1, day, day.AddDate(0, 0, 2))
+	suite.Require().NoError(err)
+	suite.Require().Len(counts, 2)
+	assert.Equal(suite.T(), "2024-05-01", counts[0].Date.Format(time.DateOnly))
+	assert.Equal(suite.T(), int64(3), counts[0].Count)
+	assert.Equal(suite.T(), "2024-05-03", counts[1].Date.Format(time.DateOnly))
+	assert.Equal(suite.T(), int64(4), counts[1].Count)
+
+	total, err = queryRepo.SumByShortUrlID(suite.ctx, 3)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(0), total)
+}
+
+func TestShortClickDailyCommandRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ShortClickDailyCommandRepositoryTestSuite))
+}
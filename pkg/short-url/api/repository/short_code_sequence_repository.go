@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"short-url/domains/repositories"
+
+	"gorm.io/gorm"
+)
+
+type shortCodeSequenceRepository struct {
+	db           *gorm.DB
+	sequenceName string
+}
+
+// NewShortCodeSequenceRepository reads from a Postgres sequence, which hands
+// out every value once even across concurrent transactions.
+func NewShortCodeSequenceRepository(db *gorm.DB, sequenceName string) repositories.ShortCodeSequenceRepositoryInterface {
+	return &shortCodeSequenceRepository{
+		db:           db,
+		sequenceName: sequenceName,
+	}
+}
+
+func (r *shortCodeSequenceRepository) NextValue(ctx context.Context) (uint64, error) {
+	var value uint64
+	err := r.db.WithContext(ctx).Raw("SELECT nextval(?::regclass)", r.sequenceName).Scan(&value).Error
+	return value, err
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type urlSafetyCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewUrlSafetyCommandRepository(db *gorm.DB) repositories.UrlSafetyCommandRepositoryInterface {
+	return &urlSafetyCommandRepository{
+		db: db,
+	}
+}
+
+// Upsert keeps a single verdict per link, replacing the previous one.
+func (r *urlSafetyCommandRepository) Upsert(ctx context.Context, safety *entities.UrlSafety) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "short_url_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"is_safe", "checker", "reason", "checked_at"}),
+	}).Create(safety).Error
+}
+
+type urlSafetyQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewUrlSafetyQueryRepository(db *gorm.DB) repositories.UrlSafetyQueryRepositoryInterface {
+	return &urlSafetyQueryRepository{
+		db: db,
+	}
+}
+
+func (r *urlSafetyQueryRepository) FindByShortUrlID(ctx context.Context, shortUrlID uint) (*entities.UrlSafety, error) {
+	var safety entities.UrlSafety
+	err := r.db.WithContext(ctx).Where("short_url_id = ?", shortUrlID).First(&safety).Error
+	if err != nil {
+		return nil, err
+	}
+	return &safety, nil
+}
+
+// FindShortUrlsDueForCheck returns links after afterID that were never scanned
+// or were last scanned before checkedBefore, oldest link first, with their
+// last verdict.
+func (r *urlSafetyQueryRepository) FindShortUrlsDueForCheck(ctx context.Context, checkedBefore time.Time, afterID uint, limit int) ([]entities.ShortUrl, error) {
+	var shortUrls []entities.ShortUrl
+	err := r.db.WithContext(ctx).
+		Select("short_urls.*").
+		Preload("Destinations").
+		Preload("UrlSafety").
+		Joins("LEFT JOIN url_safeties ON url_safeties.short_url_id = short_urls.id AND url_safeties.deleted_at IS NULL").
+		Where("url_safeties.id IS NULL OR url_safeties.checked_at < ?", checkedBefore).
+		Where("short_urls.id > ?", afterID).
+		Order("short_urls.id ASC").
+		Limit(limit).
+		Find(&shortUrls).Error
+	if err != nil {
+		return nil, err
+	}
+	return shortUrls, nil
+}
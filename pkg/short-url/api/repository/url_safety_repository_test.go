@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type UrlSafetyRepositoryTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	commandRepo *urlSafetyCommandRepository
+	queryRepo   *urlSafetyQueryRepository
+	ctx         context.Context
+}
+
+func (suite *UrlSafetyRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&entities.ShortUrl{}, &entities.ShortUrlDestination{}, &entities.UrlSafety{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.commandRepo = &urlSafetyCommandRepository{db: db}
+	suite.queryRepo = &urlSafetyQueryRepository{db: db}
+}
+
+func (suite *UrlSafetyRepositoryTestSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM url_safeties")
+	suite.db.Exec("DELETE FROM short_urls")
+}
+
+func (suite *UrlSafetyRepositoryTestSuite) createShortUrl(shortCode string) *entities.ShortUrl {
+	shortUrl := &entities.ShortUrl{UserID: 1, ShortCode: shortCode, LongUrl: "https://example.com/" + shortCode, IsActive: true}
+	suite.Require().NoError(suite.db.Omit("User").Create(shortUrl).Error)
+	return shortUrl
+}
+
+func (suite *UrlSafetyRepositoryTestSuite) TestUpsert_ReplacesVerdict() {
+	shortUrl := suite.createShortUrl("abc")
+
+	suite.Require().NoError(suite.commandRepo.Upsert(suite.ctx, &entities.UrlSafety{ShortUrlID: shortUrl.ID, IsSafe: true, CheckedAt: time.Now()}))
+	suite.Require().NoError(suite.commandRepo.Upsert(suite.ctx, &entities.UrlSafety{
+		ShortUrlID: shortUrl.ID,
+		IsSafe:     false,
+		Checker:    "domain_blocklist",
+		Reason:     "domain example.com is blocklisted",
+		CheckedAt:  time.Now(),
+	}))
+
+	var count int64
+	suite.db.Model(&entities.UrlSafety{}).Count(&count)
+	assert.Equal(suite.T(), int64(1), count)
+
+	safety, err := suite.queryRepo.FindByShortUrlID(suite.ctx, shortUrl.ID)
+	suite.Require().NoError(err)
+	assert.False(suite.T(), safety.IsSafe)
+	assert.Equal(suite.T(), "domain_blocklist", safety.Checker)
+}
+
+func (suite *UrlSafetyRepositoryTestSuite) TestFindShortUrlsDueForCheck() {
+	cutoff := time.Now()
+	never := suite.createShortUrl("never")
+	stale := suite.createShortUrl("stale")
+	fresh := suite.createShortUrl("fresh")
+
+	suite.Require().NoError(suite.commandRepo.Upsert(suite.ctx, &entities.UrlSafety{ShortUrlID: stale.ID, IsSafe: true, CheckedAt: cutoff.Add(-time.Hour)}))
+	suite.Require().NoError(suite.commandRepo.Upsert(suite.ctx, &entities.UrlSafety{ShortUrlID: fresh.ID, IsSafe: true, CheckedAt: cutoff.Add(time.Second)}))
+
+	due, err := suite.queryRepo.FindShortUrlsDueForCheck(suite.ctx, cutoff, 0, 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(due, 2)
+	assert.Equal(suite.T(), never.ID, due[0].ID)
+	assert.Nil(suite.T(), due[0].UrlSafety)
+	assert.Equal(suite.T(), stale.ID, due[1].ID)
+	suite.Require().NotNil(due[1].UrlSafety)
+	assert.True(suite.T(), due[1].UrlSafety.IsSafe)
+
+	due, err = suite.queryRepo.FindShortUrlsDueForCheck(suite.ctx, cutoff, 0, 1)
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), due, 1)
+
+	due, err = suite.queryRepo.FindShortUrlsDueForCheck(suite.ctx, cutoff, never.ID, 10)
+	suite.Require().NoError(err)
+	suite.Require().Len(due, 1)
+	assert.Equal(suite.T(), stale.ID, due[0].ID)
+}
+
+func TestUrlSafetyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(UrlSafetyRepositoryTestSuite))
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+
+	"gorm.io/gorm"
+)
+
+type utmTemplateCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewUtmTemplateCommandRepository(db *gorm.DB) repositories.UtmTemplateCommandRepositoryInterface {
+	return &utmTemplateCommandRepository{
+		db: db,
+	}
+}
+
+func (r *utmTemplateCommandRepository) Save(ctx context.Context, template *entities.UtmTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+func (r *utmTemplateCommandRepository) Update(ctx context.Context, template *entities.UtmTemplate) error {
+	return r.db.WithContext(ctx).Save(template).Error
+}
+
+func (r *utmTemplateCommandRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&entities.UtmTemplate{}, id).Error
+}
+
+type utmTemplateQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewUtmTemplateQueryRepository(db *gorm.DB) repositories.UtmTemplateQueryRepositoryInterface {
+	return &utmTemplateQueryRepository{
+		db: db,
+	}
+}
+
+func (r *utmTemplateQueryRepository) FindByIDAndUserID(ctx context.Context, id uint, userID uint) (*entities.UtmTemplate, error) {
+	var template entities.UtmTemplate
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// FindByUserID returns the user's templates ordered by name.
+func (r *utmTemplateQueryRepository) FindByUserID(ctx context.Context, userID uint) ([]entities.UtmTemplate, error) {
+	var templates []entities.UtmTemplate
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("name ASC, id ASC").Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
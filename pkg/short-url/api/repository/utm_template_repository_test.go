@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"short-url/domains/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type UtmTemplateRepositoryTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	commandRepo *utmTemplateCommandRepository
+	queryRepo   *utmTemplateQueryRepository
+	ctx         context.Context
+}
+
+func (suite *UtmTemplateRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	suite.Require().NoError(err)
+
+	err = db.AutoMigrate(&entities.UtmTemplate{})
+	suite.Require().NoError(err)
+
+	suite.db = db
+	suite.commandRepo = &utmTemplateCommandRepository{db: db}
+	suite.queryRepo = &utmTemplateQueryRepository{db: db}
+}
+
+func (suite *UtmTemplateRepositoryTestSuite) TearDownTest() {
+	suite.db.Exec("DELETE FROM utm_templates")
+}
+
+func (suite *UtmTemplateRepositoryTestSuite) TestFindByUserID_ScopedAndSortedByName() {
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, &entities.UtmTemplate{UserID: 1, Name: "newsletter", Utm: entities.UtmParams{Source: "newsletter", Medium: "email"}}))
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, &entities.UtmTemplate{UserID: 1, Name: "ads"}))
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, &entities.UtmTemplate{UserID: 2, Name: "other"}))
+
+	templates, err := suite.queryRepo.FindByUserID(suite.ctx, 1)
+
+	suite.Require().NoError(err)
+	suite.Require().Len(templates, 2)
+	assert.Equal(suite.T(), "ads", templates[0].Name)
+	assert.Equal(suite.T(), "newsletter", templates[1].Name)
+	assert.Equal(suite.T(), entities.UtmParams{Source: "newsletter", Medium: "email"}, templates[1].Utm)
+}
+
+func (suite *UtmTemplateRepositoryTestSuite) TestFindByIDAndUserID_OtherUserAndDeleted() {
+	template := &entities.UtmTemplate{UserID: 1, Name: "newsletter"}
+	suite.Require().NoError(suite.commandRepo.Save(suite.ctx, template))
+
+	_, err := suite.queryRepo.FindByIDAndUserID(suite.ctx, template.ID, 2)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+
+	found, err := suite.queryRepo.FindByIDAndUserID(suite.ctx, template.ID, 1)
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "newsletter", found.Name)
+
+	suite.Require().NoError(suite.commandRepo.Delete(suite.ctx, template.ID))
+	_, err = suite.queryRepo.FindByIDAndUserID(suite.ctx, template.ID, 1)
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func TestUtmTemplateRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(UtmTemplateRepositoryTestSuite))
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+)
+
+const (
+	defaultStatsRangeDays = 30
+	maxStatsRangeDays     = 366
+	defaultTrendDays      = 7
+	maxTrendDays          = 365
+	defaultBreakdownLimit = 10
+	maxBreakdownLimit     = 100
+)
+
+type analyticsService struct {
+	shortUrlQueryRepo   repositories.ShortUrlQueryRepositoryInterface
+	clickDailyQueryRepo repositories.ShortClickDailyQueryRepositoryInterface
+	clickEventQueryRepo repositories.ClickEventQueryRepositoryInterface
+	location            *time.Location
+	now                 func() time.Time
+}
+
+// NewAnalyticsService builds the stats service. location is the timezone the
+// daily rollups are bucketed in and is used when a request does not ask for
+// another one.
+func NewAnalyticsService(
+	shortUrlQueryRepo repositories.ShortUrlQueryRepositoryInterface,
+	clickDailyQueryRepo repositories.ShortClickDailyQueryRepositoryInterface,
+	clickEventQueryRepo repositories.ClickEventQueryRepositoryInterface,
+	location *time.Location,
+) service.AnalyticsServiceInterface {
+	if location == nil {
+		location = time.UTC
+	}
+	return &analyticsService{
+		shortUrlQueryRepo:   shortUrlQueryRepo,
+		clickDailyQueryRepo: clickDailyQueryRepo,
+		clickEventQueryRepo: clickEventQueryRepo,
+		location:            location,
+		now:                 time.Now,
+	}
+}
+
+// GetShortUrlStats returns the click totals for a link owned by userID. The
+// timezone only decides which calendar day is "today"; rollup rows keep the
+// day they were bucketed under.
+func (s *analyticsService) GetShortUrlStats(ctx context.Context, domainID uint, shortCode string, userID uint, query dto.ShortUrlStatsQuery) (*dto.ShortUrlStatsResponse, error) {
+	location, err := s.resolveLocation(query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	trendDays := query.TrendDays
+	if trendDays == 0 {
+		trendDays = defaultTrendDays
+	}
+	if trendDays < 1 || trendDays > maxTrendDays {
+		return nil, service.ErrInvalidTrendDays
+	}
+
+	today := calendarDay(s.now().In(location))
+	from, to, err := resolveStatsRange(query.From, query.To, today)
+	if err != nil {
+		return nil, err
+	}
+
+	shortUrl, err := s.shortUrlQueryRepo.FindByShortCodeAndUserIDAnyStatus(ctx, domainID, shortCode, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.clickDailyQueryRepo.SumByShortUrlID(ctx, shortUrl.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.clickDailyQueryRepo.FindByShortUrlIDAndDateRange(ctx, shortUrl.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	trendFrom := today.AddDate(0, 0, -2*trendDays+1)
+	trendRows, err := s.clickDailyQueryRepo.FindByShortUrlIDAndDateRange(ctx, shortUrl.ID, trendFrom, today)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ShortUrlStatsResponse{
+		ShortCode:   shortUrl.ShortCode,
+		TotalClicks: total,
+		Timezone:    location.String(),
+		From:        from.Format(time.DateOnly),
+		To:          to.Format(time.DateOnly),
+		Daily:       zeroFillDaily(rows, from, to),
+		Trend:       buildTrend(trendRows, today, trendDays),
+	}, nil
+}
+
+// GetClickBreakdown groups the raw click events of a link by referrer host,
+// browser, language or split destination. Unlike the daily rollups, events carry their exact
+// timestamp, so the from/to dates are interpreted in the requested timezone.
+func (s *analyticsService) GetClickBreakdown(ctx context.Context, domainID uint, shortCode string, userID uint, dimension string, query dto.ClickBreakdownQuery) (*dto.ClickBreakdownResponse, error) {
+	switch dimension {
+	case dto.ClickDimensionReferrer, dto.ClickDimensionBrowser, dto.ClickDimensionLanguage, dto.ClickDimensionDestination:
+	default:
+		return nil, service.ErrInvalidDimension
+	}
+
+	location, err := s.resolveLocation(query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultBreakdownLimit
+	}
+	if limit < 1 || limit > maxBreakdownLimit {
+		return nil, service.ErrInvalidLimit
+	}
+
+	from, to, err := resolveStatsRange(query.From, query.To, calendarDay(s.now().In(location)))
+	if err != nil {
+		return nil, err
+	}
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, location)
+
+	shortUrl, err := s.shortUrlQueryRepo.FindByShortCodeAndUserIDAnyStatus(ctx, domainID, shortCode, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.clickEventQueryRepo.CountByShortUrlID(ctx, shortUrl.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.clickEventQueryRepo.TopValues(ctx, shortUrl.ID, dimension, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []dto.ClickBreakdownItem{}
+	}
+	if dimension == dto.ClickDimensionDestination {
+		labelDestinations(items, shortUrl)
+	}
+
+	return &dto.ClickBreakdownResponse{
+		ShortCode:   shortUrl.ShortCode,
+		Dimension:   dimension,
+		Timezone:    location.String(),
+		From:        from.Format(time.DateOnly),
+		To:          to.Format(time.DateOnly),
+		TotalClicks: total,
+		Items:       items,
+	}, nil
+}
+
+// labelDestinations adds the URL of each split destination the link still
+// has. Destinations removed since keep only their ID.
+func labelDestinations(items []dto.ClickBreakdownItem, shortUrl *entities.ShortUrl) {
+	for i := range items {
+		id, err := strconv.ParseUint(items[i].Value, 10, 64)
+		if err != nil {
+			continue
+		}
+		if destination := shortUrl.FindDestination(uint(id)); destination != nil {
+			items[i].Url = destination.Url
+		}
+	}
+}
+
+func (s *analyticsService) resolveLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return s.location, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, service.ErrInvalidTimezone
+	}
+	return location, nil
+}
+
+// calendarDay drops the clock and zone, keeping only the date as seen in t's
+// location. Day arithmetic on the result is free of DST surprises.
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func resolveStatsRange(fromStr, toStr string, today time.Time) (time.Time, time.Time, error) {
+	to := today
+	if toStr != "" {
+		parsed, err := time.Parse(time.DateOnly, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, service.ErrInvalidStatsRange
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -defaultStatsRangeDays+1)
+	if fromStr != "" {
+		parsed, err := time.Parse(time.DateOnly, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, service.ErrInvalidStatsRange
+		}
+		from = parsed
+	}
+
+	if from.After(to) || to.Sub(from) >= maxStatsRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, service.ErrInvalidStatsRange
+	}
+	return from, to, nil
+}
+
+func countsByDay(rows []dto.DailyClickCount) map[string]int64 {
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Date.Format(time.DateOnly)] += row.Count
+	}
+	return counts
+}
+
+// zeroFillDaily returns one point per day from from to to, inclusive, so
+// charts do not have to fill the gaps themselves.
+func zeroFillDaily(rows []dto.DailyClickCount, from, to time.Time) []dto.DailyClickPoint {
+	counts := countsByDay(rows)
+
+	points := make([]dto.DailyClickPoint, 0, int(to.Sub(from).Hours()/24)+1)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		points = append(points, dto.DailyClickPoint{Date: date, Clicks: counts[date]})
+	}
+	return points
+}
+
+// buildTrend compares the last days days, ending today, with the days before
+// them. The change is left empty when there is nothing to compare against.
+func buildTrend(rows []dto.DailyClickCount, today time.Time, days int) dto.ClickTrend {
+	currentFrom := today.AddDate(0, 0, -days+1)
+
+	trend := dto.ClickTrend{Days: days}
+	for _, row := range rows {
+		if calendarDay(row.Date).Before(currentFrom) {
+			trend.PreviousClicks += row.Count
+		} else {
+			trend.Clicks += row.Count
+		}
+	}
+
+	if trend.PreviousClicks > 0 {
+		change := float64(trend.Clicks-trend.PreviousClicks) / float64(trend.PreviousClicks) * 100
+		change = math.Round(change*100) / 100
+		trend.ChangePercent = &change
+	}
+	return trend
+}
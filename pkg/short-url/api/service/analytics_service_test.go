@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AnalyticsServiceTestSuite struct {
+	suite.Suite
+	ctx            context.Context
+	queryRepo      *mocks.MockShortUrlQueryRepositoryInterface
+	clickDailyRepo *mocks.MockShortClickDailyQueryRepositoryInterface
+	clickEventRepo *mocks.MockClickEventQueryRepositoryInterface
+	service        *analyticsService
+}
+
+func (suite *AnalyticsServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.queryRepo = mocks.NewMockShortUrlQueryRepositoryInterface(suite.T())
+	suite.clickDailyRepo = mocks.NewMockShortClickDailyQueryRepositoryInterface(suite.T())
+	suite.clickEventRepo = mocks.NewMockClickEventQueryRepositoryInterface(suite.T())
+	suite.service = NewAnalyticsService(suite.queryRepo, suite.clickDailyRepo, suite.clickEventRepo, time.UTC).(*analyticsService)
+	// 2024-05-10 23:30 UTC is already 2024-05-11 in Jakarta.
+	suite.service.now = func() time.Time { return time.Date(2024, 5, 10, 23, 30, 0, 0, time.UTC) }
+}
+
+func parseDay(value string) time.Time {
+	t, _ := time.Parse(time.DateOnly, value)
+	return t
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetShortUrlStats_ZeroFillsAndComputesTrend() {
+	shortUrl := &entities.ShortUrl{ID: 7, ShortCode: "abc123"}
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.clickDailyRepo.EXPECT().SumByShortUrlID(suite.ctx, uint(7)).Return(int64(42), nil)
+	suite.clickDailyRepo.EXPECT().FindByShortUrlIDAndDateRange(suite.ctx, uint(7), parseDay("2024-05-08"), parseDay("2024-05-10")).
+		Return([]dto.DailyClickCount{{ShortUrlID: 7, Date: parseDay("2024-05-09"), Count: 5}}, nil)
+	suite.clickDailyRepo.EXPECT().FindByShortUrlIDAndDateRange(suite.ctx, uint(7), parseDay("2024-05-07"), parseDay("2024-05-10")).
+		Return([]dto.DailyClickCount{
+			{ShortUrlID: 7, Date: parseDay("2024-05-07"), Count: 4},
+			{ShortUrlID: 7, Date: parseDay("2024-05-09"), Count: 5},
+			{ShortUrlID: 7, Date: parseDay("2024-05-10"), Count: 1},
+		}, nil)
+
+	stats, err := suite.service.GetShortUrlStats(suite.ctx, 0, "abc123", 1, dto.ShortUrlStatsQuery{
+		From:      "2024-05-08",
+		To:        "2024-05-10",
+		TrendDays: 2,
+	})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(42), stats.TotalClicks)
+	assert.Equal(suite.T(), "UTC", stats.Timezone)
+	assert.Equal(suite.T(), []dto.DailyClickPoint{
+		{Date: "2024-05-08", Clicks: 0},
+		{Date: "2024-05-09", Clicks: 5},
+		{Date: "2024-05-10", Clicks: 0},
+	}, stats.Daily)
+	assert.Equal(suite.T(), int64(6), stats.Trend.Clicks)
+	assert.Equal(suite.T(), int64(4), stats.Trend.PreviousClicks)
+	suite.Require().NotNil(stats.Trend.ChangePercent)
+	assert.Equal(suite.T(), 50.0, *stats.Trend.ChangePercent)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetShortUrlStats_DefaultRangeUsesRequestedTimezone() {
+	shortUrl := &entities.ShortUrl{ID: 7, ShortCode: "abc123"}
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.clickDailyRepo.EXPECT().SumByShortUrlID(suite.ctx, uint(7)).Return(int64(0), nil)
+	suite.clickDailyRepo.EXPECT().FindByShortUrlIDAndDateRange(suite.ctx, uint(7), parseDay("2024-04-12"), parseDay("2024-05-11")).Return(nil, nil)
+	suite.clickDailyRepo.EXPECT().FindByShortUrlIDAndDateRange(suite.ctx, uint(7), parseDay("2024-04-28"), parseDay("2024-05-11")).Return(nil, nil)
+
+	stats, err := suite.service.GetShortUrlStats(suite.ctx, 0, "abc123", 1, dto.ShortUrlStatsQuery{Timezone: "Asia/Jakarta"})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "Asia/Jakarta", stats.Timezone)
+	assert.Equal(suite.T(), "2024-05-11", stats.To)
+	assert.Len(suite.T(), stats.Daily, 30)
+	assert.Equal(suite.T(), 7, stats.Trend.Days)
+	assert.Nil(suite.T(), stats.Trend.ChangePercent)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetShortUrlStats_InvalidQuery() {
+	_, err := suite.service.GetShortUrlStats(suite.ctx, 0, "abc123", 1, dto.ShortUrlStatsQuery{Timezone: "Mars/Olympus"})
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidTimezone)
+
+	_, err = suite.service.GetShortUrlStats(suite.ctx, 0, "abc123", 1, dto.ShortUrlStatsQuery{From: "2024-05-10", To: "2024-05-01"})
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidStatsRange)
+
+	_, err = suite.service.GetShortUrlStats(suite.ctx, 0, "abc123", 1, dto.ShortUrlStatsQuery{From: "2022-01-01", To: "2024-01-01"})
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidStatsRange)
+
+	_, err = suite.service.GetShortUrlStats(suite.ctx, 0, "abc123", 1, dto.ShortUrlStatsQuery{TrendDays: 400})
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidTrendDays)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetShortUrlStats_NotOwner() {
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(2)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := suite.service.GetShortUrlStats(suite.ctx, 0, "abc123", 2, dto.ShortUrlStatsQuery{})
+
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+	suite.clickDailyRepo.AssertNotCalled(suite.T(), "SumByShortUrlID", mock.Anything, mock.Anything)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetClickBreakdown_UsesTimezoneDayBounds() {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	suite.Require().NoError(err)
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, jakarta)
+	end := time.Date(2024, 5, 3, 0, 0, 0, 0, jakarta)
+	items := []dto.ClickBreakdownItem{{Value: "google.com", Clicks: 3}, {Value: "", Clicks: 1}}
+
+	shortUrl := &entities.ShortUrl{ID: 7, ShortCode: "abc123"}
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.clickEventRepo.EXPECT().CountByShortUrlID(suite.ctx, uint(7), start, end).Return(int64(4), nil)
+	suite.clickEventRepo.EXPECT().TopValues(suite.ctx, uint(7), dto.ClickDimensionReferrer, start, end, 5).Return(items, nil)
+
+	breakdown, err := suite.service.GetClickBreakdown(suite.ctx, 0, "abc123", 1, dto.ClickDimensionReferrer, dto.ClickBreakdownQuery{
+		From:     "2024-05-01",
+		To:       "2024-05-02",
+		Timezone: "Asia/Jakarta",
+		Limit:    5,
+	})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(4), breakdown.TotalClicks)
+	assert.Equal(suite.T(), items, breakdown.Items)
+	assert.Equal(suite.T(), "2024-05-02", breakdown.To)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetClickBreakdown_LabelsDestinations() {
+	shortUrl := &entities.ShortUrl{ID: 7, ShortCode: "abc123", Destinations: []entities.ShortUrlDestination{
+		{ID: 11, Url: "https://example.com/a", Weight: 50},
+		{ID: 12, Url: "https://example.com/b", Weight: 50},
+	}}
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.clickEventRepo.EXPECT().CountByShortUrlID(suite.ctx, uint(7), mock.Anything, mock.Anything).Return(int64(6), nil)
+	suite.clickEventRepo.EXPECT().TopValues(suite.ctx, uint(7), dto.ClickDimensionDestination, mock.Anything, mock.Anything, 10).Return([]dto.ClickBreakdownItem{
+		{Value: "12", Clicks: 3},
+		{Value: "11", Clicks: 2},
+		{Value: "9", Clicks: 1},
+	}, nil)
+
+	breakdown, err := suite.service.GetClickBreakdown(suite.ctx, 0, "abc123", 1, dto.ClickDimensionDestination, dto.ClickBreakdownQuery{})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []dto.ClickBreakdownItem{
+		{Value: "12", Url: "https://example.com/b", Clicks: 3},
+		{Value: "11", Url: "https://example.com/a", Clicks: 2},
+		{Value: "9", Clicks: 1},
+	}, breakdown.Items)
+}
+
+func (suite *AnalyticsServiceTestSuite) TestGetClickBreakdown_InvalidQuery() {
+	_, err := suite.service.GetClickBreakdown(suite.ctx, 0, "abc123", 1, "country", dto.ClickBreakdownQuery{})
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidDimension)
+
+	_, err = suite.service.GetClickBreakdown(suite.ctx, 0, "abc123", 1, dto.ClickDimensionBrowser, dto.ClickBreakdownQuery{Limit: 500})
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidLimit)
+}
+
+func TestAnalyticsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsServiceTestSuite))
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/helper"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+)
+
+const (
+	clickEventBufferSize    = 10000
+	clickEventBatchSize     = 500
+	clickEventFlushInterval = 2 * time.Second
+	clickEventWriteTimeout  = 10 * time.Second
+)
+
+type clickEventRecorderService struct {
+	clickEventRepo repositories.ClickEventCommandRepositoryInterface
+	events         chan dto.ClickEventInput
+	batchSize      int
+	flushInterval  time.Duration
+	dropped        atomic.Int64
+}
+
+func NewClickEventRecorderService(clickEventRepo repositories.ClickEventCommandRepositoryInterface) service.ClickEventRecorderServiceInterface {
+	return &clickEventRecorderService{
+		clickEventRepo: clickEventRepo,
+		events:         make(chan dto.ClickEventInput, clickEventBufferSize),
+		batchSize:      clickEventBatchSize,
+		flushInterval:  clickEventFlushInterval,
+	}
+}
+
+// Record queues the event without blocking. When the buffer is full the event
+// is dropped; the redirect matters more than its analytics.
+func (s *clickEventRecorderService) Record(input dto.ClickEventInput) {
+	select {
+	case s.events <- input:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Run writes queued events in batches, either when a batch fills up or on
+// every flush interval, until ctx is cancelled. Whatever is still queued at
+// that point is written before returning.
+func (s *clickEventRecorderService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]dto.ClickEventInput, 0, s.batchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			s.write(append(batch, s.drainQueued()...))
+			return
+		case input := <-s.events:
+			batch = append(batch, input)
+			if len(batch) >= s.batchSize {
+				s.write(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			s.write(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (s *clickEventRecorderService) drainQueued() []dto.ClickEventInput {
+	var queued []dto.ClickEventInput
+	for {
+		select {
+		case input := <-s.events:
+			queued = append(queued, input)
+		default:
+			return queued
+		}
+	}
+}
+
+func (s *clickEventRecorderService) write(batch []dto.ClickEventInput) {
+	if dropped := s.dropped.Swap(0); dropped > 0 {
+		log.Printf("Dropped %d click events: buffer full", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	events := make([]entities.ClickEvent, 0, len(batch))
+	for _, input := range batch {
+		events = append(events, newClickEvent(input))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), clickEventWriteTimeout)
+	defer cancel()
+
+	if err := s.clickEventRepo.SaveBatch(ctx, events); err != nil {
+		log.Printf("Failed to write %d click events: %v", len(events), err)
+	}
+}
+
+func newClickEvent(input dto.ClickEventInput) entities.ClickEvent {
+	userAgent := helper.ParseUserAgent(input.UserAgent)
+
+	acceptLanguage := input.AcceptLanguage
+	if len(acceptLanguage) > 255 {
+		acceptLanguage = acceptLanguage[:255]
+	}
+
+	referrerHost := helper.ReferrerHost(input.Referrer)
+	if len(referrerHost) > 255 {
+		referrerHost = referrerHost[:255]
+	}
+
+	var destinationID *uint
+	if input.DestinationID != 0 {
+		destinationID = &input.DestinationID
+	}
+
+	return entities.ClickEvent{
+		ShortUrlID:     input.ShortUrlID,
+		ClickedAt:      input.ClickedAt,
+		ReferrerHost:   referrerHost,
+		Browser:        userAgent.Browser,
+		OS:             userAgent.OS,
+		DeviceClass:    userAgent.DeviceClass,
+		AcceptLanguage: acceptLanguage,
+		Language:       helper.PrimaryLanguage(input.AcceptLanguage),
+		IPAddress:      helper.AnonymizeIP(input.IP),
+		DestinationID:  destinationID,
+	}
+}
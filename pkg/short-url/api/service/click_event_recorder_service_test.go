@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/helper"
+	"short-url/domains/repositories/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClickEventRecorderServiceTestSuite struct {
+	suite.Suite
+	clickEventRepo *mocks.MockClickEventCommandRepositoryInterface
+	service        *clickEventRecorderService
+}
+
+func (suite *ClickEventRecorderServiceTestSuite) SetupTest() {
+	suite.clickEventRepo = mocks.NewMockClickEventCommandRepositoryInterface(suite.T())
+	suite.service = NewClickEventRecorderService(suite.clickEventRepo).(*clickEventRecorderService)
+	suite.service.batchSize = 2
+	suite.service.flushInterval = time.Hour
+}
+
+func (suite *ClickEventRecorderServiceTestSuite) TestRun_WritesFullBatchesAndFlushesOnShutdown() {
+	var batches [][]entities.ClickEvent
+	suite.clickEventRepo.EXPECT().SaveBatch(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, events []entities.ClickEvent) { batches = append(batches, events) }).
+		Return(nil)
+
+	clickedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	suite.service.Record(dto.ClickEventInput{
+		ShortUrlID:     7,
+		ClickedAt:      clickedAt,
+		Referrer:       "https://News.Example.com/article?id=1",
+		UserAgent:      "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+		AcceptLanguage: "fr;q=0.5, en-us, *;q=0.1",
+		IP:             "203.0.113.77",
+	})
+	suite.service.Record(dto.ClickEventInput{ShortUrlID: 7, ClickedAt: clickedAt, IP: "2001:db8:85a3:8d3:1319:8a2e:370:7348"})
+	suite.service.Record(dto.ClickEventInput{ShortUrlID: 8, ClickedAt: clickedAt})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		suite.service.Run(ctx)
+		close(done)
+	}()
+
+	suite.Eventually(func() bool { return len(suite.service.events) == 0 }, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+
+	suite.Require().Len(batches, 2)
+	suite.Require().Len(batches[0], 2)
+	suite.Require().Len(batches[1], 1)
+
+	assert.Equal(suite.T(), entities.ClickEvent{
+		ShortUrlID:     7,
+		ClickedAt:      clickedAt,
+		ReferrerHost:   "news.example.com",
+		Browser:        "Safari",
+		OS:             "iOS",
+		DeviceClass:    helper.DeviceClassMobile,
+		AcceptLanguage: "fr;q=0.5, en-us, *;q=0.1",
+		Language:       "en-US",
+		IPAddress:      "203.0.113.0",
+	}, batches[0][0])
+	assert.Equal(suite.T(), "2001:db8:85a3::", batches[0][1].IPAddress)
+	assert.Equal(suite.T(), helper.DeviceClassUnknown, batches[0][1].DeviceClass)
+	assert.Equal(suite.T(), uint(8), batches[1][0].ShortUrlID)
+}
+
+func (suite *ClickEventRecorderServiceTestSuite) TestRecord_DropsWhenBufferIsFull() {
+	suite.service.events = make(chan dto.ClickEventInput, 1)
+
+	suite.service.Record(dto.ClickEventInput{ShortUrlID: 1})
+	suite.service.Record(dto.ClickEventInput{ShortUrlID: 2})
+
+	assert.Len(suite.T(), suite.service.events, 1)
+	assert.Equal(suite.T(), int64(1), suite.service.dropped.Load())
+}
+
+func TestClickEventRecorderServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ClickEventRecorderServiceTestSuite))
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+)
+
+// clickFlushBatchRetention is how long a batch marker is kept. A batch is
+// replayed at the next flush after a crash, so this only has to outlast an
+// outage of Redis or of every flusher.
+const clickFlushBatchRetention = 7 * 24 * time.Hour
+
+type clickFlusherService struct {
+	clickCounterRepo repositories.ClickCounterRepositoryInterface
+	clickDailyRepo   repositories.ShortClickDailyCommandRepositoryInterface
+	interval         time.Duration
+}
+
+func NewClickFlusherService(
+	clickCounterRepo repositories.ClickCounterRepositoryInterface,
+	clickDailyRepo repositories.ShortClickDailyCommandRepositoryInterface,
+	interval time.Duration,
+) service.ClickFlusherServiceInterface {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &clickFlusherService{
+		clickCounterRepo: clickCounterRepo,
+		clickDailyRepo:   clickDailyRepo,
+		interval:         interval,
+	}
+}
+
+// Run flushes on every interval until ctx is cancelled, with a final flush on
+// the way out so a graceful shutdown does not leave counts behind.
+func (s *clickFlusherService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := s.Flush(shutdownCtx); err != nil {
+				log.Printf("Final click flush failed: %v", err)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			if err := s.Flush(ctx); err != nil {
+				log.Printf("Click flush failed: %v", err)
+			}
+		}
+	}
+}
+
+// Flush drains the live Redis counters into a new batch and then applies every
+// pending batch, including batches left behind by a crashed flush. Markers of
+// batches older than clickFlushBatchRetention are deleted last.
+func (s *clickFlusherService) Flush(ctx context.Context) error {
+	batchID, err := generateBatchID()
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.clickCounterRepo.Drain(ctx, batchID); err != nil {
+		return fmt.Errorf("failed to drain click counters: %w", err)
+	}
+
+	pending, err := s.clickCounterRepo.PendingBatches(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pending click batches: %w", err)
+	}
+
+	for _, pendingID := range pending {
+		counts, err := s.clickCounterRepo.BatchCounts(ctx, pendingID)
+		if err != nil {
+			return fmt.Errorf("failed to read click batch %s: %w", pendingID, err)
+		}
+
+		if err := s.clickDailyRepo.ApplyBatch(ctx, pendingID, counts); err != nil {
+			return fmt.Errorf("failed to apply click batch %s: %w", pendingID, err)
+		}
+
+		if err := s.clickCounterRepo.CompleteBatch(ctx, pendingID); err != nil {
+			return fmt.Errorf("failed to complete click batch %s: %w", pendingID, err)
+		}
+	}
+
+	if err := s.clickDailyRepo.DeleteBatchMarkersBefore(ctx, time.Now().Add(-clickFlushBatchRetention)); err != nil {
+		return fmt.Errorf("failed to delete old click batch markers: %w", err)
+	}
+
+	return nil
+}
+
+func generateBatchID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
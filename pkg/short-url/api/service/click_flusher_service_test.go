@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClickFlusherServiceTestSuite struct {
+	suite.Suite
+	ctx            context.Context
+	clickRepo      *mocks.MockClickCounterRepositoryInterface
+	clickDailyRepo *mocks.MockShortClickDailyCommandRepositoryInterface
+	service        service.ClickFlusherServiceInterface
+}
+
+func (suite *ClickFlusherServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.clickRepo = mocks.NewMockClickCounterRepositoryInterface(suite.T())
+	suite.clickDailyRepo = mocks.NewMockShortClickDailyCommandRepositoryInterface(suite.T())
+	suite.service = NewClickFlusherService(suite.clickRepo, suite.clickDailyRepo, time.Minute)
+}
+
+func (suite *ClickFlusherServiceTestSuite) TestFlush_AppliesNewAndLeftoverBatches() {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	var drainedID string
+
+	suite.clickRepo.EXPECT().Drain(suite.ctx, mock.AnythingOfType("string")).
+		Run(func(ctx context.Context, batchID string) { drainedID = batchID }).
+		Return(true, nil)
+	suite.clickRepo.EXPECT().PendingBatches(suite.ctx).
+		RunAndReturn(func(ctx context.Context) ([]string, error) { return []string{"crashed", drainedID}, nil })
+
+	suite.clickRepo.EXPECT().BatchCounts(suite.ctx, "crashed").Return([]dto.DailyClickCount{{ShortUrlID: 1, Date: day, Count: 2}}, nil)
+	suite.clickDailyRepo.EXPECT().ApplyBatch(suite.ctx, "crashed", []dto.DailyClickCount{{ShortUrlID: 1, Date: day, Count: 2}}).Return(nil)
+	suite.clickRepo.EXPECT().CompleteBatch(suite.ctx, "crashed").Return(nil)
+
+	suite.clickRepo.EXPECT().BatchCounts(suite.ctx, mock.MatchedBy(func(id string) bool { return id == drainedID })).
+		Return([]dto.DailyClickCount{{ShortUrlID: 2, Date: day, Count: 9}}, nil)
+	suite.clickDailyRepo.EXPECT().ApplyBatch(suite.ctx, mock.MatchedBy(func(id string) bool { return id == drainedID }), mock.Anything).Return(nil)
+	suite.clickRepo.EXPECT().CompleteBatch(suite.ctx, mock.MatchedBy(func(id string) bool { return id == drainedID })).Return(nil)
+	suite.clickDailyRepo.EXPECT().DeleteBatchMarkersBefore(suite.ctx, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= clickFlushBatchRetention && time.Since(before) < clickFlushBatchRetention+time.Minute
+	})).Return(nil)
+
+	err := suite.service.Flush(suite.ctx)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ClickFlusherServiceTestSuite) TestFlush_KeepsBatchPendingWhenApplyFails() {
+	suite.clickRepo.EXPECT().Drain(suite.ctx, mock.AnythingOfType("string")).Return(false, nil)
+	suite.clickRepo.EXPECT().PendingBatches(suite.ctx).Return([]string{"crashed"}, nil)
+	suite.clickRepo.EXPECT().BatchCounts(suite.ctx, "crashed").Return(nil, nil)
+	suite.clickDailyRepo.EXPECT().ApplyBatch(suite.ctx, "crashed", []dto.DailyClickCount(nil)).Return(assert.AnError)
+
+	err := suite.service.Flush(suite.ctx)
+
+	assert.ErrorIs(suite.T(), err, assert.AnError)
+	suite.clickRepo.AssertNotCalled(suite.T(), "CompleteBatch", mock.Anything, mock.Anything)
+}
+
+func TestClickFlusherServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ClickFlusherServiceTestSuite))
+}
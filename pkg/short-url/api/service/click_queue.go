@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"short-url/domains/repositories"
+)
+
+const (
+	clickQueueSize     = 10000
+	clickRecordTimeout = 2 * time.Second
+)
+
+// clickQueue hands redirects' clicks to a single worker that adds them to the
+// Redis counters, so neither a slow Redis nor a burst of redirects delays a
+// response. A nil *clickQueue drops every click.
+type clickQueue struct {
+	clickCounterRepo repositories.ClickCounterRepositoryInterface
+	clicks           chan queuedClick
+	dropped          atomic.Int64
+}
+
+type queuedClick struct {
+	shortUrlID uint
+	at         time.Time
+}
+
+// newClickQueue returns nil, a queue that drops every click, without a
+// clickCounterRepo.
+func newClickQueue(clickCounterRepo repositories.ClickCounterRepositoryInterface) *clickQueue {
+	if clickCounterRepo == nil {
+		return nil
+	}
+	return &clickQueue{
+		clickCounterRepo: clickCounterRepo,
+		clicks:           make(chan queuedClick, clickQueueSize),
+	}
+}
+
+// push queues the click without blocking. When the queue is full the click is
+// dropped; the redirect matters more than its count.
+func (q *clickQueue) push(shortUrlID uint, at time.Time) {
+	if q == nil {
+		return
+	}
+	select {
+	case q.clicks <- queuedClick{shortUrlID: shortUrlID, at: at}:
+	default:
+		q.dropped.Add(1)
+	}
+}
+
+// run counts queued clicks until ctx is cancelled. Whatever is still queued
+// at that point is counted before returning.
+func (q *clickQueue) run(ctx context.Context) {
+	if q == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case click := <-q.clicks:
+					q.count(click)
+				default:
+					return
+				}
+			}
+		case click := <-q.clicks:
+			q.count(click)
+		}
+	}
+}
+
+func (q *clickQueue) count(click queuedClick) {
+	if dropped := q.dropped.Swap(0); dropped > 0 {
+		log.Printf("Dropped %d clicks: queue full", dropped)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), clickRecordTimeout)
+	defer cancel()
+
+	if err := q.clickCounterRepo.Increment(ctx, click.shortUrlID, click.at); err != nil {
+		log.Printf("Failed to record click for short url %d: %v", click.shortUrlID, err)
+	}
+}
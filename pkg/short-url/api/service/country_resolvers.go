@@ -0,0 +1,130 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"short-url/domains/service"
+)
+
+type countryRange struct {
+	first   netip.Addr
+	last    netip.Addr
+	country string
+}
+
+type cidrCountryResolver struct {
+	// ranges are sorted by first address and do not overlap.
+	ranges []countryRange
+}
+
+// NewCountryResolver loads the resolver configured by GEO_IP_COUNTRY_FILE.
+// Without a file there is none and country conditions never match.
+func NewCountryResolver(countryFile string) (service.CountryResolver, error) {
+	if countryFile == "" {
+		return nil, nil
+	}
+	return NewCidrCountryResolverFromFile(countryFile)
+}
+
+// NewCidrCountryResolverFromFile loads one "network,country" pair per line,
+// for example "192.0.2.0/24,DE". Blank lines, lines starting with '#' and a
+// header row are ignored, as is anything after the second column, so
+// GeoLite2 style exports that were reduced to those two columns work as is.
+func NewCidrCountryResolverFromFile(path string) (service.CountryResolver, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ip country file: %w", err)
+	}
+	defer file.Close()
+
+	networks := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("ip country file line %d: expected network,country", lineNumber)
+		}
+		network := strings.TrimSpace(fields[0])
+		if _, err := netip.ParsePrefix(network); err != nil {
+			if lineNumber == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("ip country file line %d: %w", lineNumber, err)
+		}
+		networks[network] = strings.TrimSpace(fields[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ip country file: %w", err)
+	}
+
+	return NewCidrCountryResolver(networks)
+}
+
+// NewCidrCountryResolver builds a resolver from networks in CIDR notation to
+// country codes. Networks may not overlap.
+func NewCidrCountryResolver(networks map[string]string) (service.CountryResolver, error) {
+	ranges := make([]countryRange, 0, len(networks))
+	for network, country := range networks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, err
+		}
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if len(country) != 2 {
+			return nil, fmt.Errorf("network %s: country %q is not a two letter code", network, country)
+		}
+		prefix = prefix.Masked()
+		ranges = append(ranges, countryRange{
+			first:   prefix.Addr().Unmap(),
+			last:    lastAddr(prefix).Unmap(),
+			country: country,
+		})
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].first.Less(ranges[j].first) })
+	for i := 1; i < len(ranges); i++ {
+		if !ranges[i-1].last.Less(ranges[i].first) {
+			return nil, fmt.Errorf("networks starting at %s and %s overlap", ranges[i-1].first, ranges[i].first)
+		}
+	}
+	return &cidrCountryResolver{ranges: ranges}, nil
+}
+
+// ResolveCountry finds the range with the largest first address not after ip
+// by binary search.
+func (r *cidrCountryResolver) ResolveCountry(ip string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	i := sort.Search(len(r.ranges), func(i int) bool { return addr.Less(r.ranges[i].first) })
+	if i == 0 {
+		return ""
+	}
+	candidate := r.ranges[i-1]
+	if candidate.last.Less(addr) || candidate.first.BitLen() != addr.BitLen() {
+		return ""
+	}
+	return candidate.country
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/helper"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+
+	"gorm.io/gorm"
+)
+
+const customDomainTokenBytes = 16
+
+type customDomainService struct {
+	commandRepo   repositories.CustomDomainCommandRepositoryInterface
+	queryRepo     repositories.CustomDomainQueryRepositoryInterface
+	proofResolver service.DomainProofResolver
+	cache         *shortUrlCache
+}
+
+func NewCustomDomainService(
+	commandRepo repositories.CustomDomainCommandRepositoryInterface,
+	queryRepo repositories.CustomDomainQueryRepositoryInterface,
+	proofResolver service.DomainProofResolver,
+	redisRepo repositories.RedisRepositoryInterface,
+) service.CustomDomainServiceInterface {
+	return &customDomainService{
+		commandRepo:   commandRepo,
+		queryRepo:     queryRepo,
+		proofResolver: proofResolver,
+		cache:         newShortUrlCache(redisRepo, nil),
+	}
+}
+
+// CreateCustomDomain registers a host for the caller's institution. It stays
+// unverified, and its links unreachable, until VerifyCustomDomain finds the
+// returned token. Hosts verified by another institution cannot be claimed.
+func (s *customDomainService) CreateCustomDomain(ctx context.Context, req *dto.CreateCustomDomainRequest, userID uint) (*entities.CustomDomain, error) {
+	// A scheme or port is refused rather than dropped; links are served on
+	// the bare host only.
+	host := helper.NormalizeHost(req.Host)
+	if strings.Contains(req.Host, ":") || !helper.IsValidHostname(host) {
+		return nil, service.ErrInvalidCustomDomainHost
+	}
+
+	if err := s.checkHostAvailable(ctx, host, 0); err != nil {
+		return nil, err
+	}
+
+	institutionID, err := s.queryRepo.FindInstitutionIDByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load institution: %w", err)
+	}
+	token, err := newCustomDomainToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	domain := &entities.CustomDomain{
+		InstitutionID:     institutionID,
+		Host:              host,
+		VerificationToken: token,
+		CreatedAt:         now,
+		CreatedBy:         userID,
+		UpdatedAt:         now,
+	}
+	if err := s.commandRepo.Save(ctx, domain); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, service.ErrCustomDomainTaken
+		}
+		return nil, fmt.Errorf("failed to save custom domain: %w", err)
+	}
+	return domain, nil
+}
+
+func (s *customDomainService) ListCustomDomains(ctx context.Context, userID uint) ([]entities.CustomDomain, error) {
+	return s.queryRepo.FindByUserID(ctx, userID)
+}
+
+func (s *customDomainService) GetCustomDomain(ctx context.Context, id uint, userID uint) (*entities.CustomDomain, error) {
+	return s.queryRepo.FindByIDAndUserID(ctx, id, userID)
+}
+
+// VerifyCustomDomain marks the domain verified once its token is published
+// in the TXT record or the well-known file. Verified domains stay verified,
+// and a host verified by another institution first stays theirs.
+func (s *customDomainService) VerifyCustomDomain(ctx context.Context, id uint, userID uint) (*entities.CustomDomain, error) {
+	domain, err := s.queryRepo.FindByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.IsVerified() {
+		return domain, nil
+	}
+	if err := s.checkHostAvailable(ctx, domain.Host, domain.ID); err != nil {
+		return nil, err
+	}
+	if !s.hasProof(ctx, domain) {
+		return nil, service.ErrCustomDomainVerificationFailed
+	}
+
+	now := time.Now()
+	domain.VerifiedAt = &now
+	domain.UpdatedAt = now
+	if err := s.commandRepo.Update(ctx, domain); err != nil {
+		// Another claim was verified since the check above.
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, service.ErrCustomDomainTaken
+		}
+		return nil, fmt.Errorf("failed to update custom domain: %w", err)
+	}
+	// Until now the host was cached as the service's own.
+	s.cache.invalidateDomain(ctx, domain.Host)
+	return domain, nil
+}
+
+// DeleteCustomDomain releases a host. Domains that still serve links cannot
+// be deleted, as their codes would start resolving elsewhere.
+func (s *customDomainService) DeleteCustomDomain(ctx context.Context, id uint, userID uint) error {
+	domain, err := s.queryRepo.FindByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.queryRepo.CountShortUrls(ctx, domain.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count short urls: %w", err)
+	}
+	if count > 0 {
+		return service.ErrCustomDomainInUse
+	}
+
+	if err := s.commandRepo.Delete(ctx, domain.ID); err != nil {
+		return fmt.Errorf("failed to delete custom domain: %w", err)
+	}
+	s.cache.invalidateDomain(ctx, domain.Host)
+	return nil
+}
+
+// checkHostAvailable fails with ErrCustomDomainTaken when a domain other than
+// domainID already verified host.
+func (s *customDomainService) checkHostAvailable(ctx context.Context, host string, domainID uint) error {
+	verified, err := s.queryRepo.FindVerifiedByHost(ctx, host)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up custom domain: %w", err)
+	}
+	if verified.ID != domainID {
+		return service.ErrCustomDomainTaken
+	}
+	return nil
+}
+
+// hasProof checks the TXT record first and falls back to the well-known
+// file. Lookup failures only mean that no proof was found there.
+func (s *customDomainService) hasProof(ctx context.Context, domain *entities.CustomDomain) bool {
+	if s.proofResolver == nil {
+		return false
+	}
+
+	records, err := s.proofResolver.LookupTXT(ctx, domain.VerificationTxtName())
+	if err == nil && slices.ContainsFunc(records, func(record string) bool {
+		return strings.TrimSpace(record) == domain.VerificationToken
+	}) {
+		return true
+	}
+
+	body, err := s.proofResolver.FetchWellKnown(ctx, domain.Host, entities.CustomDomainWellKnownPath)
+	return err == nil && strings.TrimSpace(body) == domain.VerificationToken
+}
+
+func newCustomDomainToken() (string, error) {
+	token := make([]byte, customDomainTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+	servicemocks "short-url/domains/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type CustomDomainServiceTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	commandRepo *mocks.MockCustomDomainCommandRepositoryInterface
+	queryRepo   *mocks.MockCustomDomainQueryRepositoryInterface
+	resolver    *servicemocks.MockDomainProofResolver
+	redisRepo   *mocks.MockRedisRepositoryInterface
+	service     service.CustomDomainServiceInterface
+}
+
+func (suite *CustomDomainServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.commandRepo = mocks.NewMockCustomDomainCommandRepositoryInterface(suite.T())
+	suite.queryRepo = mocks.NewMockCustomDomainQueryRepositoryInterface(suite.T())
+	suite.resolver = servicemocks.NewMockDomainProofResolver(suite.T())
+	suite.redisRepo = mocks.NewMockRedisRepositoryInterface(suite.T())
+	suite.service = NewCustomDomainService(suite.commandRepo, suite.queryRepo, suite.resolver, suite.redisRepo)
+}
+
+func (suite *CustomDomainServiceTestSuite) TestCreateCustomDomain_NormalizesHost() {
+	suite.queryRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(nil, gorm.ErrRecordNotFound)
+	suite.queryRepo.EXPECT().FindInstitutionIDByUserID(suite.ctx, uint(1)).Return(uint(10), nil)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.CustomDomain")).Return(nil)
+
+	domain, err := suite.service.CreateCustomDomain(suite.ctx, &dto.CreateCustomDomainRequest{Host: " Go.Example.COM. "}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "go.example.com", domain.Host)
+	assert.Equal(suite.T(), uint(10), domain.InstitutionID)
+	assert.Len(suite.T(), domain.VerificationToken, 32)
+	assert.False(suite.T(), domain.IsVerified())
+}
+
+func (suite *CustomDomainServiceTestSuite) TestCreateCustomDomain_InvalidHost() {
+	for _, host := range []string{"", "localhost", "https://go.example.com", "go.example.com:8080", "192.0.2.1", "go_links.example.com"} {
+		_, err := suite.service.CreateCustomDomain(suite.ctx, &dto.CreateCustomDomainRequest{Host: host}, 1)
+		assert.ErrorIs(suite.T(), err, service.ErrInvalidCustomDomainHost, host)
+	}
+}
+
+func (suite *CustomDomainServiceTestSuite) TestCreateCustomDomain_Taken() {
+	suite.queryRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(nil, gorm.ErrRecordNotFound)
+	suite.queryRepo.EXPECT().FindInstitutionIDByUserID(suite.ctx, uint(1)).Return(uint(10), nil)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.CustomDomain")).Return(gorm.ErrDuplicatedKey)
+
+	_, err := suite.service.CreateCustomDomain(suite.ctx, &dto.CreateCustomDomainRequest{Host: "go.example.com"}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrCustomDomainTaken)
+}
+
+func (suite *CustomDomainServiceTestSuite) TestCreateCustomDomain_VerifiedElsewhere() {
+	suite.queryRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(&entities.CustomDomain{ID: 8, InstitutionID: 20, Host: "go.example.com"}, nil)
+
+	_, err := suite.service.CreateCustomDomain(suite.ctx, &dto.CreateCustomDomainRequest{Host: "go.example.com"}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrCustomDomainTaken)
+}
+
+func (suite *CustomDomainServiceTestSuite) TestVerifyCustomDomain_TxtRecord() {
+	domain := &entities.CustomDomain{ID: 3, Host: "go.example.com", VerificationToken: "abc"}
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(domain, nil)
+	suite.queryRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(nil, gorm.ErrRecordNotFound)
+	suite.resolver.EXPECT().LookupTXT(suite.ctx, "_short-url-verification.go.example.com").Return([]string{"v=spf1 -all", " abc "}, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, domain).Return(nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "custom_domain:go.example.com").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "custom_domain:go.example.com").Return(nil)
+
+	result, err := suite.service.VerifyCustomDomain(suite.ctx, 3, 1)
+
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.IsVerified())
+}
+
+func (suite *CustomDomainServiceTestSuite) TestVerifyCustomDomain_WellKnownFallback() {
+	domain := &entities.CustomDomain{ID: 3, Host: "go.example.com", VerificationToken: "abc"}
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(domain, nil)
+	suite.queryRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(nil, gorm.ErrRecordNotFound)
+	suite.resolver.EXPECT().LookupTXT(suite.ctx, "_short-url-verification.go.example.com").Return(nil, errors.New("no such host"))
+	suite.resolver.EXPECT().FetchWellKnown(suite.ctx, "go.example.com", "/.well-known/short-url-verification").Return("abc\n", nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, domain).Return(nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "custom_domain:go.example.com").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "custom_domain:go.example.com").Return(nil)
+
+	result, err := suite.service.VerifyCustomDomain(suite.ctx, 3, 1)
+
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.IsVerified())
+}
+
+func (suite *CustomDomainServiceTestSuite) TestVerifyCustomDomain_NoProof() {
+	domain := &entities.CustomDomain{ID: 3, Host: "go.example.com", VerificationToken: "abc"}
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(domain, nil)
+	suite.queryRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(nil, gorm.ErrRecordNotFound)
+	suite.resolver.EXPECT().LookupTXT(suite.ctx, "_short-url-verification.go.example.com").Return([]string{"other"}, nil)
+	suite.resolver.EXPECT().FetchWellKnown(suite.ctx, "go.example.com", "/.well-known/short-url-verification").Return("", errors.New("status 404"))
+
+	_, err := suite.service.VerifyCustomDomain(suite.ctx, 3, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrCustomDomainVerificationFailed)
+	assert.False(suite.T(), domain.IsVerified())
+}
+
+func (suite *CustomDomainServiceTestSuite) TestVerifyCustomDomain_VerifiedElsewhere() {
+	domain := &entities.CustomDomain{ID: 3, Host: "go.example.com", VerificationToken: "abc"}
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(domain, nil)
+	suite.queryRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(&entities.CustomDomain{ID: 8, InstitutionID: 20, Host: "go.example.com"}, nil)
+
+	_, err := suite.service.VerifyCustomDomain(suite.ctx, 3, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrCustomDomainTaken)
+	assert.False(suite.T(), domain.IsVerified())
+}
+
+func (suite *CustomDomainServiceTestSuite) TestVerifyCustomDomain_VerifiedConcurrently() {
+	domain := &entities.CustomDomain{ID: 3, Host: "go.example.com", VerificationToken: "abc"}
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(domain, nil)
+	suite.queryRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(nil, gorm.ErrRecordNotFound)
+	suite.resolver.EXPECT().LookupTXT(suite.ctx, "_short-url-verification.go.example.com").Return([]string{"abc"}, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, domain).Return(gorm.ErrDuplicatedKey)
+
+	_, err := suite.service.VerifyCustomDomain(suite.ctx, 3, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrCustomDomainTaken)
+}
+
+func (suite *CustomDomainServiceTestSuite) TestVerifyCustomDomain_AlreadyVerified() {
+	verifiedAt := time.Now().Add(-time.Hour)
+	domain := &entities.CustomDomain{ID: 3, Host: "go.example.com", VerificationToken: "abc", VerifiedAt: &verifiedAt}
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(domain, nil)
+
+	result, err := suite.service.VerifyCustomDomain(suite.ctx, 3, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), verifiedAt, *result.VerifiedAt)
+}
+
+func (suite *CustomDomainServiceTestSuite) TestDeleteCustomDomain_InUse() {
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(&entities.CustomDomain{ID: 3}, nil)
+	suite.queryRepo.EXPECT().CountShortUrls(suite.ctx, uint(3)).Return(int64(2), nil)
+
+	err := suite.service.DeleteCustomDomain(suite.ctx, 3, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrCustomDomainInUse)
+}
+
+func (suite *CustomDomainServiceTestSuite) TestDeleteCustomDomain_Unused() {
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(&entities.CustomDomain{ID: 3, Host: "go.example.com"}, nil)
+	suite.queryRepo.EXPECT().CountShortUrls(suite.ctx, uint(3)).Return(int64(0), nil)
+	suite.commandRepo.EXPECT().Delete(suite.ctx, uint(3)).Return(nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "custom_domain:go.example.com").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "custom_domain:go.example.com").Return(nil)
+
+	suite.Require().NoError(suite.service.DeleteCustomDomain(suite.ctx, 3, 1))
+}
+
+func TestCustomDomainServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CustomDomainServiceTestSuite))
+}
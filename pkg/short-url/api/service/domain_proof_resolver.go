@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"short-url/domains/service"
+)
+
+const (
+	domainProofTimeout = 5 * time.Second
+	// maxWellKnownBody is far more than a token needs; anything longer is
+	// cut off rather than read.
+	maxWellKnownBody = 1024
+)
+
+// errInternalAddress is returned for hosts that resolve to this machine or
+// the private network. Fetching from them would let anyone who adds a
+// domain probe services that are not reachable from the internet.
+var errInternalAddress = errors.New("host resolves to an internal address")
+
+type netDomainProofResolver struct {
+	resolver *net.Resolver
+	client   *http.Client
+}
+
+// NewDomainProofResolver looks up TXT records with the system resolver and
+// fetches well-known files over plain HTTP, as the domain may not have a
+// certificate for this service yet. The fetch goes straight to the host,
+// never follows redirects and refuses internal addresses.
+func NewDomainProofResolver() service.DomainProofResolver {
+	dialer := &net.Dialer{Timeout: domainProofTimeout, Control: refuseInternalAddress}
+	return &netDomainProofResolver{
+		resolver: net.DefaultResolver,
+		client: &http.Client{
+			Timeout:   domainProofTimeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// refuseInternalAddress runs after DNS resolution, right before connecting,
+// so a host cannot pass the check with one address and connect to another.
+func refuseInternalAddress(network, address string, conn syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	ip := addrPort.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return errInternalAddress
+	}
+	return nil
+}
+
+func (r *netDomainProofResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, domainProofTimeout)
+	defer cancel()
+	return r.resolver.LookupTXT(ctx, name)
+}
+
+func (r *netDomainProofResolver) FetchWellKnown(ctx context.Context, host string, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+host+path, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("well-known file answered %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxWellKnownBody))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefuseInternalAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		refused bool
+	}{
+		{"127.0.0.1:80", true},
+		{"[::1]:80", true},
+		{"10.0.0.8:80", true},
+		{"192.168.1.1:80", true},
+		{"169.254.169.254:80", true},
+		{"0.0.0.0:80", true},
+		{"[::ffff:127.0.0.1]:80", true},
+		{"93.184.216.34:80", false},
+		{"[2606:2800:220:1::]:80", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := refuseInternalAddress("tcp", tt.address, nil)
+			if tt.refused {
+				assert.ErrorIs(t, err, errInternalAddress)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFetchWellKnown_RefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("token"))
+	}))
+	defer server.Close()
+
+	_, err := NewDomainProofResolver().FetchWellKnown(context.Background(), server.Listener.Addr().String(), "/.well-known/short-url-verification")
+
+	assert.ErrorIs(t, err, errInternalAddress)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+)
+
+type institutionSettingService struct {
+	commandRepo repositories.InstitutionSettingCommandRepositoryInterface
+	queryRepo   repositories.InstitutionSettingQueryRepositoryInterface
+}
+
+func NewInstitutionSettingService(
+	commandRepo repositories.InstitutionSettingCommandRepositoryInterface,
+	queryRepo repositories.InstitutionSettingQueryRepositoryInterface,
+) service.InstitutionSettingServiceInterface {
+	return &institutionSettingService{
+		commandRepo: commandRepo,
+		queryRepo:   queryRepo,
+	}
+}
+
+// GetInstitutionSettings returns the settings of the caller's institution
+// with built-in defaults filled in for values it never set.
+func (s *institutionSettingService) GetInstitutionSettings(ctx context.Context, userID uint) (*entities.InstitutionSetting, error) {
+	setting, err := s.queryRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	setting.RedirectType = setting.EffectiveRedirectType()
+	return setting, nil
+}
+
+// UpdateInstitutionSettings changes the defaults for new links. Existing links
+// keep the values they were created with.
+func (s *institutionSettingService) UpdateInstitutionSettings(ctx context.Context, req *dto.UpdateInstitutionSettingsRequest, userID uint) (*entities.InstitutionSetting, error) {
+	setting, err := s.GetInstitutionSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RedirectType != nil {
+		if !entities.IsValidRedirectType(*req.RedirectType) {
+			return nil, service.ErrInvalidRedirectType
+		}
+		setting.RedirectType = *req.RedirectType
+	}
+
+	setting.UpdatedAt = time.Now()
+	setting.UpdatedBy = userID
+	if err := s.commandRepo.Save(ctx, setting); err != nil {
+		return nil, fmt.Errorf("failed to save institution settings: %w", err)
+	}
+	return setting, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InstitutionSettingServiceTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	commandRepo *mocks.MockInstitutionSettingCommandRepositoryInterface
+	queryRepo   *mocks.MockInstitutionSettingQueryRepositoryInterface
+	service     service.InstitutionSettingServiceInterface
+}
+
+func (suite *InstitutionSettingServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.commandRepo = mocks.NewMockInstitutionSettingCommandRepositoryInterface(suite.T())
+	suite.queryRepo = mocks.NewMockInstitutionSettingQueryRepositoryInterface(suite.T())
+	suite.service = NewInstitutionSettingService(suite.commandRepo, suite.queryRepo)
+}
+
+func (suite *InstitutionSettingServiceTestSuite) TestGetInstitutionSettings_FillsDefaults() {
+	suite.queryRepo.EXPECT().FindByUserID(suite.ctx, uint(1)).Return(&entities.InstitutionSetting{InstitutionID: 10}, nil)
+
+	setting, err := suite.service.GetInstitutionSettings(suite.ctx, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), entities.DefaultRedirectType, setting.RedirectType)
+}
+
+func (suite *InstitutionSettingServiceTestSuite) TestUpdateInstitutionSettings_SavesRedirectType() {
+	suite.queryRepo.EXPECT().FindByUserID(suite.ctx, uint(1)).Return(&entities.InstitutionSetting{InstitutionID: 10}, nil)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.MatchedBy(func(setting *entities.InstitutionSetting) bool {
+		return setting.InstitutionID == 10 && setting.RedirectType == entities.RedirectTypeMovedPermanently && setting.UpdatedBy == 1
+	})).Return(nil)
+
+	redirectType := entities.RedirectTypeMovedPermanently
+	setting, err := suite.service.UpdateInstitutionSettings(suite.ctx, &dto.UpdateInstitutionSettingsRequest{RedirectType: &redirectType}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), entities.RedirectTypeMovedPermanently, setting.RedirectType)
+}
+
+func (suite *InstitutionSettingServiceTestSuite) TestUpdateInstitutionSettings_InvalidRedirectType() {
+	suite.queryRepo.EXPECT().FindByUserID(suite.ctx, uint(1)).Return(&entities.InstitutionSetting{InstitutionID: 10}, nil)
+
+	redirectType := 303
+	_, err := suite.service.UpdateInstitutionSettings(suite.ctx, &dto.UpdateInstitutionSettingsRequest{RedirectType: &redirectType}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidRedirectType)
+	suite.commandRepo.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func TestInstitutionSettingServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(InstitutionSettingServiceTestSuite))
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// localCache is a bounded in-memory LRU whose entries also expire after a TTL.
+// A nil *localCache is a disabled cache: lookups miss and writes are dropped.
+type localCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List
+
+	// deletions counts delete and clear calls, see generation.
+	deletions uint64
+}
+
+type localCacheEntry struct {
+	key      string
+	value    any
+	expireAt time.Time
+}
+
+// newLocalCache returns nil, a disabled cache, unless capacity and ttl are
+// both positive.
+func newLocalCache(capacity int, ttl time.Duration) *localCache {
+	if capacity <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &localCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *localCache) get(key string, now time.Time) (any, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*localCacheEntry)
+	if !now.Before(entry.expireAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// set stores value for the cache's TTL, or for ttl if that is shorter, and
+// evicts the least recently used entry when the cache is full.
+func (c *localCache) set(key string, value any, ttl time.Duration, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store(key, value, ttl, now)
+}
+
+// generation changes whenever an entry is deleted or the cache cleared. Read
+// it before fetching a value from elsewhere and pass it to setIfGeneration.
+func (c *localCache) generation() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.deletions
+}
+
+// setIfGeneration is set, skipped if anything was deleted since generation
+// was read: the deletion may have been the invalidation of the value being
+// stored.
+func (c *localCache) setIfGeneration(key string, value any, ttl time.Duration, now time.Time, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.deletions != generation {
+		return
+	}
+	c.store(key, value, ttl, now)
+}
+
+// store must be called with mu held.
+func (c *localCache) store(key string, value any, ttl time.Duration, now time.Time) {
+	expireAt := now.Add(min(c.ttl, ttl))
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*localCacheEntry)
+		entry.value = value
+		entry.expireAt = expireAt
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&localCacheEntry{key: key, value: value, expireAt: expireAt})
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *localCache) delete(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deletions++
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+func (c *localCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deletions++
+	clear(c.entries)
+	c.order.Init()
+}
+
+func (c *localCache) len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// remove must be called with mu held.
+func (c *localCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*localCacheEntry).key)
+}
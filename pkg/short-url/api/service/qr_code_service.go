@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"image/color"
+	"net/url"
+	"strings"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/helper/qrcode"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+)
+
+const (
+	defaultQrSize       = 256
+	minQrSize           = 64
+	maxQrSize           = 2048
+	defaultQrMargin     = 4
+	maxQrMargin         = 16
+	defaultQrLevel      = "M"
+	defaultQrForeground = "#000000"
+	defaultQrBackground = "#ffffff"
+
+	// A code only depends on the public URL and the rendering options, so it
+	// can stay cached long after the request that produced it.
+	qrCacheTTL = 30 * 24 * time.Hour
+)
+
+type qrCodeService struct {
+	queryRepo     repositories.ShortUrlQueryRepositoryInterface
+	redisRepo     repositories.RedisRepositoryInterface
+	publicBaseUrl string
+	publicScheme  string
+}
+
+// NewQrCodeService builds the QR code renderer. publicBaseUrl is the origin
+// the public redirect is served from; the encoded URL is publicBaseUrl/<code>,
+// or the custom domain of the link with the scheme of publicBaseUrl.
+func NewQrCodeService(
+	queryRepo repositories.ShortUrlQueryRepositoryInterface,
+	redisRepo repositories.RedisRepositoryInterface,
+	publicBaseUrl string,
+) service.QrCodeServiceInterface {
+	publicScheme := "https"
+	if parsed, err := url.Parse(publicBaseUrl); err == nil && parsed.Scheme != "" {
+		publicScheme = parsed.Scheme
+	}
+	return &qrCodeService{
+		queryRepo:     queryRepo,
+		redisRepo:     redisRepo,
+		publicBaseUrl: strings.TrimRight(publicBaseUrl, "/"),
+		publicScheme:  publicScheme,
+	}
+}
+
+type qrRenderRequest struct {
+	format string
+	level  qrcode.ErrorCorrectionLevel
+	render qrcode.RenderOptions
+}
+
+// GetQrCode renders the public short URL of a link owned by userID. Ownership
+// is checked before the cache is consulted so a cached image never leaks to
+// another user.
+func (s *qrCodeService) GetQrCode(ctx context.Context, domainID uint, shortCode string, userID uint, opts dto.QrCodeOptions) (*dto.QrCodeImage, error) {
+	req, err := resolveQrOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	shortUrl, err := s.queryRepo.FindByShortCodeAndUserIDAnyStatus(ctx, domainID, shortCode, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	content := s.publicUrl(shortUrl)
+	cacheKey := qrCacheKey(content, req)
+	contentType := qrContentType(req.format)
+
+	if s.redisRepo != nil {
+		cached, err := s.redisRepo.Get(ctx, cacheKey)
+		if err == nil && cached != "" {
+			return &dto.QrCodeImage{ContentType: contentType, Data: []byte(cached)}, nil
+		}
+	}
+
+	code, err := qrcode.Encode(content, req.level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode qr code: %w", err)
+	}
+
+	var data []byte
+	if req.format == dto.QrCodeFormatSVG {
+		data = code.SVG(req.render)
+	} else {
+		data, err = code.PNG(req.render)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render qr code: %w", err)
+		}
+	}
+
+	if s.redisRepo != nil {
+		s.redisRepo.Set(ctx, cacheKey, data, qrCacheTTL)
+	}
+
+	return &dto.QrCodeImage{ContentType: contentType, Data: data}, nil
+}
+
+func (s *qrCodeService) publicUrl(shortUrl *entities.ShortUrl) string {
+	if shortUrl.Domain != nil {
+		return fmt.Sprintf("%s://%s/%s", s.publicScheme, shortUrl.Domain.Host, shortUrl.ShortCode)
+	}
+	return fmt.Sprintf("%s/%s", s.publicBaseUrl, shortUrl.ShortCode)
+}
+
+func resolveQrOptions(opts dto.QrCodeOptions) (qrRenderRequest, error) {
+	var req qrRenderRequest
+
+	req.format = strings.ToLower(opts.Format)
+	if req.format == "" {
+		req.format = dto.QrCodeFormatPNG
+	}
+	if req.format != dto.QrCodeFormatPNG && req.format != dto.QrCodeFormatSVG {
+		return req, service.ErrInvalidQrFormat
+	}
+
+	req.render.Size = opts.Size
+	if req.render.Size == 0 {
+		req.render.Size = defaultQrSize
+	}
+	if req.render.Size < minQrSize || req.render.Size > maxQrSize {
+		return req, service.ErrInvalidQrSize
+	}
+
+	req.render.Margin = defaultQrMargin
+	if opts.Margin != nil {
+		req.render.Margin = *opts.Margin
+	}
+	if req.render.Margin < 0 || req.render.Margin > maxQrMargin {
+		return req, service.ErrInvalidQrMargin
+	}
+
+	levelName := opts.ErrorCorrection
+	if levelName == "" {
+		levelName = defaultQrLevel
+	}
+	level, err := qrcode.ParseErrorCorrectionLevel(levelName)
+	if err != nil {
+		return req, service.ErrInvalidQrLevel
+	}
+	req.level = level
+
+	if req.render.Foreground, err = parseHexColor(opts.Foreground, defaultQrForeground); err != nil {
+		return req, err
+	}
+	if req.render.Background, err = parseHexColor(opts.Background, defaultQrBackground); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+// parseHexColor accepts #rgb, #rrggbb and #rrggbbaa, with or without the
+// leading '#'.
+func parseHexColor(value, fallback string) (color.NRGBA, error) {
+	if value == "" {
+		value = fallback
+	}
+	value = strings.TrimPrefix(value, "#")
+	if len(value) == 3 {
+		value = string([]byte{value[0], value[0], value[1], value[1], value[2], value[2]})
+	}
+	if len(value) == 6 {
+		value += "ff"
+	}
+	if len(value) != 8 {
+		return color.NRGBA{}, service.ErrInvalidQrColor
+	}
+
+	rgba, err := hex.DecodeString(value)
+	if err != nil {
+		return color.NRGBA{}, service.ErrInvalidQrColor
+	}
+	return color.NRGBA{R: rgba[0], G: rgba[1], B: rgba[2], A: rgba[3]}, nil
+}
+
+func qrContentType(format string) string {
+	if format == dto.QrCodeFormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// qrCacheKey covers everything that changes the rendered bytes, including the
+// encoded URL so a new public base URL never serves stale images.
+func qrCacheKey(content string, req qrRenderRequest) string {
+	return fmt.Sprintf("qr:%s:%s:%s:%d:%d:%s:%s",
+		content, req.format, req.level, req.render.Size, req.render.Margin,
+		hex.EncodeToString([]byte{req.render.Foreground.R, req.render.Foreground.G, req.render.Foreground.B, req.render.Foreground.A}),
+		hex.EncodeToString([]byte{req.render.Background.R, req.render.Background.G, req.render.Background.B, req.render.Background.A}),
+	)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/png"
+	"testing"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/helper"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type QrCodeServiceTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	queryRepo *mocks.MockShortUrlQueryRepositoryInterface
+	redisRepo *mocks.MockRedisRepositoryInterface
+	service   service.QrCodeServiceInterface
+}
+
+func (suite *QrCodeServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.queryRepo = mocks.NewMockShortUrlQueryRepositoryInterface(suite.T())
+	suite.redisRepo = mocks.NewMockRedisRepositoryInterface(suite.T())
+	suite.service = NewQrCodeService(suite.queryRepo, suite.redisRepo, "https://sho.rt/")
+}
+
+func (suite *QrCodeServiceTestSuite) TestGetQrCode_RendersAndCachesPNG() {
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).
+		Return(&entities.ShortUrl{ID: 1, ShortCode: "abc123"}, nil)
+	suite.redisRepo.EXPECT().Get(suite.ctx, "qr:https://sho.rt/abc123:png:M:200:4:000000ff:ffffffff").
+		Return("", errors.New("redis: nil"))
+	suite.redisRepo.EXPECT().Set(suite.ctx, "qr:https://sho.rt/abc123:png:M:200:4:000000ff:ffffffff", mock.Anything, qrCacheTTL).
+		Return(nil)
+
+	image, err := suite.service.GetQrCode(suite.ctx, 0, "abc123", 1, dto.QrCodeOptions{Size: 200})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "image/png", image.ContentType)
+	decoded, err := png.Decode(bytes.NewReader(image.Data))
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 200, decoded.Bounds().Dx())
+	assert.Equal(suite.T(), 200, decoded.Bounds().Dy())
+
+	// The corners belong to the quiet zone and the top-left finder pattern.
+	r, g, b, _ := decoded.At(0, 0).RGBA()
+	assert.Equal(suite.T(), [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b})
+	r, g, b, _ = decoded.At(decoded.Bounds().Dx()/2-60, decoded.Bounds().Dy()/2-60).RGBA()
+	assert.Equal(suite.T(), [3]uint32{0, 0, 0}, [3]uint32{r, g, b})
+}
+
+func (suite *QrCodeServiceTestSuite) TestGetQrCode_ServesFromCache() {
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).
+		Return(&entities.ShortUrl{ID: 1, ShortCode: "abc123"}, nil)
+	suite.redisRepo.EXPECT().Get(suite.ctx, "qr:https://sho.rt/abc123:svg:H:256:2:112233ff:ffffff00").
+		Return("<svg/>", nil)
+
+	image, err := suite.service.GetQrCode(suite.ctx, 0, "abc123", 1, dto.QrCodeOptions{
+		Format:          "SVG",
+		ErrorCorrection: "h",
+		Margin:          helper.IntPtr(2),
+		Foreground:      "#123",
+		Background:      "ffffff00",
+	})
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "image/svg+xml", image.ContentType)
+	assert.Equal(suite.T(), []byte("<svg/>"), image.Data)
+}
+
+func (suite *QrCodeServiceTestSuite) TestGetQrCode_RendersSVG() {
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).
+		Return(&entities.ShortUrl{ID: 1, ShortCode: "abc123"}, nil)
+	suite.redisRepo.EXPECT().Get(suite.ctx, mock.Anything).Return("", errors.New("redis: nil"))
+	suite.redisRepo.EXPECT().Set(suite.ctx, mock.Anything, mock.Anything, qrCacheTTL).Return(nil)
+
+	image, err := suite.service.GetQrCode(suite.ctx, 0, "abc123", 1, dto.QrCodeOptions{Format: "svg", Margin: helper.IntPtr(0)})
+
+	suite.Require().NoError(err)
+	svg := string(image.Data)
+	// "https://sho.rt/abc123" fits a version 2 symbol at level M: 25 modules.
+	assert.Contains(suite.T(), svg, `viewBox="0 0 25 25"`)
+	assert.Contains(suite.T(), svg, `fill="#000000" d="M0 0h7v1h-7z`)
+}
+
+func (suite *QrCodeServiceTestSuite) TestGetQrCode_InvalidOptions() {
+	cases := []struct {
+		opts dto.QrCodeOptions
+		err  error
+	}{
+		{dto.QrCodeOptions{Format: "gif"}, service.ErrInvalidQrFormat},
+		{dto.QrCodeOptions{Size: 32}, service.ErrInvalidQrSize},
+		{dto.QrCodeOptions{Size: 4096}, service.ErrInvalidQrSize},
+		{dto.QrCodeOptions{Margin: helper.IntPtr(-1)}, service.ErrInvalidQrMargin},
+		{dto.QrCodeOptions{ErrorCorrection: "X"}, service.ErrInvalidQrLevel},
+		{dto.QrCodeOptions{Foreground: "#12345"}, service.ErrInvalidQrColor},
+		{dto.QrCodeOptions{Background: "zzzzzz"}, service.ErrInvalidQrColor},
+	}
+
+	for _, tc := range cases {
+		_, err := suite.service.GetQrCode(suite.ctx, 0, "abc123", 1, tc.opts)
+		assert.ErrorIs(suite.T(), err, tc.err)
+	}
+}
+
+func (suite *QrCodeServiceTestSuite) TestGetQrCode_NotOwned() {
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(2)).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := suite.service.GetQrCode(suite.ctx, 0, "abc123", 2, dto.QrCodeOptions{})
+
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func TestQrCodeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(QrCodeServiceTestSuite))
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"short-url/domains/helper"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+)
+
+const (
+	ShortCodeStrategyRandom     = "random"
+	ShortCodeStrategySequence   = "sequence"
+	ShortCodeStrategyObfuscated = "obfuscated"
+
+	DefaultShortCodeAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	DefaultShortCodeLength   = 8
+
+	minShortCodeLength   = 4
+	minShortCodeAlphabet = 16
+)
+
+// NewShortCodeGenerator builds the generator selected by strategy. For the
+// random strategy length is the exact code length; the sequence based ones
+// treat it as a minimum and grow once the counter no longer fits.
+func NewShortCodeGenerator(
+	strategy string,
+	alphabet string,
+	length int,
+	salt string,
+	sequenceRepo repositories.ShortCodeSequenceRepositoryInterface,
+) (service.ShortCodeGenerator, error) {
+	switch strings.ToLower(strategy) {
+	case "", ShortCodeStrategyRandom:
+		return NewRandomShortCodeGenerator(alphabet, length)
+	case ShortCodeStrategySequence:
+		return NewSequenceShortCodeGenerator(sequenceRepo, alphabet, length)
+	case ShortCodeStrategyObfuscated:
+		return NewObfuscatedShortCodeGenerator(sequenceRepo, alphabet, length, salt)
+	}
+	return nil, fmt.Errorf("unknown short code strategy %q, expected %s, %s or %s",
+		strategy, ShortCodeStrategyRandom, ShortCodeStrategySequence, ShortCodeStrategyObfuscated)
+}
+
+// validateShortCodeConfig keeps generated codes inside what an alias may look
+// like, so they fit the short_code column and route like any other code.
+func validateShortCodeConfig(alphabet string, length int) error {
+	if length < minShortCodeLength || length > helper.MaxAliasLength {
+		return fmt.Errorf("short code length must be between %d and %d", minShortCodeLength, helper.MaxAliasLength)
+	}
+	if len(alphabet) < minShortCodeAlphabet {
+		return fmt.Errorf("short code alphabet must have at least %d characters", minShortCodeAlphabet)
+	}
+
+	seen := make(map[rune]bool, len(alphabet))
+	for _, r := range alphabet {
+		if !helper.IsValidAlias(strings.Repeat(string(r), helper.MinAliasLength)) {
+			return fmt.Errorf("short code alphabet may only contain letters, digits, '-' or '_', got %q", r)
+		}
+		if seen[r] {
+			return fmt.Errorf("short code alphabet contains %q more than once", r)
+		}
+		seen[r] = true
+	}
+	return nil
+}
+
+type randomShortCodeGenerator struct {
+	alphabet string
+	length   int
+}
+
+func NewRandomShortCodeGenerator(alphabet string, length int) (service.ShortCodeGenerator, error) {
+	if err := validateShortCodeConfig(alphabet, length); err != nil {
+		return nil, err
+	}
+	return &randomShortCodeGenerator{alphabet: alphabet, length: length}, nil
+}
+
+func (g *randomShortCodeGenerator) Name() string {
+	return ShortCodeStrategyRandom
+}
+
+// Generate draws each character uniformly. Bytes at or above the largest
+// multiple of the alphabet size are discarded to avoid modulo bias.
+func (g *randomShortCodeGenerator) Generate(ctx context.Context) (string, error) {
+	limit := 256 - 256%len(g.alphabet)
+	code := make([]byte, 0, g.length)
+	buf := make([]byte, g.length*2)
+	for len(code) < g.length {
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
+		}
+		for _, b := range buf {
+			if int(b) >= limit {
+				continue
+			}
+			code = append(code, g.alphabet[int(b)%len(g.alphabet)])
+			if len(code) == g.length {
+				break
+			}
+		}
+	}
+	return string(code), nil
+}
+
+type sequenceShortCodeGenerator struct {
+	sequenceRepo repositories.ShortCodeSequenceRepositoryInterface
+	alphabet     string
+	minLength    int
+}
+
+// NewSequenceShortCodeGenerator encodes the next database sequence value in
+// the alphabet, left padded to minLength. Codes are short and never repeat,
+// but they are predictable.
+func NewSequenceShortCodeGenerator(sequenceRepo repositories.ShortCodeSequenceRepositoryInterface, alphabet string, minLength int) (service.ShortCodeGenerator, error) {
+	if err := validateShortCodeConfig(alphabet, minLength); err != nil {
+		return nil, err
+	}
+	if sequenceRepo == nil {
+		return nil, fmt.Errorf("the %s short code strategy needs a sequence repository", ShortCodeStrategySequence)
+	}
+	return &sequenceShortCodeGenerator{sequenceRepo: sequenceRepo, alphabet: alphabet, minLength: minLength}, nil
+}
+
+func (g *sequenceShortCodeGenerator) Name() string {
+	return ShortCodeStrategySequence
+}
+
+func (g *sequenceShortCodeGenerator) Generate(ctx context.Context) (string, error) {
+	value, err := g.sequenceRepo.NextValue(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read short code sequence: %w", err)
+	}
+
+	code := encodeInAlphabet(value, g.alphabet)
+	if len(code) < g.minLength {
+		code = strings.Repeat(g.alphabet[:1], g.minLength-len(code)) + code
+	}
+	return code, nil
+}
+
+type obfuscatedShortCodeGenerator struct {
+	sequenceRepo repositories.ShortCodeSequenceRepositoryInterface
+	alphabet     []byte
+	salt         []byte
+	minLength    int
+}
+
+// NewObfuscatedShortCodeGenerator encodes sequence values the way Hashids
+// does: the alphabet is shuffled by the salt, and again per value by a
+// lottery character, so consecutive values give unrelated looking codes.
+// Changing the salt changes every future code but never an existing one.
+func NewObfuscatedShortCodeGenerator(sequenceRepo repositories.ShortCodeSequenceRepositoryInterface, alphabet string, minLength int, salt string) (service.ShortCodeGenerator, error) {
+	if err := validateShortCodeConfig(alphabet, minLength); err != nil {
+		return nil, err
+	}
+	if sequenceRepo == nil {
+		return nil, fmt.Errorf("the %s short code strategy needs a sequence repository", ShortCodeStrategyObfuscated)
+	}
+	return &obfuscatedShortCodeGenerator{
+		sequenceRepo: sequenceRepo,
+		alphabet:     consistentShuffle([]byte(alphabet), []byte(salt)),
+		salt:         []byte(salt),
+		minLength:    minLength,
+	}, nil
+}
+
+func (g *obfuscatedShortCodeGenerator) Name() string {
+	return ShortCodeStrategyObfuscated
+}
+
+func (g *obfuscatedShortCodeGenerator) Generate(ctx context.Context) (string, error) {
+	value, err := g.sequenceRepo.NextValue(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read short code sequence: %w", err)
+	}
+	return g.encode(value), nil
+}
+
+func (g *obfuscatedShortCodeGenerator) encode(value uint64) string {
+	alphabet := g.alphabet
+	lottery := alphabet[value%uint64(len(alphabet))]
+
+	buffer := append([]byte{lottery}, g.salt...)
+	buffer = append(buffer, alphabet...)
+	alphabet = consistentShuffle(alphabet, buffer[:len(alphabet)])
+	code := string(lottery) + encodeInAlphabet(value, string(alphabet))
+
+	half := len(alphabet) / 2
+	for len(code) < g.minLength {
+		alphabet = consistentShuffle(alphabet, alphabet)
+		code = string(alphabet[half:]) + code + string(alphabet[:half])
+		if excess := len(code) - g.minLength; excess > 0 {
+			code = code[excess/2 : excess/2+g.minLength]
+		}
+	}
+	return code
+}
+
+// encodeInAlphabet writes value in base len(alphabet), most significant digit
+// first.
+func encodeInAlphabet(value uint64, alphabet string) string {
+	base := uint64(len(alphabet))
+	var digits []byte
+	for {
+		digits = append(digits, alphabet[value%base])
+		value /= base
+		if value == 0 {
+			break
+		}
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// consistentShuffle is the deterministic shuffle used by Hashids: the same
+// alphabet and salt always give the same permutation.
+func consistentShuffle(alphabet, salt []byte) []byte {
+	result := append([]byte(nil), alphabet...)
+	if len(salt) == 0 {
+		return result
+	}
+	for i, v, p := len(result)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		integer := int(salt[v])
+		p += integer
+		j := (integer + v + p) % i
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
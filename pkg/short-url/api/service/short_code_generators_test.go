@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"short-url/domains/repositories/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomShortCodeGenerator_UsesAlphabetAndLength(t *testing.T) {
+	generator, err := NewRandomShortCodeGenerator("abcdefghijklmnop", 6)
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		code, err := generator.Generate(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, code, 6)
+		assert.Empty(t, strings.Trim(code, "abcdefghijklmnop"))
+	}
+}
+
+func TestSequenceShortCodeGenerator_EncodesCounter(t *testing.T) {
+	sequenceRepo := mocks.NewMockShortCodeSequenceRepositoryInterface(t)
+	sequenceRepo.EXPECT().NextValue(context.Background()).Return(uint64(1), nil).Once()
+	sequenceRepo.EXPECT().NextValue(context.Background()).Return(uint64(62), nil).Once()
+	sequenceRepo.EXPECT().NextValue(context.Background()).Return(uint64(62*62*62*62*62), nil).Once()
+
+	generator, err := NewSequenceShortCodeGenerator(sequenceRepo, DefaultShortCodeAlphabet, 4)
+	require.NoError(t, err)
+
+	for _, want := range []string{"0001", "0010", "100000"} {
+		code, err := generator.Generate(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, want, code)
+	}
+}
+
+func TestObfuscatedShortCodeGenerator_UniqueAndSaltDependent(t *testing.T) {
+	salted, err := NewObfuscatedShortCodeGenerator(nil, DefaultShortCodeAlphabet, 6, "pepper")
+	assert.Error(t, err)
+	assert.Nil(t, salted)
+
+	sequenceRepo := mocks.NewMockShortCodeSequenceRepositoryInterface(t)
+	salted, err = NewObfuscatedShortCodeGenerator(sequenceRepo, DefaultShortCodeAlphabet, 6, "pepper")
+	require.NoError(t, err)
+	other, err := NewObfuscatedShortCodeGenerator(sequenceRepo, DefaultShortCodeAlphabet, 6, "salt")
+	require.NoError(t, err)
+
+	encode := func(generator any, value uint64) string {
+		return generator.(*obfuscatedShortCodeGenerator).encode(value)
+	}
+
+	seen := make(map[string]bool)
+	for value := uint64(1); value <= 20000; value++ {
+		code := encode(salted, value)
+		assert.GreaterOrEqual(t, len(code), 6)
+		assert.False(t, seen[code], "duplicate code %s for %d", code, value)
+		seen[code] = true
+	}
+	assert.Equal(t, encode(salted, 42), encode(salted, 42))
+	assert.NotEqual(t, encode(salted, 42), encode(other, 42))
+	assert.NotEqual(t, encode(salted, 42)[1:], encode(salted, 43)[1:])
+}
+
+func TestNewShortCodeGenerator_InvalidConfig(t *testing.T) {
+	_, err := NewShortCodeGenerator("uuid", DefaultShortCodeAlphabet, 8, "", nil)
+	assert.Error(t, err)
+
+	_, err = NewShortCodeGenerator(ShortCodeStrategyRandom, DefaultShortCodeAlphabet, 11, "", nil)
+	assert.Error(t, err)
+
+	_, err = NewShortCodeGenerator(ShortCodeStrategyRandom, "abc", 8, "", nil)
+	assert.Error(t, err)
+
+	_, err = NewShortCodeGenerator(ShortCodeStrategyRandom, "0123456789abcdef/", 8, "", nil)
+	assert.Error(t, err)
+
+	_, err = NewShortCodeGenerator(ShortCodeStrategyRandom, "0123456789abcdeff", 8, "", nil)
+	assert.Error(t, err)
+
+	_, err = NewShortCodeGenerator(ShortCodeStrategySequence, DefaultShortCodeAlphabet, 8, "", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"short-url/domains/entities"
+	"short-url/domains/helper/jwt"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minLinkPasswordLength = 4
+	maxLinkPasswordLength = 72 // bcrypt ignores anything longer
+
+	// maxPasswordAttempts failed attempts from one IP lock a link for that IP
+	// until passwordAttemptWindow has passed since the first failure.
+	maxPasswordAttempts   = 5
+	passwordAttemptWindow = 15 * time.Minute
+)
+
+type shortUrlAccessService struct {
+	redisRepo repositories.RedisRepositoryInterface
+	secretKey string
+	accessTTL time.Duration
+}
+
+// NewShortUrlAccessService builds the password gate for protected links.
+// Access tokens are signed with secretKey and stay valid for accessTTL.
+func NewShortUrlAccessService(
+	redisRepo repositories.RedisRepositoryInterface,
+	secretKey string,
+	accessTTL time.Duration,
+) service.ShortUrlAccessServiceInterface {
+	return &shortUrlAccessService{
+		redisRepo: redisRepo,
+		secretKey: secretKey,
+		accessTTL: accessTTL,
+	}
+}
+
+// Unlock refuses without comparing once the IP has used up its attempts, so
+// a locked out client cannot keep the bcrypt comparison busy either.
+func (s *shortUrlAccessService) Unlock(ctx context.Context, shortUrl *entities.ShortUrl, password string, clientIP string) (string, time.Time, error) {
+	attemptsKey := passwordAttemptsKey(shortUrl.ShortCode, clientIP)
+	if attempts, err := s.redisRepo.GetInt(ctx, attemptsKey); err == nil && attempts >= maxPasswordAttempts {
+		return "", time.Time{}, service.ErrTooManyPasswordAttempts
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(shortUrl.PasswordHash), []byte(password)); err != nil {
+		s.recordFailedAttempt(ctx, attemptsKey)
+		return "", time.Time{}, service.ErrIncorrectLinkPassword
+	}
+
+	s.redisRepo.Delete(ctx, attemptsKey)
+
+	token, expiresAt, err := jwt.GenerateLinkAccessToken(shortUrl.ShortCode, passwordFingerprint(shortUrl.PasswordHash), s.secretKey, s.accessTTL)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+func (s *shortUrlAccessService) HasAccess(shortUrl *entities.ShortUrl, token string) bool {
+	if !shortUrl.IsPasswordProtected() {
+		return true
+	}
+	if token == "" {
+		return false
+	}
+
+	claims, err := jwt.ValidateLinkAccessToken(token, s.secretKey)
+	if err != nil {
+		return false
+	}
+	return claims.ShortCode == shortUrl.ShortCode && claims.PasswordFingerprint == passwordFingerprint(shortUrl.PasswordHash)
+}
+
+// recordFailedAttempt starts the window on the first failure only, so retries
+// do not keep extending the lockout.
+func (s *shortUrlAccessService) recordFailedAttempt(ctx context.Context, attemptsKey string) {
+	attempts, err := s.redisRepo.Increment(ctx, attemptsKey)
+	if err != nil {
+		log.Printf("Failed to record password attempt %s: %v", attemptsKey, err)
+		return
+	}
+	if attempts == 1 {
+		if err := s.redisRepo.Expire(ctx, attemptsKey, passwordAttemptWindow); err != nil {
+			log.Printf("Failed to expire password attempts %s: %v", attemptsKey, err)
+		}
+	}
+}
+
+// hashLinkPassword validates and hashes a password set on a link.
+func hashLinkPassword(password string) (string, error) {
+	if len(password) < minLinkPasswordLength || len(password) > maxLinkPasswordLength {
+		return "", service.ErrInvalidLinkPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// passwordFingerprint identifies a password hash without exposing it. Every
+// bcrypt hash has its own salt, so setting the same password again still
+// gives a new fingerprint.
+func passwordFingerprint(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+	return hex.EncodeToString(sum[:8])
+}
+
+func passwordAttemptsKey(shortCode, clientIP string) string {
+	return fmt.Sprintf("link_password_attempts:%s:%s", shortCode, clientIP)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"short-url/domains/entities"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShortUrlAccessServiceTestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	redisRepo *mocks.MockRedisRepositoryInterface
+	service   service.ShortUrlAccessServiceInterface
+	shortUrl  *entities.ShortUrl
+}
+
+func (suite *ShortUrlAccessServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.redisRepo = mocks.NewMockRedisRepositoryInterface(suite.T())
+	suite.service = NewShortUrlAccessService(suite.redisRepo, "test-secret", time.Minute)
+
+	passwordHash, err := hashLinkPassword("open sesame")
+	suite.Require().NoError(err)
+	suite.shortUrl = &entities.ShortUrl{ID: 1, ShortCode: "abc123", LongUrl: "https://example.com", PasswordHash: passwordHash}
+}
+
+func (suite *ShortUrlAccessServiceTestSuite) TestUnlock_CorrectPasswordGrantsAccess() {
+	suite.redisRepo.EXPECT().GetInt(suite.ctx, "link_password_attempts:abc123:192.0.2.1").Return(0, errors.New("redis: nil"))
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "link_password_attempts:abc123:192.0.2.1").Return(nil)
+
+	token, expiresAt, err := suite.service.Unlock(suite.ctx, suite.shortUrl, "open sesame", "192.0.2.1")
+
+	suite.Require().NoError(err)
+	assert.WithinDuration(suite.T(), time.Now().Add(time.Minute), expiresAt, 5*time.Second)
+	assert.True(suite.T(), suite.service.HasAccess(suite.shortUrl, token))
+	assert.False(suite.T(), suite.service.HasAccess(&entities.ShortUrl{ShortCode: "other1", PasswordHash: suite.shortUrl.PasswordHash}, token))
+}
+
+func (suite *ShortUrlAccessServiceTestSuite) TestUnlock_IncorrectPasswordCountsAttempt() {
+	suite.redisRepo.EXPECT().GetInt(suite.ctx, "link_password_attempts:abc123:192.0.2.1").Return(0, errors.New("redis: nil"))
+	suite.redisRepo.EXPECT().Increment(suite.ctx, "link_password_attempts:abc123:192.0.2.1").Return(1, nil)
+	suite.redisRepo.EXPECT().Expire(suite.ctx, "link_password_attempts:abc123:192.0.2.1", passwordAttemptWindow).Return(nil)
+
+	token, _, err := suite.service.Unlock(suite.ctx, suite.shortUrl, "guess", "192.0.2.1")
+
+	assert.ErrorIs(suite.T(), err, service.ErrIncorrectLinkPassword)
+	assert.Empty(suite.T(), token)
+}
+
+func (suite *ShortUrlAccessServiceTestSuite) TestUnlock_LockedOutAfterMaxAttempts() {
+	suite.redisRepo.EXPECT().GetInt(suite.ctx, "link_password_attempts:abc123:192.0.2.1").Return(maxPasswordAttempts, nil)
+
+	_, _, err := suite.service.Unlock(suite.ctx, suite.shortUrl, "open sesame", "192.0.2.1")
+
+	assert.ErrorIs(suite.T(), err, service.ErrTooManyPasswordAttempts)
+}
+
+func (suite *ShortUrlAccessServiceTestSuite) TestHasAccess_RevokedByPasswordChange() {
+	suite.redisRepo.EXPECT().GetInt(suite.ctx, "link_password_attempts:abc123:192.0.2.1").Return(0, errors.New("redis: nil"))
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "link_password_attempts:abc123:192.0.2.1").Return(nil)
+
+	token, _, err := suite.service.Unlock(suite.ctx, suite.shortUrl, "open sesame", "192.0.2.1")
+	suite.Require().NoError(err)
+
+	suite.shortUrl.PasswordHash, err = hashLinkPassword("open sesame")
+	suite.Require().NoError(err)
+
+	assert.False(suite.T(), suite.service.HasAccess(suite.shortUrl, token))
+	assert.False(suite.T(), suite.service.HasAccess(suite.shortUrl, ""))
+	assert.False(suite.T(), suite.service.HasAccess(suite.shortUrl, "not-a-token"))
+	assert.True(suite.T(), suite.service.HasAccess(&entities.ShortUrl{ShortCode: "open01"}, ""))
+}
+
+func TestShortUrlAccessServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ShortUrlAccessServiceTestSuite))
+}
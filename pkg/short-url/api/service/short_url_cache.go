@@ -0,0 +1,507 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+)
+
+const (
+	// shortUrlCacheTTL bounds how long a link is served from the cache. Every
+	// change to a link invalidates its entry, so this only limits how long an
+	// entry missed by an invalidation can live.
+	shortUrlCacheTTL = 24 * time.Hour
+
+	// missingCacheTTL is how long a code or host that did not resolve is
+	// remembered. Creating a link clears the entry for its code, the short
+	// TTL limits what probing random codes or hosts can put into Redis.
+	missingCacheTTL = 30 * time.Second
+
+	// customDomainCacheTTL is how long a resolved custom domain is cached.
+	// Verifying or deleting a domain clears its entry.
+	customDomainCacheTTL = 10 * time.Minute
+
+	// missingCacheEntry is stored for codes without an active link.
+	missingCacheEntry = "-"
+
+	// reloadingCacheEntry prefixes the placeholder a reload stores before it
+	// queries the database. Lookups treat it as a miss.
+	reloadingCacheEntry = "reloading:"
+
+	// shortUrlCacheChannel carries the keys of invalidated entries to the
+	// in-process tier of every instance.
+	shortUrlCacheChannel = "short_url_cache:invalidate"
+
+	// cacheSubscribeRetryDelay is how long listen waits before subscribing
+	// again after the subscription failed.
+	cacheSubscribeRetryDelay = 5 * time.Second
+
+	// shortUrlLockTTL bounds how long one instance holds the right to reload
+	// a link into the cache, and so how long the others wait for it.
+	shortUrlLockTTL = 2 * time.Second
+
+	// shortUrlLockPoll is how often an instance waiting for another one's
+	// reload looks for the entry.
+	shortUrlLockPoll = 20 * time.Millisecond
+)
+
+// shortUrlCache keeps what the public redirect looks up in Redis: whole link
+// records keyed by domain and code, and the custom domain of each host. A
+// hit answers without touching the database. Without a redisRepo every
+// lookup misses and every write is skipped.
+//
+// An optional local tier keeps the same entries in memory for a short TTL,
+// so hot links skip the Redis round trip too. Invalidations are published
+// over Redis and dropped from the local tier of every instance by listen.
+type shortUrlCache struct {
+	redisRepo repositories.RedisRepositoryInterface
+	local     *localCache
+
+	localHits   atomic.Int64
+	localMisses atomic.Int64
+	redisHits   atomic.Int64
+	redisMisses atomic.Int64
+}
+
+// newShortUrlCache builds the cache. local may be nil; services that only
+// invalidate entries pass nil and still reach the local tiers of all
+// instances through Redis.
+func newShortUrlCache(redisRepo repositories.RedisRepositoryInterface, local *localCache) *shortUrlCache {
+	return &shortUrlCache{redisRepo: redisRepo, local: local}
+}
+
+// cachedShortUrl is what the cache keeps of a link: everything the public
+// redirect checks and everything it needs to pick its destination and
+// status. Only active links are cached; inactive ones are cached as missing.
+type cachedShortUrl struct {
+	ID                 uint                         `json:"id"`
+	UserID             uint                         `json:"user_id"`
+	LongUrl            string                       `json:"long_url"`
+	IsActive           bool                         `json:"is_active"`
+	ExpireAt           *time.Time                   `json:"expire_at,omitempty"`
+	ActiveFrom         *time.Time                   `json:"active_from,omitempty"`
+	Availability       *entities.AvailabilityWindow `json:"availability,omitempty"`
+	FallbackUrl        *string                      `json:"fallback_url,omitempty"`
+	PasswordHash       string                       `json:"password_hash,omitempty"`
+	MaxClicks          *int64                       `json:"max_clicks,omitempty"`
+	RemainingClicks    *int64                       `json:"remaining_clicks,omitempty"`
+	ForwardQuery       bool                         `json:"forward_query,omitempty"`
+	ForwardPath        bool                         `json:"forward_path,omitempty"`
+	Utm                entities.UtmParams           `json:"utm,omitzero"`
+	TargetingRules     []entities.TargetingRule     `json:"targeting_rules,omitempty"`
+	Destinations       []cachedShortUrlDestination  `json:"destinations,omitempty"`
+	StickyDestinations bool                         `json:"sticky_destinations,omitempty"`
+	Preview            bool                         `json:"preview,omitempty"`
+	RedirectType       int                          `json:"redirect_type,omitempty"`
+	Safety             *cachedUrlSafety             `json:"safety,omitempty"`
+	CreatedAt          time.Time                    `json:"created_at"`
+}
+
+type cachedShortUrlDestination struct {
+	ID     uint   `json:"id"`
+	Url    string `json:"url"`
+	Weight int    `json:"weight"`
+}
+
+type cachedUrlSafety struct {
+	IsSafe    bool      `json:"safe"`
+	Checker   string    `json:"checker,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+func newCachedShortUrl(shortUrl *entities.ShortUrl) cachedShortUrl {
+	cached := cachedShortUrl{
+		ID:                 shortUrl.ID,
+		UserID:             shortUrl.UserID,
+		LongUrl:            shortUrl.LongUrl,
+		IsActive:           shortUrl.IsActive,
+		ExpireAt:           shortUrl.ExpireAt,
+		ActiveFrom:         shortUrl.ActiveFrom,
+		Availability:       shortUrl.Availability,
+		FallbackUrl:        shortUrl.FallbackUrl,
+		PasswordHash:       shortUrl.PasswordHash,
+		MaxClicks:          shortUrl.MaxClicks,
+		RemainingClicks:    shortUrl.RemainingClicks,
+		ForwardQuery:       shortUrl.ForwardQuery,
+		ForwardPath:        shortUrl.ForwardPath,
+		Utm:                shortUrl.Utm,
+		TargetingRules:     shortUrl.TargetingRules,
+		StickyDestinations: shortUrl.StickyDestinations,
+		Preview:            shortUrl.Preview,
+		RedirectType:       shortUrl.RedirectType,
+		CreatedAt:          shortUrl.CreatedAt,
+	}
+	for _, destination := range shortUrl.Destinations {
+		cached.Destinations = append(cached.Destinations, cachedShortUrlDestination{ID: destination.ID, Url: destination.Url, Weight: destination.Weight})
+	}
+	if shortUrl.UrlSafety != nil {
+		cached.Safety = &cachedUrlSafety{
+			IsSafe:    shortUrl.UrlSafety.IsSafe,
+			Checker:   shortUrl.UrlSafety.Checker,
+			Reason:    shortUrl.UrlSafety.Reason,
+			CheckedAt: shortUrl.UrlSafety.CheckedAt,
+		}
+	}
+	return cached
+}
+
+func (c cachedShortUrl) toShortUrl(domainID uint, shortCode string) *entities.ShortUrl {
+	shortUrl := &entities.ShortUrl{
+		ID:                 c.ID,
+		UserID:             c.UserID,
+		LongUrl:            c.LongUrl,
+		ShortCode:          shortCode,
+		DomainID:           domainID,
+		IsActive:           c.IsActive,
+		ExpireAt:           c.ExpireAt,
+		ActiveFrom:         c.ActiveFrom,
+		Availability:       c.Availability,
+		FallbackUrl:        c.FallbackUrl,
+		PasswordHash:       c.PasswordHash,
+		MaxClicks:          c.MaxClicks,
+		RemainingClicks:    c.RemainingClicks,
+		ForwardQuery:       c.ForwardQuery,
+		ForwardPath:        c.ForwardPath,
+		Utm:                c.Utm,
+		TargetingRules:     c.TargetingRules,
+		StickyDestinations: c.StickyDestinations,
+		Preview:            c.Preview,
+		RedirectType:       c.RedirectType,
+		CreatedAt:          c.CreatedAt,
+	}
+	for _, destination := range c.Destinations {
+		shortUrl.Destinations = append(shortUrl.Destinations, entities.ShortUrlDestination{ID: destination.ID, ShortUrlID: c.ID, Url: destination.Url, Weight: destination.Weight})
+	}
+	if c.Safety != nil {
+		shortUrl.UrlSafety = &entities.UrlSafety{
+			ShortUrlID: c.ID,
+			IsSafe:     c.Safety.IsSafe,
+			Checker:    c.Safety.Checker,
+			Reason:     c.Safety.Reason,
+			CheckedAt:  c.Safety.CheckedAt,
+		}
+	}
+	return shortUrl
+}
+
+// cacheTier is the tier that answered a lookup.
+type cacheTier int
+
+const (
+	cacheTierNone cacheTier = iota
+	cacheTierLocal
+	cacheTierRedis
+)
+
+// get returns the cached link and whether the cache knew the code at all, and
+// counts the lookup in stats. A code cached as missing is a hit with a nil
+// link.
+func (c *shortUrlCache) get(ctx context.Context, domainID uint, shortCode string) (*entities.ShortUrl, bool) {
+	shortUrl, ok, tier := c.peek(ctx, domainID, shortCode)
+	if tier == cacheTierLocal {
+		c.localHits.Add(1)
+		return shortUrl, ok
+	}
+	if c.local != nil {
+		c.localMisses.Add(1)
+	}
+	if tier == cacheTierRedis {
+		if ok {
+			c.redisHits.Add(1)
+		} else {
+			c.redisMisses.Add(1)
+		}
+	}
+	return shortUrl, ok
+}
+
+// peek is get without counting, for looking a code up again after get missed
+// it. Reload placeholders, unreadable entries, and entries written before the
+// cache kept whole links, are misses. A Redis hit only refills the local tier
+// if no invalidation arrived while it was read, as it may be what was
+// invalidated.
+func (c *shortUrlCache) peek(ctx context.Context, domainID uint, shortCode string) (*entities.ShortUrl, bool, cacheTier) {
+	key := shortUrlCacheKey(domainID, shortCode)
+	now := time.Now()
+	if value, ok := c.local.get(key, now); ok {
+		return toCachedShortUrl(value, domainID, shortCode), true, cacheTierLocal
+	}
+
+	if c.redisRepo == nil {
+		return nil, false, cacheTierNone
+	}
+	generation := c.local.generation()
+	value, err := c.redisRepo.Get(ctx, key)
+	if err != nil || value == "" || strings.HasPrefix(value, reloadingCacheEntry) {
+		return nil, false, cacheTierRedis
+	}
+	if value == missingCacheEntry {
+		c.local.setIfGeneration(key, (*cachedShortUrl)(nil), missingCacheTTL, now, generation)
+		return nil, true, cacheTierRedis
+	}
+
+	var cached cachedShortUrl
+	if err := json.Unmarshal([]byte(value), &cached); err != nil || cached.ID == 0 || !cached.IsActive {
+		return nil, false, cacheTierRedis
+	}
+	c.local.setIfGeneration(key, &cached, shortUrlCacheTTL, now, generation)
+	return cached.toShortUrl(domainID, shortCode), true, cacheTierRedis
+}
+
+// toCachedShortUrl turns a value of the local tier back into a link; a nil
+// *cachedShortUrl stands for a code cached as missing.
+func toCachedShortUrl(value any, domainID uint, shortCode string) *entities.ShortUrl {
+	cached, _ := value.(*cachedShortUrl)
+	if cached == nil {
+		return nil
+	}
+	return cached.toShortUrl(domainID, shortCode)
+}
+
+// beginReload stores a placeholder for the link before it is read from the
+// database and returns it for set or setMissing. An invalidation deletes the
+// placeholder along with the entry, so a reload that read the row before a
+// change committed cannot cache it afterwards. An empty result means the
+// reload is not cached at all.
+func (c *shortUrlCache) beginReload(ctx context.Context, domainID uint, shortCode string) string {
+	if c.redisRepo == nil {
+		return ""
+	}
+	token, err := generateCacheToken()
+	if err != nil {
+		log.Printf("Failed to begin reload of short url %s: %v", shortCode, err)
+		return ""
+	}
+	reload := reloadingCacheEntry + token
+	if err := c.redisRepo.Set(ctx, shortUrlCacheKey(domainID, shortCode), reload, shortUrlLockTTL); err != nil {
+		log.Printf("Failed to begin reload of short url %s: %v", shortCode, err)
+		return ""
+	}
+	return reload
+}
+
+// set caches an active link until shortUrlCacheTTL or its expiry, whichever
+// comes first, if the entry still holds the placeholder of reload. An expired
+// link is not cached: Redis would keep an entry without a positive TTL
+// forever.
+func (c *shortUrlCache) set(ctx context.Context, shortUrl *entities.ShortUrl, reload string, now time.Time) {
+	if c.redisRepo == nil || reload == "" || !shortUrl.IsActive {
+		return
+	}
+	ttl := shortUrlCacheTTL
+	if shortUrl.ExpireAt != nil {
+		ttl = min(ttl, shortUrl.ExpireAt.Sub(now))
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	cached := newCachedShortUrl(shortUrl)
+	record, err := json.Marshal(cached)
+	if err != nil {
+		log.Printf("Failed to encode cached short url %s: %v", shortUrl.ShortCode, err)
+		return
+	}
+	c.store(ctx, shortUrlCacheKey(shortUrl.DomainID, shortUrl.ShortCode), &cached, string(record), ttl, reload, now)
+}
+
+// setMissing remembers for missingCacheTTL that the code has no active link,
+// if the entry still holds the placeholder of reload.
+func (c *shortUrlCache) setMissing(ctx context.Context, domainID uint, shortCode string, reload string) {
+	if c.redisRepo == nil || reload == "" {
+		return
+	}
+	c.store(ctx, shortUrlCacheKey(domainID, shortCode), (*cachedShortUrl)(nil), missingCacheEntry, missingCacheTTL, reload, time.Now())
+}
+
+// store replaces the placeholder of reload with record. The local tier is
+// written first and dropped again if the placeholder is gone, so an
+// invalidation published in between cannot be missed by it.
+func (c *shortUrlCache) store(ctx context.Context, key string, cached *cachedShortUrl, record string, ttl time.Duration, reload string, now time.Time) {
+	c.local.set(key, cached, ttl, now)
+	stored, err := c.redisRepo.SetIfEqual(ctx, key, reload, record, ttl)
+	if err != nil {
+		log.Printf("Failed to cache entry %s: %v", key, err)
+	}
+	if !stored {
+		c.local.delete(key)
+	}
+}
+
+// invalidate drops the entry of the link, including one that marks its code
+// as missing, here and on every other instance. Call it after the change is
+// written.
+func (c *shortUrlCache) invalidate(ctx context.Context, shortUrl *entities.ShortUrl) {
+	c.drop(ctx, shortUrlCacheKey(shortUrl.DomainID, shortUrl.ShortCode))
+}
+
+// lock claims the reload of a link for this instance until unlock or
+// shortUrlLockTTL and returns the token to unlock it with. It also reports
+// true, with an empty token, when Redis cannot be asked, so an outage never
+// keeps a lookup from the database.
+func (c *shortUrlCache) lock(ctx context.Context, domainID uint, shortCode string) (string, bool) {
+	if c.redisRepo == nil {
+		return "", true
+	}
+	token, err := generateCacheToken()
+	if err != nil {
+		log.Printf("Failed to lock short url %s: %v", shortCode, err)
+		return "", true
+	}
+	locked, err := c.redisRepo.SetNX(ctx, shortUrlLockKey(domainID, shortCode), token, shortUrlLockTTL)
+	if err != nil {
+		log.Printf("Failed to lock short url %s: %v", shortCode, err)
+		return "", true
+	}
+	if !locked {
+		return "", false
+	}
+	return token, true
+}
+
+// unlock releases the lock only while it still holds token. A reload slower
+// than shortUrlLockTTL has lost the lock, maybe to another instance, and
+// must not release theirs.
+func (c *shortUrlCache) unlock(ctx context.Context, domainID uint, shortCode string, token string) {
+	if c.redisRepo == nil || token == "" {
+		return
+	}
+	if err := c.redisRepo.DeleteIfEqual(ctx, shortUrlLockKey(domainID, shortCode), token); err != nil {
+		log.Printf("Failed to unlock short url %s: %v", shortCode, err)
+	}
+}
+
+func generateCacheToken() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// getDomainID returns the custom domain cached for host, 0 for a host that is
+// not a verified custom domain, and whether the host was cached at all.
+func (c *shortUrlCache) getDomainID(ctx context.Context, host string) (uint, bool) {
+	key := customDomainCacheKey(host)
+	now := time.Now()
+	if value, ok := c.local.get(key, now); ok {
+		return value.(uint), true
+	}
+
+	if c.redisRepo == nil {
+		return 0, false
+	}
+	generation := c.local.generation()
+	value, err := c.redisRepo.Get(ctx, key)
+	if err != nil || value == "" {
+		return 0, false
+	}
+	domainID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	c.local.setIfGeneration(key, uint(domainID), domainCacheTTL(uint(domainID)), now, generation)
+	return uint(domainID), true
+}
+
+// setDomainID caches the custom domain of host. Hosts that are not one,
+// domainID 0, are kept for missingCacheTTL only.
+func (c *shortUrlCache) setDomainID(ctx context.Context, host string, domainID uint) {
+	if c.redisRepo == nil {
+		return
+	}
+	key := customDomainCacheKey(host)
+	ttl := domainCacheTTL(domainID)
+	c.local.set(key, domainID, ttl, time.Now())
+	if err := c.redisRepo.Set(ctx, key, strconv.FormatUint(uint64(domainID), 10), ttl); err != nil {
+		log.Printf("Failed to cache custom domain %s: %v", host, err)
+	}
+}
+
+func domainCacheTTL(domainID uint) time.Duration {
+	if domainID == 0 {
+		return missingCacheTTL
+	}
+	return customDomainCacheTTL
+}
+
+func (c *shortUrlCache) invalidateDomain(ctx context.Context, host string) {
+	c.drop(ctx, customDomainCacheKey(host))
+}
+
+// drop deletes key from Redis and publishes it, so every instance, this one
+// included, removes it from its local tier.
+func (c *shortUrlCache) drop(ctx context.Context, key string) {
+	c.local.delete(key)
+	if c.redisRepo == nil {
+		return
+	}
+	if err := c.redisRepo.Delete(ctx, key); err != nil {
+		log.Printf("Failed to invalidate cache entry %s: %v", key, err)
+	}
+	if err := c.redisRepo.Publish(ctx, shortUrlCacheChannel, key); err != nil {
+		log.Printf("Failed to publish invalidation of cache entry %s: %v", key, err)
+	}
+}
+
+// listen drops the keys published by drop on any instance from the local tier
+// until ctx is done. Invalidations published while the subscription is down
+// are lost, so the local tier is cleared whenever it ends.
+func (c *shortUrlCache) listen(ctx context.Context) {
+	if c.local == nil || c.redisRepo == nil {
+		return
+	}
+	for {
+		err := c.redisRepo.Subscribe(ctx, shortUrlCacheChannel, c.local.delete)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Short url cache subscription ended: %v", err)
+		c.local.clear()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cacheSubscribeRetryDelay):
+		}
+	}
+}
+
+func (c *shortUrlCache) stats() dto.LinkCacheStats {
+	return dto.LinkCacheStats{
+		LocalHits:   c.localHits.Load(),
+		LocalMisses: c.localMisses.Load(),
+		LocalSize:   c.local.len(),
+		RedisHits:   c.redisHits.Load(),
+		RedisMisses: c.redisMisses.Load(),
+	}
+}
+
+// shortUrlCacheKey keeps the original key for links on the service's own host
+// so their entries survive the introduction of custom domains.
+func shortUrlCacheKey(domainID uint, shortCode string) string {
+	if domainID == 0 {
+		return fmt.Sprintf("short_url:%s", shortCode)
+	}
+	return fmt.Sprintf("short_url:%d:%s", domainID, shortCode)
+}
+
+func shortUrlLockKey(domainID uint, shortCode string) string {
+	return "lock:" + shortUrlCacheKey(domainID, shortCode)
+}
+
+func customDomainCacheKey(host string) string {
+	return fmt.Sprintf("custom_domain:%s", host)
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newLocalCache(2, time.Minute)
+	now := time.Now()
+
+	cache.set("a", 1, time.Hour, now)
+	cache.set("b", 2, time.Hour, now)
+	_, ok := cache.get("a", now)
+	require.True(t, ok)
+	cache.set("c", 3, time.Hour, now)
+
+	_, ok = cache.get("b", now)
+	assert.False(t, ok)
+	value, ok := cache.get("a", now)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 2, cache.len())
+}
+
+func TestLocalCache_ExpiresEntries(t *testing.T) {
+	cache := newLocalCache(10, time.Minute)
+	now := time.Now()
+
+	cache.set("long", 1, time.Hour, now)
+	cache.set("short", 2, time.Second, now)
+
+	_, ok := cache.get("short", now.Add(time.Second))
+	assert.False(t, ok)
+	_, ok = cache.get("long", now.Add(59*time.Second))
+	assert.True(t, ok)
+	_, ok = cache.get("long", now.Add(time.Minute))
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.len())
+}
+
+func TestLocalCache_Disabled(t *testing.T) {
+	assert.Nil(t, newLocalCache(0, time.Minute))
+	assert.Nil(t, newLocalCache(10, 0))
+
+	var cache *localCache
+	cache.set("a", 1, time.Hour, time.Now())
+	_, ok := cache.get("a", time.Now())
+	assert.False(t, ok)
+	cache.delete("a")
+	cache.clear()
+	assert.Equal(t, 0, cache.len())
+}
+
+func TestShortUrlCache_LocalTierServesHotLinks(t *testing.T) {
+	ctx := context.Background()
+	redisRepo := mocks.NewMockRedisRepositoryInterface(t)
+	cache := newShortUrlCache(redisRepo, newLocalCache(10, time.Minute))
+	shortUrl := &entities.ShortUrl{ID: 7, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}
+	record, err := json.Marshal(newCachedShortUrl(shortUrl))
+	require.NoError(t, err)
+
+	redisRepo.EXPECT().Get(ctx, "short_url:abc123").Return(string(record), nil).Once()
+	redisRepo.EXPECT().Get(ctx, "short_url:nope").Return(missingCacheEntry, nil).Once()
+
+	for range 3 {
+		result, ok := cache.get(ctx, 0, "abc123")
+		require.True(t, ok)
+		assert.Equal(t, shortUrl, result)
+
+		result, ok = cache.get(ctx, 0, "nope")
+		require.True(t, ok)
+		assert.Nil(t, result)
+	}
+
+	assert.Equal(t, dto.LinkCacheStats{LocalHits: 4, LocalMisses: 2, LocalSize: 2, RedisHits: 2}, cache.stats())
+}
+
+func TestShortUrlCache_RedisHitSkipsLocalRefillAfterInvalidation(t *testing.T) {
+	ctx := context.Background()
+	redisRepo := mocks.NewMockRedisRepositoryInterface(t)
+	local := newLocalCache(10, time.Minute)
+	cache := newShortUrlCache(redisRepo, local)
+	record, err := json.Marshal(newCachedShortUrl(&entities.ShortUrl{ID: 7, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}))
+	require.NoError(t, err)
+
+	// The invalidation reaches this instance while the old record is read.
+	redisRepo.EXPECT().Get(ctx, "short_url:abc123").RunAndReturn(func(ctx context.Context, key string) (string, error) {
+		local.delete(key)
+		return string(record), nil
+	}).Once()
+
+	result, ok := cache.get(ctx, 0, "abc123")
+	require.True(t, ok)
+	assert.Equal(t, uint(7), result.ID)
+	assert.Equal(t, 0, local.len())
+}
+
+func TestShortUrlCache_InvalidatePublishesKey(t *testing.T) {
+	ctx := context.Background()
+	redisRepo := mocks.NewMockRedisRepositoryInterface(t)
+	cache := newShortUrlCache(redisRepo, newLocalCache(10, time.Minute))
+	shortUrl := &entities.ShortUrl{ID: 7, DomainID: 4, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}
+
+	redisRepo.EXPECT().Set(ctx, "short_url:4:abc123", mock.AnythingOfType("string"), shortUrlLockTTL).Return(nil)
+	redisRepo.EXPECT().SetIfEqual(ctx, "short_url:4:abc123", mock.AnythingOfType("string"), mock.AnythingOfType("string"), shortUrlCacheTTL).Return(true, nil)
+	cache.set(ctx, shortUrl, cache.beginReload(ctx, 4, "abc123"), time.Now())
+	result, ok := cache.get(ctx, 4, "abc123")
+	require.True(t, ok)
+	assert.Equal(t, shortUrl.LongUrl, result.LongUrl)
+
+	redisRepo.EXPECT().Delete(ctx, "short_url:4:abc123").Return(nil)
+	redisRepo.EXPECT().Publish(ctx, shortUrlCacheChannel, "short_url:4:abc123").Return(nil)
+	cache.invalidate(ctx, shortUrl)
+
+	redisRepo.EXPECT().Get(ctx, "short_url:4:abc123").Return("", assert.AnError)
+	_, ok = cache.get(ctx, 4, "abc123")
+	assert.False(t, ok)
+}
+
+func TestShortUrlCache_ListenDropsPublishedKeys(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	redisRepo := mocks.NewMockRedisRepositoryInterface(t)
+	local := newLocalCache(10, time.Minute)
+	cache := newShortUrlCache(redisRepo, local)
+	now := time.Now()
+	local.set("short_url:abc123", &cachedShortUrl{ID: 7, IsActive: true}, time.Hour, now)
+	local.set("custom_domain:go.example.com", uint(4), time.Hour, now)
+
+	redisRepo.EXPECT().Subscribe(ctx, shortUrlCacheChannel, mock.Anything).RunAndReturn(func(ctx context.Context, channel string, handle func(message string)) error {
+		handle("short_url:abc123")
+		cancel()
+		return nil
+	})
+	cache.listen(ctx)
+
+	_, ok := local.get("short_url:abc123", now)
+	assert.False(t, ok)
+	_, ok = local.get("custom_domain:go.example.com", now)
+	assert.True(t, ok)
+}
+
+func TestShortUrlCache_ListenWithoutLocalTier(t *testing.T) {
+	redisRepo := mocks.NewMockRedisRepositoryInterface(t)
+	newShortUrlCache(redisRepo, nil).listen(context.Background())
+}
+
+// memoryRedisRepository keeps keys in memory, without expiry. Get waits for
+// latency first, which stands in for the network round trip to Redis.
+type memoryRedisRepository struct {
+	repositories.RedisRepositoryInterface
+	mu      sync.Mutex
+	values  map[string]string
+	latency time.Duration
+}
+
+func newMemoryRedisRepository(latency time.Duration) *memoryRedisRepository {
+	return &memoryRedisRepository{values: make(map[string]string), latency: latency}
+}
+
+func (r *memoryRedisRepository) SetIfEqual(ctx context.Context, key string, expected string, value interface{}, expiration time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.values[key] != expected {
+		return false, nil
+	}
+	r.values[key] = fmt.Sprint(value)
+	return true, nil
+}
+
+func (r *memoryRedisRepository) Get(ctx context.Context, key string) (string, error) {
+	time.Sleep(r.latency)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.values[key], nil
+}
+
+func (r *memoryRedisRepository) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[key] = fmt.Sprint(value)
+	return nil
+}
+
+func (r *memoryRedisRepository) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.values[key]; ok {
+		return false, nil
+	}
+	r.values[key] = fmt.Sprint(value)
+	return true, nil
+}
+
+func (r *memoryRedisRepository) Delete(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.values, key)
+	return nil
+}
+
+func (r *memoryRedisRepository) Publish(ctx context.Context, channel string, message interface{}) error {
+	return nil
+}
+
+func (r *memoryRedisRepository) DeleteIfEqual(ctx context.Context, key string, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.values[key] == value {
+		delete(r.values, key)
+	}
+	return nil
+}
+
+// lookupInParallel runs perService concurrent public lookups of shortCode on
+// every service and returns how often the database was queried.
+func lookupInParallel(t *testing.T, queryRepo *mocks.MockShortUrlQueryRepositoryInterface, services []service.ShortUrlServiceInterface, perService int, shortCode string) int64 {
+	var queries atomic.Int64
+	queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), shortCode).RunAndReturn(func(ctx context.Context, domainID uint, shortCode string) (*entities.ShortUrl, error) {
+		queries.Add(1)
+		// A slow query, so the other lookups pile up behind it.
+		time.Sleep(50 * time.Millisecond)
+		return &entities.ShortUrl{ID: 7, ShortCode: shortCode, LongUrl: "https://example.com", IsActive: true}, nil
+	})
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, svc := range services {
+		for range perService {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				shortUrl, err := svc.GetByShortCodePublic(context.Background(), "", shortCode)
+				if assert.NoError(t, err) {
+					assert.Equal(t, "https://example.com", shortUrl.LongUrl)
+				}
+			}()
+		}
+	}
+	close(start)
+	wg.Wait()
+	return queries.Load()
+}
+
+func TestGetByShortCodePublic_CoalescesConcurrentMisses(t *testing.T) {
+	queryRepo := mocks.NewMockShortUrlQueryRepositoryInterface(t)
+	svc := NewShortUrlService(nil, queryRepo, newMemoryRedisRepository(0), nil, nil, nil, nil, nil, nil, nil, dto.LinkCacheConfig{})
+
+	queries := lookupInParallel(t, queryRepo, []service.ShortUrlServiceInterface{svc}, 100, "abc123")
+
+	assert.Equal(t, int64(1), queries)
+}
+
+func TestGetByShortCodePublic_OneInstanceReloads(t *testing.T) {
+	queryRepo := mocks.NewMockShortUrlQueryRepositoryInterface(t)
+	redisRepo := newMemoryRedisRepository(0)
+	var instances []service.ShortUrlServiceInterface
+	for range 4 {
+		instances = append(instances, NewShortUrlService(nil, queryRepo, redisRepo, nil, nil, nil, nil, nil, nil, nil, dto.LinkCacheConfig{}))
+	}
+
+	queries := lookupInParallel(t, queryRepo, instances, 25, "abc123")
+
+	assert.Equal(t, int64(1), queries)
+	_, locked := redisRepo.values["lock:short_url:abc123"]
+	assert.False(t, locked)
+}
+
+func TestGetByShortCodePublic_WaitsForLockHolder(t *testing.T) {
+	queryRepo := mocks.NewMockShortUrlQueryRepositoryInterface(t)
+	redisRepo := newMemoryRedisRepository(0)
+	svc := NewShortUrlService(nil, queryRepo, redisRepo, nil, nil, nil, nil, nil, nil, nil, dto.LinkCacheConfig{})
+	holder := newShortUrlCache(redisRepo, nil)
+	ctx := context.Background()
+
+	// Another instance holds the lock and fills the entry while we wait.
+	token, ok := holder.lock(ctx, 0, "abc123")
+	require.True(t, ok)
+	go func() {
+		time.Sleep(3 * shortUrlLockPoll)
+		holder.set(ctx, &entities.ShortUrl{ID: 7, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}, holder.beginReload(ctx, 0, "abc123"), time.Now())
+		holder.unlock(ctx, 0, "abc123", token)
+	}()
+
+	shortUrl, err := svc.GetByShortCodePublic(ctx, "", "abc123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", shortUrl.LongUrl)
+	queryRepo.AssertNotCalled(t, "FindByShortCode", mock.Anything, mock.Anything, mock.Anything)
+	// Polling for the holder's entry is not counted, only the first miss.
+	assert.Equal(t, dto.LinkCacheStats{RedisMisses: 1}, svc.CacheStats())
+}
+
+func TestShortUrlCache_ReloadSkipsWriteAfterInvalidation(t *testing.T) {
+	ctx := context.Background()
+	redisRepo := newMemoryRedisRepository(0)
+	cache := newShortUrlCache(redisRepo, newLocalCache(10, time.Minute))
+	stale := &entities.ShortUrl{ID: 7, ShortCode: "abc123", LongUrl: "https://example.com/old", IsActive: true}
+
+	// The reload read the row, then an update committed and invalidated it.
+	reload := cache.beginReload(ctx, 0, "abc123")
+	require.NotEmpty(t, reload)
+	_, ok := cache.get(ctx, 0, "abc123")
+	assert.False(t, ok)
+	cache.invalidate(ctx, stale)
+	cache.set(ctx, stale, reload, time.Now())
+
+	_, ok = cache.get(ctx, 0, "abc123")
+	assert.False(t, ok)
+	assert.Empty(t, redisRepo.values["short_url:abc123"])
+
+	// The same holds for a code the reload found missing.
+	reload = cache.beginReload(ctx, 0, "abc123")
+	cache.invalidate(ctx, stale)
+	cache.setMissing(ctx, 0, "abc123", reload)
+	assert.Empty(t, redisRepo.values["short_url:abc123"])
+}
+
+func TestShortUrlCache_UnlockKeepsAnotherOwnersLock(t *testing.T) {
+	ctx := context.Background()
+	redisRepo := newMemoryRedisRepository(0)
+	cache := newShortUrlCache(redisRepo, nil)
+
+	stale, ok := cache.lock(ctx, 0, "abc123")
+	require.True(t, ok)
+	// The lock expired and another instance took it over.
+	redisRepo.values["lock:short_url:abc123"] = "other"
+
+	cache.unlock(ctx, 0, "abc123", stale)
+	assert.Equal(t, "other", redisRepo.values["lock:short_url:abc123"])
+
+	_, ok = cache.lock(ctx, 0, "abc123")
+	assert.False(t, ok)
+}
+
+func BenchmarkShortUrlCacheGet(b *testing.B) {
+	ctx := context.Background()
+	shortUrl := &entities.ShortUrl{
+		ID:             7,
+		ShortCode:      "abc123",
+		LongUrl:        "https://example.com/landing",
+		IsActive:       true,
+		TargetingRules: []entities.TargetingRule{{OS: []string{entities.TargetOSIOS}, Destination: "https://apps.apple.com/app/id1"}},
+		UrlSafety:      &entities.UrlSafety{IsSafe: true, CheckedAt: time.Now()},
+	}
+	record, err := json.Marshal(newCachedShortUrl(shortUrl))
+	require.NoError(b, err)
+
+	for _, latency := range []time.Duration{0, 100 * time.Microsecond} {
+		redisRepo := newMemoryRedisRepository(latency)
+		redisRepo.values["short_url:abc123"] = string(record)
+		tiers := []struct {
+			name  string
+			cache *shortUrlCache
+		}{
+			{"redis", newShortUrlCache(redisRepo, nil)},
+			{"local", newShortUrlCache(redisRepo, newLocalCache(1000, time.Minute))},
+		}
+		for _, tier := range tiers {
+			b.Run(tier.name+"/latency="+latency.String(), func(b *testing.B) {
+				for b.Loop() {
+					if _, ok := tier.cache.get(ctx, 0, "abc123"); !ok {
+						b.Fatal("expected a cache hit")
+					}
+				}
+			})
+		}
+	}
+}
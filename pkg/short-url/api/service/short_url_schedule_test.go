@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToAvailabilityWindow_Validates(t *testing.T) {
+	window, err := toAvailabilityWindow(&dto.AvailabilityWindow{Days: []string{"Mon", "fri"}, Start: "09:00", End: "17:00"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mon", "fri"}, window.Days)
+	assert.Equal(t, "UTC", window.Timezone)
+
+	invalid := []dto.AvailabilityWindow{
+		{Start: "9:00", End: "17:00"},
+		{Start: "09:00", End: "09:00"},
+		{Start: "24:00", End: "09:00"},
+		{Start: "09:00", End: "24:30"},
+		{Days: []string{"funday"}, Start: "09:00", End: "17:00"},
+		{Start: "09:00", End: "17:00", Timezone: "Mars/Olympus"},
+	}
+	for _, req := range invalid {
+		_, err := toAvailabilityWindow(&req)
+		assert.ErrorIs(t, err, service.ErrInvalidAvailability, "%+v", req)
+	}
+}
+
+func TestAvailabilityWindow_Contains(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	weekdays := &entities.AvailabilityWindow{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "17:00", Timezone: "Europe/Berlin"}
+	overnight := &entities.AvailabilityWindow{Days: []string{"fri"}, Start: "22:00", End: "02:00", Timezone: "Europe/Berlin"}
+
+	cases := []struct {
+		window   *entities.AvailabilityWindow
+		at       time.Time
+		contains bool
+	}{
+		{weekdays, time.Date(2026, 10, 19, 9, 0, 0, 0, berlin), true},    // Monday opening
+		{weekdays, time.Date(2026, 10, 19, 16, 59, 0, 0, berlin), true},  // Monday just before close
+		{weekdays, time.Date(2026, 10, 19, 17, 0, 0, 0, berlin), false},  // Monday closing
+		{weekdays, time.Date(2026, 10, 18, 12, 0, 0, 0, berlin), false},  // Sunday
+		{weekdays, time.Date(2026, 10, 19, 7, 30, 0, 0, time.UTC), true}, // 09:30 in Berlin
+		{overnight, time.Date(2026, 10, 23, 23, 0, 0, 0, berlin), true},  // Friday night
+		{overnight, time.Date(2026, 10, 24, 1, 0, 0, 0, berlin), true},   // early Saturday belongs to Friday
+		{overnight, time.Date(2026, 10, 24, 23, 0, 0, 0, berlin), false},
+		{overnight, time.Date(2026, 10, 23, 1, 0, 0, 0, berlin), false},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.contains, tc.window.Contains(tc.at), "%s %s", tc.window.Start, tc.at)
+	}
+}
+
+func TestShortUrl_NextAvailableAt(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	window := &entities.AvailabilityWindow{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "17:00", Timezone: "Europe/Berlin"}
+	friday := time.Date(2026, 10, 23, 18, 0, 0, 0, berlin)
+
+	shortUrl := &entities.ShortUrl{Availability: window}
+	assert.False(t, shortUrl.IsAvailable(friday))
+	assert.Equal(t, time.Date(2026, 10, 26, 9, 0, 0, 0, berlin), shortUrl.NextAvailableAt(friday).In(berlin))
+
+	launch := time.Date(2026, 10, 20, 12, 0, 0, 0, berlin)
+	scheduled := &entities.ShortUrl{ActiveFrom: &launch, Availability: window}
+	assert.False(t, scheduled.IsAvailable(launch.Add(-time.Hour)))
+	assert.Equal(t, launch, *scheduled.NextAvailableAt(launch.Add(-48 * time.Hour)))
+	assert.True(t, scheduled.IsAvailable(launch))
+
+	evening := time.Date(2026, 10, 20, 20, 0, 0, 0, berlin)
+	scheduled.ActiveFrom = &evening
+	assert.Equal(t, time.Date(2026, 10, 21, 9, 0, 0, 0, berlin), scheduled.NextAvailableAt(launch).In(berlin))
+}
@@ -2,97 +2,1206 @@ package service
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/base64"
+	"errors"
 	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"short-url/domains/dto"
 	"short-url/domains/entities"
+	"short-url/domains/helper"
 	"short-url/domains/repositories"
 	"short-url/domains/service"
+
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBulkCreateRows = 1000
+
+	// maxShortCodeAttempts bounds how many generated codes are tried before a
+	// create gives up with ErrShortCodeExhausted.
+	maxShortCodeAttempts = 5
+
+	maxTargetingRules = 20
+
+	minSplitDestinations = 2
+	maxSplitDestinations = 10
+	maxDestinationWeight = 1000
+
+	// maxLinkTTL caps ttl at ten years, well below the point where it would
+	// overflow a time.Duration.
+	maxLinkTTL = 10 * 365 * 24 * 60 * 60
 )
 
 type shortUrlService struct {
-	commandRepo repositories.ShortUrlCommandRepositoryInterface
-	queryRepo   repositories.ShortUrlQueryRepositoryInterface
-	redisRepo   repositories.RedisRepositoryInterface
+	commandRepo        repositories.ShortUrlCommandRepositoryInterface
+	queryRepo          repositories.ShortUrlQueryRepositoryInterface
+	cache              *shortUrlCache
+	reloads            singleflight.Group
+	clicks             *clickQueue
+	urlSafetyService   service.UrlSafetyServiceInterface
+	shortCodeGenerator service.ShortCodeGenerator
+	utmTemplateRepo    repositories.UtmTemplateQueryRepositoryInterface
+	countryResolver    service.CountryResolver
+	domainRepo         repositories.CustomDomainQueryRepositoryInterface
+	settingRepo        repositories.InstitutionSettingQueryRepositoryInterface
 }
 
+// NewShortUrlService builds the link service. A nil shortCodeGenerator falls
+// back to random base62 codes of DefaultShortCodeLength characters; without a
+// utmTemplateRepo, requests naming a UTM template fail, without a
+// countryResolver no visitor matches a country targeting rule, without a
+// domainRepo every link lives on the service's own host, and without a
+// settingRepo links default to DefaultRedirectType. cacheConfig enables the
+// in-process tier of the link cache.
 func NewShortUrlService(
 	commandRepo repositories.ShortUrlCommandRepositoryInterface,
 	queryRepo repositories.ShortUrlQueryRepositoryInterface,
 	redisRepo repositories.RedisRepositoryInterface,
+	clickCounterRepo repositories.ClickCounterRepositoryInterface,
+	urlSafetyService service.UrlSafetyServiceInterface,
+	shortCodeGenerator service.ShortCodeGenerator,
+	utmTemplateRepo repositories.UtmTemplateQueryRepositoryInterface,
+	countryResolver service.CountryResolver,
+	domainRepo repositories.CustomDomainQueryRepositoryInterface,
+	settingRepo repositories.InstitutionSettingQueryRepositoryInterface,
+	cacheConfig dto.LinkCacheConfig,
 ) service.ShortUrlServiceInterface {
+	if shortCodeGenerator == nil {
+		shortCodeGenerator = &randomShortCodeGenerator{alphabet: DefaultShortCodeAlphabet, length: DefaultShortCodeLength}
+	}
 	return &shortUrlService{
-		commandRepo: commandRepo,
-		queryRepo:   queryRepo,
-		redisRepo:   redisRepo,
+		commandRepo:        commandRepo,
+		queryRepo:          queryRepo,
+		cache:              newShortUrlCache(redisRepo, newLocalCache(cacheConfig.LocalSize, cacheConfig.LocalTTL)),
+		clicks:             newClickQueue(clickCounterRepo),
+		urlSafetyService:   urlSafetyService,
+		shortCodeGenerator: shortCodeGenerator,
+		utmTemplateRepo:    utmTemplateRepo,
+		countryResolver:    countryResolver,
+		domainRepo:         domainRepo,
+		settingRepo:        settingRepo,
 	}
 }
 
-func (s *shortUrlService) CreateShortUrl(ctx context.Context, longUrl string, userID uint) (*entities.ShortUrl, error) {
-	shortCode := s.generateShortCode()
+// CreateShortUrl stores a new link. With req.Dedupe set and no alias, an
+// existing active link for the same normalized URL is returned instead,
+// together with ErrDuplicateLongUrl.
+func (s *shortUrlService) CreateShortUrl(ctx context.Context, req *dto.CreateShortUrlRequest, userID uint) (*entities.ShortUrl, error) {
+	if req.Dedupe != "" && req.Dedupe != dto.DedupeScopeUser && req.Dedupe != dto.DedupeScopeInstitution {
+		return nil, service.ErrInvalidDedupeScope
+	}
+	domain, err := s.findCustomDomain(ctx, req.DomainID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Alias != "" {
+		if err := s.validateAlias(ctx, req.DomainID, req.Alias); err != nil {
+			return nil, err
+		}
+	}
+
+	template, err := s.findUtmTemplate(ctx, req.UtmTemplateID, userID, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	shortUrl := &entities.ShortUrl{
-		UserID:    userID,
-		LongUrl:   longUrl,
-		ShortCode: shortCode,
-		IsActive:  true,
-		CreatedAt: time.Now(),
-		CreatedBy: userID,
-		UpdatedAt: time.Now(),
-		UpdatedBy: userID,
+	now := time.Now()
+	shortUrl, err := newShortUrl(req, template, userID, req.Alias, now)
+	if err != nil {
+		return nil, err
+	}
+	if domain != nil {
+		shortUrl.DomainID = domain.ID
+		shortUrl.Domain = domain
+	}
+	if shortUrl.RedirectType == 0 {
+		shortUrl.RedirectType, err = s.defaultRedirectType(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Dedupe != "" && canDedupe(req, shortUrl) {
+		existing, err := s.findDuplicateLongUrl(ctx, shortUrl.LongUrlHash, req.Dedupe, userID, now)
+		if err != nil {
+			return nil, err
+		}
+		// A match created with another redirect type, for example before the
+		// institution default changed, would answer with the wrong status.
+		if existing != nil && existing.RedirectStatus() == shortUrl.RedirectType {
+			return existing, service.ErrDuplicateLongUrl
+		}
 	}
 
-	if err := s.commandRepo.Save(ctx, shortUrl); err != nil {
+	if req.Alias == "" {
+		if err := s.saveWithGeneratedCode(ctx, shortUrl); err != nil {
+			return nil, err
+		}
+	} else if err := s.commandRepo.Save(ctx, shortUrl); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, service.ErrAliasTaken
+		}
 		return nil, fmt.Errorf("failed to save short url: %w", err)
 	}
 
+	s.scanUrlSafety(ctx, shortUrl)
+	// The code may have been looked up, and cached as missing, before.
+	s.cache.invalidate(ctx, shortUrl)
+
 	return shortUrl, nil
 }
 
-func (s *shortUrlService) GetByShortCode(ctx context.Context, shortCode string, userID uint) (*entities.ShortUrl, error) {
-	return s.queryRepo.FindByShortCodeAndUserID(ctx, shortCode, userID)
+func (s *shortUrlService) findDuplicateLongUrl(ctx context.Context, longUrlHash string, scope string, userID uint, now time.Time) (*entities.ShortUrl, error) {
+	shortUrl, err := s.queryRepo.FindActiveByLongUrlHash(ctx, longUrlHash, userID, scope == dto.DedupeScopeInstitution, now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up existing short url: %w", err)
+	}
+	return shortUrl, nil
+}
+
+// canDedupe reports whether an existing link can stand in for the new one.
+func canDedupe(req *dto.CreateShortUrlRequest, shortUrl *entities.ShortUrl) bool {
+	return req.Alias == "" && req.MaxClicks == 0 && req.Password == "" &&
+		req.ActiveFrom == nil && req.Availability == nil &&
+		shortUrl.ExpireAt == nil && shortUrl.FallbackUrl == nil &&
+		req.DomainID == 0 && !req.ForwardQuery && !req.ForwardPath && !req.Preview &&
+		shortUrl.Utm.IsEmpty() && len(shortUrl.TargetingRules) == 0 && !shortUrl.HasSplitDestinations()
+}
+
+// findCustomDomain loads a verified domain of the caller's institution, or
+// returns nil for ID 0, which is the service's own host.
+func (s *shortUrlService) findCustomDomain(ctx context.Context, id uint, userID uint) (*entities.CustomDomain, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	if s.domainRepo == nil {
+		return nil, service.ErrCustomDomainNotFound
+	}
+
+	domain, err := s.domainRepo.FindByIDAndUserID(ctx, id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, service.ErrCustomDomainNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load custom domain: %w", err)
+	}
+	if !domain.IsVerified() {
+		return nil, service.ErrCustomDomainNotVerified
+	}
+	return domain, nil
 }
 
-func (s *shortUrlService) GetByShortCodePublic(ctx context.Context, shortCode string) (*entities.ShortUrl, error) {
-	if s.redisRepo != nil {
-		cachedUrl, err := s.redisRepo.Get(ctx, fmt.Sprintf("short_url:%s", shortCode))
-		if err == nil && cachedUrl != "" {
-			shortUrl, err := s.queryRepo.FindByShortCode(ctx, shortCode)
-			if err == nil {
-				return shortUrl, nil
+// defaultRedirectType is the redirect type of the caller's institution.
+func (s *shortUrlService) defaultRedirectType(ctx context.Context, userID uint) (int, error) {
+	if s.settingRepo == nil {
+		return entities.DefaultRedirectType, nil
+	}
+
+	setting, err := s.settingRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.DefaultRedirectType, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load institution settings: %w", err)
+	}
+	return setting.EffectiveRedirectType(), nil
+}
+
+// findUtmTemplate loads the caller's template with the given ID, or returns
+// nil for ID 0. Bulk creation passes a cache so each template is loaded once.
+func (s *shortUrlService) findUtmTemplate(ctx context.Context, id uint, userID uint, cache map[uint]*entities.UtmTemplate) (*entities.UtmTemplate, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	if template, ok := cache[id]; ok {
+		return template, nil
+	}
+	if s.utmTemplateRepo == nil {
+		return nil, service.ErrUtmTemplateNotFound
+	}
+
+	template, err := s.utmTemplateRepo.FindByIDAndUserID(ctx, id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, service.ErrUtmTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load utm template: %w", err)
+	}
+	if cache != nil {
+		cache[id] = template
+	}
+	return template, nil
+}
+
+// BulkCreateShortUrls validates every row up front. In atomic mode nothing is
+// written unless all rows are valid, and then all rows are written in one
+// transaction. Otherwise each valid row is saved on its own and failures are
+// reported per row.
+func (s *shortUrlService) BulkCreateShortUrls(ctx context.Context, reqs []dto.CreateShortUrlRequest, userID uint, atomic bool) (*dto.BulkCreateShortUrlResponse, error) {
+	if len(reqs) == 0 || len(reqs) > maxBulkCreateRows {
+		return nil, service.ErrInvalidBulkSize
+	}
+
+	claimedCodes, err := s.findTakenAliases(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	redirectType, err := s.defaultRedirectType(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response := &dto.BulkCreateShortUrlResponse{Results: make([]dto.BulkCreateShortUrlResult, len(reqs))}
+	shortUrls := make([]*entities.ShortUrl, len(reqs))
+	templates := make(map[uint]*entities.UtmTemplate)
+	for i := range reqs {
+		req := &reqs[i]
+		response.Results[i] = dto.BulkCreateShortUrlResult{Index: i, LongUrl: req.LongUrl}
+
+		template, err := s.findUtmTemplate(ctx, req.UtmTemplateID, userID, templates)
+		if errors.Is(err, service.ErrUtmTemplateNotFound) {
+			response.Results[i].Error = err.Error()
+			response.Failed++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		shortUrl, err := s.newBulkShortUrl(req, template, userID, now, claimedCodes)
+		if err != nil {
+			response.Results[i].Error = err.Error()
+			response.Failed++
+			continue
+		}
+		if shortUrl.RedirectType == 0 {
+			shortUrl.RedirectType = redirectType
+		}
+		shortUrls[i] = shortUrl
+	}
+
+	if atomic && response.Failed > 0 {
+		return response, service.ErrBulkCreateAborted
+	}
+
+	if err := s.assignGeneratedCodes(ctx, shortUrls, claimedCodes); err != nil {
+		return nil, err
+	}
+
+	if atomic {
+		if err := s.saveAllWithRetry(ctx, reqs, shortUrls, claimedCodes); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, shortUrl := range shortUrls {
+		if shortUrl == nil {
+			continue
+		}
+
+		if !atomic {
+			err := s.commandRepo.Save(ctx, shortUrl)
+			if errors.Is(err, gorm.ErrDuplicatedKey) && reqs[i].Alias == "" {
+				err = s.saveWithGeneratedCode(ctx, shortUrl)
 			}
+			if err != nil {
+				response.Results[i].Error = "failed to save short url"
+				switch {
+				case errors.Is(err, gorm.ErrDuplicatedKey):
+					response.Results[i].Error = service.ErrAliasTaken.Error()
+				case errors.Is(err, service.ErrShortCodeExhausted):
+					response.Results[i].Error = err.Error()
+				}
+				response.Failed++
+				continue
+			}
+		}
+
+		s.scanUrlSafety(ctx, shortUrl)
+		s.cache.invalidate(ctx, shortUrl)
+		response.Results[i].ShortCode = shortUrl.ShortCode
+		response.Results[i].ExpireAt = shortUrl.ExpireAt
+		response.Created++
+	}
+
+	return response, nil
+}
+
+func (s *shortUrlService) findTakenAliases(ctx context.Context, reqs []dto.CreateShortUrlRequest) (map[string]bool, error) {
+	var aliases []string
+	for _, req := range reqs {
+		if req.Alias != "" {
+			aliases = append(aliases, req.Alias)
 		}
 	}
 
-	shortUrl, err := s.queryRepo.FindByShortCode(ctx, shortCode)
+	existing, err := s.queryRepo.FindExistingShortCodes(ctx, 0, aliases)
 	if err != nil {
+		return nil, fmt.Errorf("failed to check aliases: %w", err)
+	}
+
+	taken := make(map[string]bool, len(existing))
+	for _, code := range existing {
+		taken[code] = true
+	}
+	return taken, nil
+}
+
+// newBulkShortUrl validates one bulk row. Aliases it claims are added to
+// claimedCodes so a later row in the same request cannot reuse them. Rows
+// without an alias get their code from assignGeneratedCodes.
+func (s *shortUrlService) newBulkShortUrl(req *dto.CreateShortUrlRequest, template *entities.UtmTemplate, userID uint, now time.Time, claimedCodes map[string]bool) (*entities.ShortUrl, error) {
+	if err := validateLongUrl(req.LongUrl); err != nil {
 		return nil, err
 	}
+	if req.DomainID != 0 {
+		return nil, service.ErrCustomDomainInBulk
+	}
+
+	if req.Alias != "" {
+		if !helper.IsValidAlias(req.Alias) {
+			return nil, service.ErrInvalidAlias
+		}
+		if helper.IsReservedAlias(req.Alias) {
+			return nil, service.ErrReservedAlias
+		}
+		if claimedCodes[req.Alias] {
+			return nil, service.ErrAliasTaken
+		}
+	}
+
+	shortUrl, err := newShortUrl(req, template, userID, req.Alias, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Alias != "" {
+		claimedCodes[req.Alias] = true
+	}
+	return shortUrl, nil
+}
+
+// assignGeneratedCodes gives every valid row that has no code yet one that is
+// neither stored nor claimed by another row of the batch, checking the whole
+// batch against the database in one query per round.
+func (s *shortUrlService) assignGeneratedCodes(ctx context.Context, shortUrls []*entities.ShortUrl, claimedCodes map[string]bool) error {
+	var pending []int
+	for i, shortUrl := range shortUrls {
+		if shortUrl != nil && shortUrl.ShortCode == "" {
+			pending = append(pending, i)
+		}
+	}
 
-	if s.redisRepo != nil {
-		s.redisRepo.Set(ctx, fmt.Sprintf("short_url:%s", shortCode), shortUrl.LongUrl, time.Hour*24)
+	for attempt := 1; len(pending) > 0; attempt++ {
+		if attempt > maxShortCodeAttempts {
+			return service.ErrShortCodeExhausted
+		}
+
+		codes := make([]string, 0, len(pending))
+		for _, i := range pending {
+			shortCode, err := s.generateShortCode(ctx)
+			if err != nil {
+				return err
+			}
+			shortUrls[i].ShortCode = shortCode
+			codes = append(codes, shortCode)
+		}
+
+		existing, err := s.queryRepo.FindExistingShortCodes(ctx, 0, codes)
+		if err != nil {
+			return fmt.Errorf("failed to check short codes: %w", err)
+		}
+		taken := make(map[string]bool, len(existing))
+		for _, code := range existing {
+			taken[code] = true
+		}
+
+		var retry []int
+		for _, i := range pending {
+			shortCode := shortUrls[i].ShortCode
+			if taken[shortCode] || claimedCodes[shortCode] {
+				shortUrls[i].ShortCode = ""
+				retry = append(retry, i)
+				continue
+			}
+			claimedCodes[shortCode] = true
+		}
+		pending = retry
+	}
+
+	return nil
+}
+
+// saveAllWithRetry writes an atomic batch. A unique violation here means a
+// concurrent request took one of the codes after they were checked: a taken
+// alias fails the batch, a taken generated code is replaced and the batch is
+// written again.
+func (s *shortUrlService) saveAllWithRetry(ctx context.Context, reqs []dto.CreateShortUrlRequest, shortUrls []*entities.ShortUrl, claimedCodes map[string]bool) error {
+	for attempt := 1; ; attempt++ {
+		err := s.commandRepo.SaveAll(ctx, shortUrls)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("failed to save short urls: %w", err)
+		}
+		if attempt == maxShortCodeAttempts {
+			return service.ErrShortCodeExhausted
+		}
+
+		codes := make([]string, len(shortUrls))
+		for i, shortUrl := range shortUrls {
+			codes[i] = shortUrl.ShortCode
+		}
+		existing, err := s.queryRepo.FindExistingShortCodes(ctx, 0, codes)
+		if err != nil {
+			return fmt.Errorf("failed to check short codes: %w", err)
+		}
+		taken := make(map[string]bool, len(existing))
+		for _, code := range existing {
+			taken[code] = true
+		}
+
+		for i, shortUrl := range shortUrls {
+			// The rolled back insert may have assigned IDs already.
+			shortUrl.ID = 0
+			for j := range shortUrl.Destinations {
+				shortUrl.Destinations[j].ID = 0
+				shortUrl.Destinations[j].ShortUrlID = 0
+			}
+			if !taken[shortUrl.ShortCode] {
+				continue
+			}
+			if reqs[i].Alias != "" {
+				return service.ErrAliasTaken
+			}
+			shortUrl.ShortCode = ""
+		}
+
+		if err := s.assignGeneratedCodes(ctx, shortUrls, claimedCodes); err != nil {
+			return err
+		}
+	}
+}
+
+func newShortUrl(req *dto.CreateShortUrlRequest, template *entities.UtmTemplate, userID uint, shortCode string, now time.Time) (*entities.ShortUrl, error) {
+	if err := validateLongUrl(req.LongUrl); err != nil {
+		return nil, err
+	}
+	expireAt, err := resolveExpireAt(req.ExpireAt, req.TTL, now)
+	if err != nil {
+		return nil, err
 	}
 
+	shortUrl := &entities.ShortUrl{
+		UserID:       userID,
+		LongUrl:      req.LongUrl,
+		LongUrlHash:  helper.LongUrlHash(req.LongUrl),
+		ShortCode:    shortCode,
+		IsActive:     true,
+		ExpireAt:     expireAt,
+		ForwardQuery: req.ForwardQuery,
+		ForwardPath:  req.ForwardPath,
+		CreatedAt:    now,
+		CreatedBy:    userID,
+		UpdatedAt:    now,
+		UpdatedBy:    userID,
+	}
+	if req.FallbackUrl != "" {
+		if !isHttpUrl(req.FallbackUrl) {
+			return nil, service.ErrInvalidFallbackUrl
+		}
+		shortUrl.FallbackUrl = &req.FallbackUrl
+	}
+	shortUrl.Availability, err = toAvailabilityWindow(req.Availability)
+	if err != nil {
+		return nil, err
+	}
+	shortUrl.ActiveFrom = req.ActiveFrom
+	if !activeFromBeforeExpiry(shortUrl) {
+		return nil, service.ErrInvalidActiveFrom
+	}
+	if req.Password != "" {
+		shortUrl.PasswordHash, err = hashLinkPassword(req.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.MaxClicks != 0 {
+		if req.MaxClicks < 0 {
+			return nil, service.ErrInvalidMaxClicks
+		}
+		shortUrl.MaxClicks = &req.MaxClicks
+		shortUrl.RemainingClicks = &req.MaxClicks
+	}
+	shortUrl.Utm, err = toUtmParams(template, req.Utm)
+	if err != nil {
+		return nil, err
+	}
+	shortUrl.TargetingRules, err = toTargetingRules(req.TargetingRules)
+	if err != nil {
+		return nil, err
+	}
+	shortUrl.Destinations, err = toSplitDestinations(req.Destinations)
+	if err != nil {
+		return nil, err
+	}
+	shortUrl.StickyDestinations = req.StickyDestinations
+	shortUrl.Preview = req.Preview
+	if req.RedirectType != 0 && !entities.IsValidRedirectType(req.RedirectType) {
+		return nil, service.ErrInvalidRedirectType
+	}
+	shortUrl.RedirectType = req.RedirectType
 	return shortUrl, nil
 }
 
+func (s *shortUrlService) GetByShortCode(ctx context.Context, domainID uint, shortCode string, userID uint) (*entities.ShortUrl, error) {
+	return s.queryRepo.FindByShortCodeAndUserID(ctx, domainID, shortCode, userID)
+}
+
+func (s *shortUrlService) UpdateShortUrl(ctx context.Context, domainID uint, shortCode string, req *dto.UpdateShortUrlRequest, userID uint) (*entities.ShortUrl, error) {
+	shortUrl, err := s.queryRepo.FindByShortCodeAndUserIDAnyStatus(ctx, domainID, shortCode, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.ClearExpiry {
+		if req.ExpireAt != nil || req.TTL != 0 {
+			return nil, service.ErrConflictingExpiry
+		}
+		shortUrl.ExpireAt = nil
+	} else if req.ExpireAt != nil || req.TTL != 0 {
+		expireAt, err := resolveExpireAt(req.ExpireAt, req.TTL, now)
+		if err != nil {
+			return nil, err
+		}
+		shortUrl.ExpireAt = expireAt
+	}
+
+	if (req.ClearActiveFrom && req.ActiveFrom != nil) || (req.ClearAvailability && req.Availability != nil) {
+		return nil, service.ErrConflictingSchedule
+	}
+	if req.ClearActiveFrom {
+		shortUrl.ActiveFrom = nil
+	} else if req.ActiveFrom != nil {
+		shortUrl.ActiveFrom = req.ActiveFrom
+	}
+	if req.ClearAvailability {
+		shortUrl.Availability = nil
+	} else if req.Availability != nil {
+		shortUrl.Availability, err = toAvailabilityWindow(req.Availability)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !activeFromBeforeExpiry(shortUrl) {
+		return nil, service.ErrInvalidActiveFrom
+	}
+
+	var maxClicks *int64
+	if req.MaxClicks != nil {
+		if *req.MaxClicks < 0 {
+			return nil, service.ErrInvalidMaxClicks
+		}
+		if *req.MaxClicks > 0 {
+			maxClicks = req.MaxClicks
+		}
+	}
+
+	if req.Utm != nil || req.UtmTemplateID != 0 {
+		template, err := s.findUtmTemplate(ctx, req.UtmTemplateID, userID, nil)
+		if err != nil {
+			return nil, err
+		}
+		shortUrl.Utm, err = toUtmParams(template, req.Utm)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	previousDestinations := shortUrl.DestinationUrls()
+	if req.TargetingRules != nil {
+		shortUrl.TargetingRules, err = toTargetingRules(*req.TargetingRules)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var splitDestinations []entities.ShortUrlDestination
+	if req.Destinations != nil {
+		splitDestinations, err = toSplitDestinations(*req.Destinations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Password != nil {
+		shortUrl.PasswordHash = ""
+		if *req.Password != "" {
+			shortUrl.PasswordHash, err = hashLinkPassword(*req.Password)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if req.LongUrl != nil {
+		if err := validateLongUrl(*req.LongUrl); err != nil {
+			return nil, err
+		}
+		shortUrl.LongUrl = *req.LongUrl
+		shortUrl.LongUrlHash = helper.LongUrlHash(shortUrl.LongUrl)
+	}
+	if req.IsActive != nil {
+		shortUrl.IsActive = *req.IsActive
+	}
+	if req.ForwardQuery != nil {
+		shortUrl.ForwardQuery = *req.ForwardQuery
+	}
+	if req.ForwardPath != nil {
+		shortUrl.ForwardPath = *req.ForwardPath
+	}
+	if req.StickyDestinations != nil {
+		shortUrl.StickyDestinations = *req.StickyDestinations
+	}
+	if req.Preview != nil {
+		shortUrl.Preview = *req.Preview
+	}
+	if req.RedirectType != nil {
+		if !entities.IsValidRedirectType(*req.RedirectType) {
+			return nil, service.ErrInvalidRedirectType
+		}
+		shortUrl.RedirectType = *req.RedirectType
+	}
+	if req.FallbackUrl != nil {
+		if *req.FallbackUrl != "" && !isHttpUrl(*req.FallbackUrl) {
+			return nil, service.ErrInvalidFallbackUrl
+		}
+		shortUrl.FallbackUrl = req.FallbackUrl
+		if *req.FallbackUrl == "" {
+			shortUrl.FallbackUrl = nil
+		}
+	}
+
+	shortUrl.UpdatedAt = now
+	shortUrl.UpdatedBy = userID
+
+	if err := s.commandRepo.Update(ctx, shortUrl); err != nil {
+		return nil, fmt.Errorf("failed to update short url: %w", err)
+	}
+
+	if req.MaxClicks != nil {
+		if err := s.commandRepo.ResetClickBudget(ctx, shortUrl.ID, maxClicks); err != nil {
+			return nil, fmt.Errorf("failed to update click limit: %w", err)
+		}
+		shortUrl.MaxClicks = maxClicks
+		shortUrl.RemainingClicks = maxClicks
+	}
+
+	if req.Destinations != nil {
+		shortUrl.Destinations, err = s.commandRepo.ReplaceDestinations(ctx, shortUrl.ID, splitDestinations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update destinations: %w", err)
+		}
+	}
+
+	if !slices.Equal(previousDestinations, shortUrl.DestinationUrls()) {
+		s.scanUrlSafety(ctx, shortUrl)
+	}
+	s.cache.invalidate(ctx, shortUrl)
+
+	return shortUrl, nil
+}
+
+func (s *shortUrlService) DeleteShortUrl(ctx context.Context, domainID uint, shortCode string, userID uint) error {
+	shortUrl, err := s.queryRepo.FindByShortCodeAndUserIDAnyStatus(ctx, domainID, shortCode, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.commandRepo.Delete(ctx, shortUrl.ID); err != nil {
+		return fmt.Errorf("failed to delete short url: %w", err)
+	}
+
+	s.cache.invalidate(ctx, shortUrl)
+
+	return nil
+}
+
+// GetByShortCodePublic resolves a code on the host the request came in on.
+// Hosts that are not a verified custom domain resolve on the service's own
+// host.
+func (s *shortUrlService) GetByShortCodePublic(ctx context.Context, host string, shortCode string) (*entities.ShortUrl, error) {
+	domainID, err := s.resolveDomainID(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	shortUrl, err := s.findPublicShortUrl(ctx, domainID, shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if shortUrl.IsExpired(now) {
+		return shortUrl, service.ErrShortUrlExpired
+	}
+	if shortUrl.IsClickLimitReached() {
+		return shortUrl, service.ErrShortUrlClickLimitReached
+	}
+	if !shortUrl.IsAvailable(now) {
+		return shortUrl, service.ErrShortUrlNotAvailable
+	}
+	// The password comes before the safety verdict: the unsafe warning page
+	// shows the destination, which a protected link must not reveal.
+	if shortUrl.IsPasswordProtected() {
+		return shortUrl, service.ErrShortUrlPasswordRequired
+	}
+	if shortUrl.IsFlaggedUnsafe() {
+		return shortUrl, service.ErrShortUrlUnsafe
+	}
+
+	return shortUrl, nil
+}
+
+// findPublicShortUrl loads the active link from the cache, or from the
+// database on a miss. The checks of GetByShortCodePublic run on every lookup,
+// so links are cached whatever state they are in and only the record has to
+// be kept current. Codes without an active link are cached as missing.
+//
+// Concurrent misses for the same code share one reload, and with it the
+// returned link, which callers must not modify. The reload runs without the
+// caller's cancellation, so one client going away does not fail the others.
+func (s *shortUrlService) findPublicShortUrl(ctx context.Context, domainID uint, shortCode string) (*entities.ShortUrl, error) {
+	if shortUrl, ok := s.cache.get(ctx, domainID, shortCode); ok {
+		return cachedLookup(shortUrl)
+	}
+
+	result, err, _ := s.reloads.Do(shortUrlCacheKey(domainID, shortCode), func() (any, error) {
+		return s.reloadPublicShortUrl(context.WithoutCancel(ctx), domainID, shortCode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*entities.ShortUrl), nil
+}
+
+// reloadPublicShortUrl queries the database for a code that missed the
+// cache. Only one instance reloads a code at a time; the others wait up to
+// shortUrlLockTTL for its entry before they query the database themselves.
+// The cache is checked again first, since the entry may have been filled
+// since the caller missed it. These checks are not counted in the cache
+// stats; the caller's miss already was.
+func (s *shortUrlService) reloadPublicShortUrl(ctx context.Context, domainID uint, shortCode string) (*entities.ShortUrl, error) {
+	deadline := time.Now().Add(shortUrlLockTTL)
+	for {
+		if shortUrl, ok, _ := s.cache.peek(ctx, domainID, shortCode); ok {
+			return cachedLookup(shortUrl)
+		}
+		if token, ok := s.cache.lock(ctx, domainID, shortCode); ok {
+			defer s.cache.unlock(ctx, domainID, shortCode, token)
+			break
+		}
+		if !time.Now().Before(deadline) {
+			break
+		}
+		time.Sleep(shortUrlLockPoll)
+	}
+
+	reload := s.cache.beginReload(ctx, domainID, shortCode)
+	shortUrl, err := s.queryRepo.FindByShortCode(ctx, domainID, shortCode)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.cache.setMissing(ctx, domainID, shortCode, reload)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.set(ctx, shortUrl, reload, time.Now())
+	return shortUrl, nil
+}
+
+// cachedLookup turns a cache hit into the result of a lookup; a nil link is a
+// code cached as missing.
+func cachedLookup(shortUrl *entities.ShortUrl) (*entities.ShortUrl, error) {
+	if shortUrl == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return shortUrl, nil
+}
+
+func (s *shortUrlService) resolveDomainID(ctx context.Context, host string) (uint, error) {
+	host = helper.NormalizeHost(host)
+	if s.domainRepo == nil || host == "" {
+		return 0, nil
+	}
+	if domainID, ok := s.cache.getDomainID(ctx, host); ok {
+		return domainID, nil
+	}
+
+	var domainID uint
+	domain, err := s.domainRepo.FindVerifiedByHost(ctx, host)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		return 0, fmt.Errorf("failed to resolve host: %w", err)
+	default:
+		domainID = domain.ID
+	}
+
+	s.cache.setDomainID(ctx, host, domainID)
+	return domainID, nil
+}
+
 func (s *shortUrlService) GetByFilter(ctx context.Context, filter dto.ShortUrlQueryFilter, pagination dto.Pagination) ([]entities.ShortUrl, *dto.PaginationResponse, error) {
 	return s.queryRepo.FindByFilter(ctx, filter, pagination)
 }
 
-func (s *shortUrlService) IncrementClickCount(ctx context.Context, shortCode string) error {
-	key := fmt.Sprintf("click_count:%s", shortCode)
-	_, err := s.redisRepo.Increment(ctx, key)
-	return err
+func (s *shortUrlService) RecordClick(shortUrlID uint) {
+	s.clicks.push(shortUrlID, time.Now())
+}
+
+// CountClicks adds the clicks queued by RecordClick to the Redis counters
+// until ctx is done. Without a clickCounterRepo it returns at once.
+func (s *shortUrlService) CountClicks(ctx context.Context) {
+	s.clicks.run(ctx)
+}
+
+// ConsumeClick spends one click of a click-limited link right before it is
+// redirected. Links without a limit are left alone.
+func (s *shortUrlService) ConsumeClick(ctx context.Context, shortUrl *entities.ShortUrl) error {
+	if !shortUrl.HasClickLimit() {
+		return nil
+	}
+
+	consumed, err := s.commandRepo.ConsumeClick(ctx, shortUrl.ID)
+	if err != nil {
+		return fmt.Errorf("failed to consume click: %w", err)
+	}
+	if !consumed {
+		s.cache.invalidate(ctx, shortUrl)
+		return service.ErrShortUrlClickLimitReached
+	}
+	return nil
+}
+
+// SyncCache keeps the in-process tier of the link cache in line with changes
+// made on other instances until ctx is done. It returns at once when that
+// tier is disabled.
+func (s *shortUrlService) SyncCache(ctx context.Context) {
+	s.cache.listen(ctx)
+}
+
+// CacheStats reports the hit and miss counters of the link cache.
+func (s *shortUrlService) CacheStats() dto.LinkCacheStats {
+	return s.cache.stats()
 }
 
-func (s *shortUrlService) generateShortCode() string {
-	bytes := make([]byte, 6)
-	rand.Read(bytes)
-	encoded := base64.URLEncoding.EncodeToString(bytes)
-	return strings.TrimRight(encoded, "=")[:8]
+// SelectDestination picks where a redirect goes: the destination of the first
+// targeting rule the visitor matches, else one of the split destinations, else
+// LongUrl. The country is only looked up when a rule asks for one.
+func (s *shortUrlService) SelectDestination(ctx context.Context, shortUrl *entities.ShortUrl, visitor dto.RedirectVisitor) dto.RedirectDestination {
+	if len(shortUrl.TargetingRules) > 0 {
+		target := entities.Visitor{
+			OS:       targetOS(helper.ParseUserAgent(visitor.UserAgent)),
+			Language: helper.PrimaryLanguage(visitor.AcceptLanguage),
+		}
+		if s.countryResolver != nil && shortUrl.HasCountryTargeting() {
+			target.Country = s.countryResolver.ResolveCountry(visitor.IP)
+		}
+		if destination, ok := shortUrl.TargetDestination(target); ok {
+			return dto.RedirectDestination{Url: destination}
+		}
+	}
+
+	if destination := pickSplitDestination(shortUrl, visitor.DestinationID); destination != nil {
+		return dto.RedirectDestination{Url: destination.Url, DestinationID: destination.ID}
+	}
+	return dto.RedirectDestination{Url: shortUrl.LongUrl}
+}
+
+// pickSplitDestination keeps a sticky visitor on the destination they had, as
+// long as the link still splits to it, and draws a weighted one otherwise.
+func pickSplitDestination(shortUrl *entities.ShortUrl, previousID uint) *entities.ShortUrlDestination {
+	if !shortUrl.HasSplitDestinations() {
+		return nil
+	}
+	if shortUrl.StickyDestinations && previousID != 0 {
+		if destination := shortUrl.FindDestination(previousID); destination != nil {
+			return destination
+		}
+	}
+	total := shortUrl.TotalDestinationWeight()
+	if total <= 0 {
+		return nil
+	}
+	return shortUrl.PickDestination(rand.IntN(total))
+}
+
+// targetOS maps a parsed User-Agent onto the OS values targeting rules use.
+// Tablets count as their OS; desktop means any other non-bot computer.
+func targetOS(userAgent helper.UserAgentInfo) string {
+	switch {
+	case userAgent.OS == "iOS":
+		return entities.TargetOSIOS
+	case userAgent.OS == "Android":
+		return entities.TargetOSAndroid
+	case userAgent.DeviceClass == helper.DeviceClassDesktop:
+		return entities.TargetOSDesktop
+	default:
+		return ""
+	}
+}
+
+// scanUrlSafety records a safety verdict for the link's destinations. A failed
+// scan is only logged; the periodic recheck picks the link up again.
+func (s *shortUrlService) scanUrlSafety(ctx context.Context, shortUrl *entities.ShortUrl) {
+	if s.urlSafetyService == nil {
+		return
+	}
+	if _, err := s.urlSafetyService.Scan(ctx, shortUrl); err != nil {
+		log.Printf("Failed to scan short url %s: %v", shortUrl.ShortCode, err)
+	}
+}
+
+func (s *shortUrlService) validateAlias(ctx context.Context, domainID uint, alias string) error {
+	if !helper.IsValidAlias(alias) {
+		return service.ErrInvalidAlias
+	}
+
+	if helper.IsReservedAlias(alias) {
+		return service.ErrReservedAlias
+	}
+
+	exists, err := s.queryRepo.ExistsByShortCode(ctx, domainID, alias)
+	if err != nil {
+		return fmt.Errorf("failed to check alias: %w", err)
+	}
+	if exists {
+		return service.ErrAliasTaken
+	}
+
+	return nil
+}
+
+// toAvailabilityWindow validates a requested availability window.
+func toAvailabilityWindow(req *dto.AvailabilityWindow) (*entities.AvailabilityWindow, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	window := &entities.AvailabilityWindow{
+		Days:     append([]string(nil), req.Days...),
+		Start:    req.Start,
+		End:      req.End,
+		Timezone: req.Timezone,
+	}
+	if window.Timezone == "" {
+		window.Timezone = "UTC"
+	}
+	if err := window.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", service.ErrInvalidAvailability, err)
+	}
+	return window, nil
+}
+
+// toTargetingRules validates requested targeting rules and normalizes their
+// values. No rules give nil, so the column stays empty.
+func toTargetingRules(reqs []dto.TargetingRule) ([]entities.TargetingRule, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	if len(reqs) > maxTargetingRules {
+		return nil, fmt.Errorf("%w: %d rules given", service.ErrInvalidTargetingRules, len(reqs))
+	}
+
+	rules := make([]entities.TargetingRule, len(reqs))
+	for i, req := range reqs {
+		rule, err := toTargetingRule(req)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rule %d: %v", service.ErrInvalidTargetingRules, i+1, err)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+func toTargetingRule(req dto.TargetingRule) (entities.TargetingRule, error) {
+	rule := entities.TargetingRule{Destination: strings.TrimSpace(req.Destination)}
+	if !isHttpUrl(rule.Destination) {
+		return rule, errors.New("destination must be an http or https URL")
+	}
+	if len(req.OS) == 0 && len(req.Languages) == 0 && len(req.Countries) == 0 {
+		return rule, errors.New("at least one condition is required")
+	}
+
+	for _, os := range req.OS {
+		os = strings.ToLower(strings.TrimSpace(os))
+		if os != entities.TargetOSIOS && os != entities.TargetOSAndroid && os != entities.TargetOSDesktop {
+			return rule, fmt.Errorf("unknown os %q", os)
+		}
+		rule.OS = append(rule.OS, os)
+	}
+	for _, language := range req.Languages {
+		language = strings.TrimSpace(language)
+		if !isLanguagePrefix(language) {
+			return rule, fmt.Errorf("invalid language %q", language)
+		}
+		rule.Languages = append(rule.Languages, language)
+	}
+	for _, country := range req.Countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if len(country) != 2 || !isLetters(country) {
+			return rule, fmt.Errorf("invalid country %q", country)
+		}
+		rule.Countries = append(rule.Countries, country)
+	}
+	return rule, nil
+}
+
+// isLanguagePrefix accepts language tags such as "de" or "pt-BR".
+func isLanguagePrefix(language string) bool {
+	if language == "" || len(language) > 35 {
+		return false
+	}
+	for _, subtag := range strings.Split(language, "-") {
+		if subtag == "" || !isLetters(subtag) {
+			return false
+		}
+	}
+	return true
+}
+
+// toSplitDestinations validates requested split destinations. No destinations
+// give nil, so the link redirects to its LongUrl.
+func toSplitDestinations(reqs []dto.ShortUrlDestination) ([]entities.ShortUrlDestination, error) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	if len(reqs) < minSplitDestinations || len(reqs) > maxSplitDestinations {
+		return nil, fmt.Errorf("%w: %d destinations given", service.ErrInvalidDestinations, len(reqs))
+	}
+
+	destinations := make([]entities.ShortUrlDestination, len(reqs))
+	seen := make(map[string]bool, len(reqs))
+	for i, req := range reqs {
+		destinationUrl := strings.TrimSpace(req.Url)
+		if !isHttpUrl(destinationUrl) {
+			return nil, fmt.Errorf("%w: destination %d is not an http or https url", service.ErrInvalidDestinations, i+1)
+		}
+		if seen[destinationUrl] {
+			return nil, fmt.Errorf("%w: %s is listed twice", service.ErrInvalidDestinations, destinationUrl)
+		}
+		if req.Weight < 1 || req.Weight > maxDestinationWeight {
+			return nil, fmt.Errorf("%w: destination %d has weight %d", service.ErrInvalidDestinations, i+1, req.Weight)
+		}
+		seen[destinationUrl] = true
+		destinations[i] = entities.ShortUrlDestination{Url: destinationUrl, Weight: req.Weight, Position: i}
+	}
+	return destinations, nil
+}
+
+// validateLongUrl checks the destination of a link the same way for single
+// creates, bulk rows and updates.
+func validateLongUrl(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return service.ErrLongUrlRequired
+	}
+	if !isHttpUrl(value) {
+		return service.ErrInvalidLongUrl
+	}
+	return nil
+}
+
+func isHttpUrl(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func isLetters(value string) bool {
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func activeFromBeforeExpiry(shortUrl *entities.ShortUrl) bool {
+	return shortUrl.ActiveFrom == nil || shortUrl.ExpireAt == nil || shortUrl.ActiveFrom.Before(*shortUrl.ExpireAt)
+}
+
+// resolveExpireAt turns an absolute expire_at or relative ttl (seconds) from
+// a request into the link's expiry time.
+func resolveExpireAt(expireAt *time.Time, ttl int64, now time.Time) (*time.Time, error) {
+	if expireAt != nil && ttl != 0 {
+		return nil, service.ErrConflictingExpiry
+	}
+
+	if expireAt != nil {
+		if !expireAt.After(now) {
+			return nil, service.ErrInvalidExpiry
+		}
+		return expireAt, nil
+	}
+
+	if ttl != 0 {
+		if ttl < 0 || ttl > maxLinkTTL {
+			return nil, service.ErrInvalidExpiry
+		}
+		resolved := now.Add(time.Duration(ttl) * time.Second)
+		return &resolved, nil
+	}
+
+	return nil, nil
+}
+
+// saveWithGeneratedCode assigns a fresh code and saves the link, retrying
+// with another code whenever the unique index on short_code rejects it.
+func (s *shortUrlService) saveWithGeneratedCode(ctx context.Context, shortUrl *entities.ShortUrl) error {
+	for attempt := 1; attempt <= maxShortCodeAttempts; attempt++ {
+		shortCode, err := s.generateShortCode(ctx)
+		if err != nil {
+			return err
+		}
+		shortUrl.ShortCode = shortCode
+
+		err = s.commandRepo.Save(ctx, shortUrl)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("failed to save short url: %w", err)
+		}
+		log.Printf("Short code %s from the %s generator is taken, retrying (%d/%d)", shortCode, s.shortCodeGenerator.Name(), attempt, maxShortCodeAttempts)
+	}
+	return service.ErrShortCodeExhausted
+}
+
+// generateShortCode skips candidates that match a reserved path; they would be
+// refused as aliases and must not be handed out either.
+func (s *shortUrlService) generateShortCode(ctx context.Context) (string, error) {
+	for attempt := 0; attempt < maxShortCodeAttempts; attempt++ {
+		shortCode, err := s.shortCodeGenerator.Generate(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate short code: %w", err)
+		}
+		if !helper.IsReservedAlias(shortCode) {
+			return shortCode, nil
+		}
+	}
+	return "", service.ErrShortCodeExhausted
 }
@@ -0,0 +1,894 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/helper"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+	servicemocks "short-url/domains/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type ShortUrlServiceTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	commandRepo *mocks.MockShortUrlCommandRepositoryInterface
+	queryRepo   *mocks.MockShortUrlQueryRepositoryInterface
+	redisRepo   *mocks.MockRedisRepositoryInterface
+	clickRepo   *mocks.MockClickCounterRepositoryInterface
+	safety      *servicemocks.MockUrlSafetyServiceInterface
+	utmRepo     *mocks.MockUtmTemplateQueryRepositoryInterface
+	domainRepo  *mocks.MockCustomDomainQueryRepositoryInterface
+	settingRepo *mocks.MockInstitutionSettingQueryRepositoryInterface
+	countries   service.CountryResolver
+	service     service.ShortUrlServiceInterface
+}
+
+func (suite *ShortUrlServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.commandRepo = mocks.NewMockShortUrlCommandRepositoryInterface(suite.T())
+	suite.queryRepo = mocks.NewMockShortUrlQueryRepositoryInterface(suite.T())
+	suite.redisRepo = mocks.NewMockRedisRepositoryInterface(suite.T())
+	suite.clickRepo = mocks.NewMockClickCounterRepositoryInterface(suite.T())
+	suite.safety = servicemocks.NewMockUrlSafetyServiceInterface(suite.T())
+	suite.utmRepo = mocks.NewMockUtmTemplateQueryRepositoryInterface(suite.T())
+	suite.domainRepo = mocks.NewMockCustomDomainQueryRepositoryInterface(suite.T())
+	suite.settingRepo = mocks.NewMockInstitutionSettingQueryRepositoryInterface(suite.T())
+	countries, err := NewCidrCountryResolver(map[string]string{"192.0.2.0/24": "DE", "2001:db8::/32": "AT"})
+	suite.Require().NoError(err)
+	suite.countries = countries
+	suite.service = NewShortUrlService(suite.commandRepo, suite.queryRepo, suite.redisRepo, suite.clickRepo, suite.safety, nil, suite.utmRepo, suite.countries, suite.domainRepo, nil, dto.LinkCacheConfig{})
+}
+
+// cachedRecord is the cache entry GetByShortCodePublic would store for shortUrl.
+// expectReloadLock expects a lookup that missed the cache to take and release
+// the reload lock, and to store the reload placeholder in the entry.
+func (suite *ShortUrlServiceTestSuite) expectReloadLock(key string) {
+	entryKey := strings.TrimPrefix(key, "lock:")
+	suite.redisRepo.EXPECT().Set(mock.Anything, entryKey, mock.MatchedBy(func(value string) bool {
+		return strings.HasPrefix(value, reloadingCacheEntry)
+	}), shortUrlLockTTL).Return(nil).Once()
+	var token string
+	suite.redisRepo.EXPECT().SetNX(mock.Anything, key, mock.AnythingOfType("string"), shortUrlLockTTL).RunAndReturn(func(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+		token = value.(string)
+		return true, nil
+	}).Once()
+	suite.redisRepo.EXPECT().DeleteIfEqual(mock.Anything, key, mock.AnythingOfType("string")).RunAndReturn(func(ctx context.Context, key string, value string) error {
+		suite.Equal(token, value)
+		return nil
+	}).Once()
+}
+
+func (suite *ShortUrlServiceTestSuite) cachedRecord(shortUrl *entities.ShortUrl) string {
+	record, err := json.Marshal(newCachedShortUrl(shortUrl))
+	suite.Require().NoError(err)
+	return string(record)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_WithTTL() {
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	before := time.Now()
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", TTL: 3600}, 1)
+
+	suite.Require().NoError(err)
+	suite.Require().NotNil(result.ExpireAt)
+	assert.WithinDuration(suite.T(), before.Add(time.Hour), *result.ExpireAt, 5*time.Second)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_RetriesOnCollision() {
+	generator := servicemocks.NewMockShortCodeGenerator(suite.T())
+	generator.EXPECT().Name().Return("mock").Maybe()
+	generator.EXPECT().Generate(suite.ctx).Return("taken001", nil).Once()
+	generator.EXPECT().Generate(suite.ctx).Return("health", nil).Once()
+	generator.EXPECT().Generate(suite.ctx).Return("fresh001", nil).Once()
+	svc := NewShortUrlService(suite.commandRepo, suite.queryRepo, suite.redisRepo, suite.clickRepo, suite.safety, generator, suite.utmRepo, suite.countries, suite.domainRepo, nil, dto.LinkCacheConfig{})
+
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.MatchedBy(func(shortUrl *entities.ShortUrl) bool { return shortUrl.ShortCode == "taken001" })).
+		Return(gorm.ErrDuplicatedKey).Once()
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.MatchedBy(func(shortUrl *entities.ShortUrl) bool { return shortUrl.ShortCode == "fresh001" })).
+		Return(nil).Once()
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := svc.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com"}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "fresh001", result.ShortCode)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_GivesUpAfterMaxAttempts() {
+	generator := servicemocks.NewMockShortCodeGenerator(suite.T())
+	generator.EXPECT().Name().Return("mock").Maybe()
+	generator.EXPECT().Generate(suite.ctx).Return("taken001", nil).Times(maxShortCodeAttempts)
+	svc := NewShortUrlService(suite.commandRepo, suite.queryRepo, suite.redisRepo, suite.clickRepo, suite.safety, generator, suite.utmRepo, suite.countries, suite.domainRepo, nil, dto.LinkCacheConfig{})
+
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(gorm.ErrDuplicatedKey).Times(maxShortCodeAttempts)
+
+	_, err := svc.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com"}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrShortCodeExhausted)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_DedupeReturnsExisting() {
+	existing := &entities.ShortUrl{ID: 3, UserID: 2, ShortCode: "exist001", LongUrl: "https://example.com/page"}
+	hash := helper.LongUrlHash("https://Example.com/page")
+	suite.queryRepo.EXPECT().FindActiveByLongUrlHash(suite.ctx, hash, uint(1), true, mock.AnythingOfType("time.Time")).Return(existing, nil)
+
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{
+		LongUrl: "https://Example.com/page",
+		Dedupe:  dto.DedupeScopeInstitution,
+	}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrDuplicateLongUrl)
+	assert.Equal(suite.T(), existing, result)
+	suite.commandRepo.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_DedupeCreatesWhenNoMatch() {
+	suite.queryRepo.EXPECT().FindActiveByLongUrlHash(suite.ctx, mock.Anything, uint(1), false, mock.AnythingOfType("time.Time")).Return(nil, gorm.ErrRecordNotFound)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com/page", Dedupe: dto.DedupeScopeUser}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), helper.LongUrlHash("https://example.com/page"), result.LongUrlHash)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_InvalidDedupeScope() {
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", Dedupe: "everyone"}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidDedupeScope)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_InstitutionRedirectType() {
+	svc := NewShortUrlService(suite.commandRepo, suite.queryRepo, suite.redisRepo, suite.clickRepo, suite.safety, nil, suite.utmRepo, suite.countries, suite.domainRepo, suite.settingRepo, dto.LinkCacheConfig{})
+	suite.settingRepo.EXPECT().FindByUserID(suite.ctx, uint(1)).Return(&entities.InstitutionSetting{InstitutionID: 1, RedirectType: entities.RedirectTypePermanentRedirect}, nil)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := svc.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com"}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), entities.RedirectTypePermanentRedirect, result.RedirectType)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_RedirectTypeOverridesDefault() {
+	svc := NewShortUrlService(suite.commandRepo, suite.queryRepo, suite.redisRepo, suite.clickRepo, suite.safety, nil, suite.utmRepo, suite.countries, suite.domainRepo, suite.settingRepo, dto.LinkCacheConfig{})
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := svc.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", RedirectType: entities.RedirectTypeTemporaryRedirect}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), entities.RedirectTypeTemporaryRedirect, result.RedirectType)
+	suite.settingRepo.AssertNotCalled(suite.T(), "FindByUserID", mock.Anything, mock.Anything)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_InvalidRedirectType() {
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", RedirectType: 303}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidRedirectType)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_DedupeSkipsOtherRedirectType() {
+	existing := &entities.ShortUrl{ID: 3, UserID: 1, ShortCode: "exist001", LongUrl: "https://example.com/page", RedirectType: entities.RedirectTypeFound}
+	suite.queryRepo.EXPECT().FindActiveByLongUrlHash(suite.ctx, mock.Anything, uint(1), false, mock.AnythingOfType("time.Time")).Return(existing, nil)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{
+		LongUrl:      "https://example.com/page",
+		Dedupe:       dto.DedupeScopeUser,
+		RedirectType: entities.RedirectTypeMovedPermanently,
+	}, 1)
+
+	suite.Require().NoError(err)
+	assert.NotEqual(suite.T(), existing.ID, result.ID)
+	assert.Equal(suite.T(), entities.RedirectTypeMovedPermanently, result.RedirectType)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_InvalidRedirectType() {
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(&entities.ShortUrl{ID: 5, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com"}, nil)
+
+	redirectType := 200
+	_, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{RedirectType: &redirectType}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidRedirectType)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_InvalidLongUrl() {
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: " "}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrLongUrlRequired)
+
+	for _, value := range []string{"example.com", "javascript:alert(1)", "ftp://example.com"} {
+		_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: value}, 1)
+		assert.ErrorIs(suite.T(), err, service.ErrInvalidLongUrl, value)
+	}
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_InvalidUrls() {
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(&entities.ShortUrl{ID: 5, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com"}, nil)
+
+	empty := ""
+	_, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{LongUrl: &empty}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrLongUrlRequired)
+
+	for _, value := range []string{"example.com", "javascript:alert(1)", "ftp://example.com"} {
+		_, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{LongUrl: &value}, 1)
+		assert.ErrorIs(suite.T(), err, service.ErrInvalidLongUrl, value)
+
+		_, err = suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{FallbackUrl: &value}, 1)
+		assert.ErrorIs(suite.T(), err, service.ErrInvalidFallbackUrl, value)
+	}
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_HashesPassword() {
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", Password: "open sesame"}, 1)
+
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.IsPasswordProtected())
+	assert.NoError(suite.T(), bcrypt.CompareHashAndPassword([]byte(result.PasswordHash), []byte("open sesame")))
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_InvalidPassword() {
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", Password: "abc"}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidLinkPassword)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_OneTimeLinkSkipsDedupe() {
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", MaxClicks: 1, Dedupe: dto.DedupeScopeUser}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(1), *result.MaxClicks)
+	assert.Equal(suite.T(), int64(1), *result.RemainingClicks)
+	suite.queryRepo.AssertNotCalled(suite.T(), "FindActiveByLongUrlHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_PasswordSkipsDedupe() {
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", Password: "secret", Dedupe: dto.DedupeScopeUser}, 1)
+
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.IsPasswordProtected())
+	suite.queryRepo.AssertNotCalled(suite.T(), "FindActiveByLongUrlHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_ExpiryAndFallbackSkipDedupe() {
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	for _, req := range []dto.CreateShortUrlRequest{
+		{LongUrl: "https://example.com", TTL: 3600, Dedupe: dto.DedupeScopeUser},
+		{LongUrl: "https://example.com", FallbackUrl: "https://example.com/ended", Dedupe: dto.DedupeScopeUser},
+	} {
+		_, err := suite.service.CreateShortUrl(suite.ctx, &req, 1)
+		suite.Require().NoError(err)
+	}
+	suite.queryRepo.AssertNotCalled(suite.T(), "FindActiveByLongUrlHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_UtmTemplateWithOverrides() {
+	template := &entities.UtmTemplate{ID: 3, UserID: 1, Name: "newsletter", Utm: entities.UtmParams{Source: "newsletter", Medium: "email", Campaign: "spring"}}
+	suite.utmRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(template, nil)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{
+		LongUrl:       "https://example.com",
+		UtmTemplateID: 3,
+		Utm:           &dto.UtmParams{Campaign: " summer ", Content: "header"},
+		Dedupe:        dto.DedupeScopeUser,
+	}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), entities.UtmParams{Source: "newsletter", Medium: "email", Campaign: "summer", Content: "header"}, result.Utm)
+	assert.Equal(suite.T(), "https://example.com", result.LongUrl)
+	suite.queryRepo.AssertNotCalled(suite.T(), "FindActiveByLongUrlHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_UnknownUtmTemplate() {
+	suite.utmRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", UtmTemplateID: 3}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrUtmTemplateNotFound)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_CustomDomain() {
+	now := time.Now()
+	domain := &entities.CustomDomain{ID: 4, Host: "go.example.com", VerifiedAt: &now}
+	suite.domainRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(4), uint(1)).Return(domain, nil)
+	suite.queryRepo.EXPECT().ExistsByShortCode(suite.ctx, uint(4), "promo").Return(false, nil)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil)
+
+	result, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", Alias: "promo", DomainID: 4, Dedupe: dto.DedupeScopeUser}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), uint(4), result.DomainID)
+	assert.Equal(suite.T(), domain, result.Domain)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_UnverifiedCustomDomain() {
+	suite.domainRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(4), uint(1)).Return(&entities.CustomDomain{ID: 4, Host: "go.example.com"}, nil)
+
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", DomainID: 4}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrCustomDomainNotVerified)
+
+	suite.domainRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(5), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err = suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", DomainID: 5}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrCustomDomainNotFound)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_EmptyUtmRemovesValues() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, Utm: entities.UtmParams{Source: "newsletter"}}
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, shortUrl).Return(nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:abc123").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:abc123").Return(nil)
+
+	result, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{Utm: &dto.UtmParams{}}, 1)
+
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.Utm.IsEmpty())
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_InvalidMaxClicks() {
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", MaxClicks: -1}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidMaxClicks)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_InvalidExpiry() {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", ExpireAt: &past}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidExpiry)
+
+	_, err = suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", TTL: -5}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidExpiry)
+
+	_, err = suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", TTL: math.MaxInt64}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidExpiry)
+
+	_, err = suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", ExpireAt: &future, TTL: 60}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrConflictingExpiry)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_InvalidFallbackUrl() {
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", FallbackUrl: "javascript:alert(1)"}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidFallbackUrl)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestBulkCreateShortUrls_PartialSuccess() {
+	reqs := []dto.CreateShortUrlRequest{
+		{LongUrl: "https://example.com/a", Alias: "taken"},
+		{LongUrl: "https://example.com/b", Alias: "fresh"},
+		{LongUrl: "https://example.com/c", Alias: "fresh"},
+		{LongUrl: ""},
+		{LongUrl: "https://example.com/e", TTL: 60},
+		{LongUrl: "https://example.com/f", DomainID: 4},
+	}
+
+	suite.queryRepo.EXPECT().FindExistingShortCodes(suite.ctx, uint(0), []string{"taken", "fresh", "fresh"}).Return([]string{"taken"}, nil)
+	suite.queryRepo.EXPECT().FindExistingShortCodes(suite.ctx, uint(0), mock.MatchedBy(func(codes []string) bool { return len(codes) == 1 })).Return([]string{}, nil)
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(nil).Twice()
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil).Twice()
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil).Twice()
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil).Twice()
+
+	result, err := suite.service.BulkCreateShortUrls(suite.ctx, reqs, 1, false)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 2, result.Created)
+	assert.Equal(suite.T(), 4, result.Failed)
+	assert.Equal(suite.T(), service.ErrAliasTaken.Error(), result.Results[0].Error)
+	assert.Equal(suite.T(), "fresh", result.Results[1].ShortCode)
+	assert.Equal(suite.T(), service.ErrAliasTaken.Error(), result.Results[2].Error)
+	assert.Equal(suite.T(), service.ErrLongUrlRequired.Error(), result.Results[3].Error)
+	assert.Len(suite.T(), result.Results[4].ShortCode, 8)
+	assert.NotNil(suite.T(), result.Results[4].ExpireAt)
+	assert.Equal(suite.T(), service.ErrCustomDomainInBulk.Error(), result.Results[5].Error)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestBulkCreateShortUrls_AtomicAbortsOnInvalidRow() {
+	reqs := []dto.CreateShortUrlRequest{
+		{LongUrl: "https://example.com/a"},
+		{LongUrl: "https://example.com/b", Alias: "a!"},
+		{LongUrl: "javascript:alert(1)"},
+	}
+
+	suite.queryRepo.EXPECT().FindExistingShortCodes(suite.ctx, uint(0), []string{"a!"}).Return([]string{}, nil)
+
+	result, err := suite.service.BulkCreateShortUrls(suite.ctx, reqs, 1, true)
+
+	assert.ErrorIs(suite.T(), err, service.ErrBulkCreateAborted)
+	assert.Equal(suite.T(), 0, result.Created)
+	assert.Equal(suite.T(), service.ErrInvalidAlias.Error(), result.Results[1].Error)
+	assert.Equal(suite.T(), service.ErrInvalidLongUrl.Error(), result.Results[2].Error)
+	suite.commandRepo.AssertNotCalled(suite.T(), "SaveAll", mock.Anything, mock.Anything)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestBulkCreateShortUrls_AtomicSavesInOneCall() {
+	reqs := []dto.CreateShortUrlRequest{
+		{LongUrl: "https://example.com/a"},
+		{LongUrl: "https://example.com/b"},
+	}
+
+	suite.queryRepo.EXPECT().FindExistingShortCodes(suite.ctx, uint(0), []string(nil)).Return([]string{}, nil)
+	suite.queryRepo.EXPECT().FindExistingShortCodes(suite.ctx, uint(0), mock.MatchedBy(func(codes []string) bool { return len(codes) == 2 })).Return([]string{}, nil)
+	suite.commandRepo.EXPECT().SaveAll(suite.ctx, mock.MatchedBy(func(shortUrls []*entities.ShortUrl) bool { return len(shortUrls) == 2 })).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil).Twice()
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil).Twice()
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil).Twice()
+
+	result, err := suite.service.BulkCreateShortUrls(suite.ctx, reqs, 1, true)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 2, result.Created)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestBulkCreateShortUrls_RegeneratesTakenCodes() {
+	generator := servicemocks.NewMockShortCodeGenerator(suite.T())
+	generator.EXPECT().Generate(suite.ctx).Return("same0001", nil).Twice()
+	generator.EXPECT().Generate(suite.ctx).Return("next0001", nil).Once()
+	svc := NewShortUrlService(suite.commandRepo, suite.queryRepo, suite.redisRepo, suite.clickRepo, suite.safety, generator, suite.utmRepo, suite.countries, suite.domainRepo, nil, dto.LinkCacheConfig{})
+	reqs := []dto.CreateShortUrlRequest{
+		{LongUrl: "https://example.com/a"},
+		{LongUrl: "https://example.com/b"},
+	}
+
+	suite.queryRepo.EXPECT().FindExistingShortCodes(suite.ctx, uint(0), []string(nil)).Return([]string{}, nil)
+	suite.queryRepo.EXPECT().FindExistingShortCodes(suite.ctx, uint(0), []string{"same0001", "same0001"}).Return([]string{}, nil)
+	suite.queryRepo.EXPECT().FindExistingShortCodes(suite.ctx, uint(0), []string{"next0001"}).Return([]string{}, nil)
+	suite.commandRepo.EXPECT().SaveAll(suite.ctx, mock.Anything).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, mock.AnythingOfType("*entities.ShortUrl")).Return(dto.UrlSafetyVerdict{Safe: true}, nil).Twice()
+	suite.redisRepo.EXPECT().Delete(suite.ctx, mock.AnythingOfType("string")).Return(nil).Twice()
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, mock.AnythingOfType("string")).Return(nil).Twice()
+
+	result, err := svc.BulkCreateShortUrls(suite.ctx, reqs, 1, true)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "same0001", result.Results[0].ShortCode)
+	assert.Equal(suite.T(), "next0001", result.Results[1].ShortCode)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestBulkCreateShortUrls_InvalidSize() {
+	_, err := suite.service.BulkCreateShortUrls(suite.ctx, nil, 1, false)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidBulkSize)
+
+	_, err = suite.service.BulkCreateShortUrls(suite.ctx, make([]dto.CreateShortUrlRequest, maxBulkCreateRows+1), 1, false)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidBulkSize)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_Expired() {
+	expiredAt := time.Now().Add(-time.Minute)
+	shortUrl := &entities.ShortUrl{ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, ExpireAt: &expiredAt}
+
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:abc123").Return("", assert.AnError)
+	suite.expectReloadLock("lock:short_url:abc123")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), "abc123").Return(shortUrl, nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "", "abc123")
+
+	assert.ErrorIs(suite.T(), err, service.ErrShortUrlExpired)
+	suite.redisRepo.AssertNotCalled(suite.T(), "SetIfEqual", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(suite.T(), shortUrl, result)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_FlaggedUnsafe() {
+	shortUrl := &entities.ShortUrl{
+		ID:        3,
+		ShortCode: "abc123",
+		LongUrl:   "http://192.0.2.1/login",
+		IsActive:  true,
+		UrlSafety: &entities.UrlSafety{ShortUrlID: 3, IsSafe: false, Checker: "suspicious_pattern", Reason: "host is an IP address literal"},
+	}
+
+	suite.redisRepo.EXPECT().Get(suite.ctx, "short_url:abc123").Return(suite.cachedRecord(shortUrl), nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "", "abc123")
+
+	assert.ErrorIs(suite.T(), err, service.ErrShortUrlUnsafe)
+	assert.Equal(suite.T(), shortUrl.UrlSafety.Reason, result.UrlSafety.Reason)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_ClickLimitReached() {
+	shortUrl := &entities.ShortUrl{ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, MaxClicks: helper.Int64Ptr(1), RemainingClicks: helper.Int64Ptr(0)}
+
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:abc123").Return("", assert.AnError)
+	suite.expectReloadLock("lock:short_url:abc123")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), "abc123").Return(shortUrl, nil)
+	suite.redisRepo.EXPECT().SetIfEqual(mock.Anything, "short_url:abc123", mock.AnythingOfType("string"), mock.AnythingOfType("string"), shortUrlCacheTTL).Return(true, nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "", "abc123")
+
+	assert.ErrorIs(suite.T(), err, service.ErrShortUrlClickLimitReached)
+	assert.Equal(suite.T(), shortUrl, result)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestConsumeClick() {
+	unlimited := &entities.ShortUrl{ID: 1, ShortCode: "free0001"}
+	assert.NoError(suite.T(), suite.service.ConsumeClick(suite.ctx, unlimited))
+
+	limited := &entities.ShortUrl{ID: 2, ShortCode: "once0001", MaxClicks: helper.Int64Ptr(1), RemainingClicks: helper.Int64Ptr(1)}
+	suite.commandRepo.EXPECT().ConsumeClick(suite.ctx, uint(2)).Return(true, nil).Once()
+	assert.NoError(suite.T(), suite.service.ConsumeClick(suite.ctx, limited))
+
+	suite.commandRepo.EXPECT().ConsumeClick(suite.ctx, uint(2)).Return(false, nil).Once()
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:once0001").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:once0001").Return(nil)
+	assert.ErrorIs(suite.T(), suite.service.ConsumeClick(suite.ctx, limited), service.ErrShortUrlClickLimitReached)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_NotAvailableYet() {
+	launch := time.Now().Add(time.Hour)
+	shortUrl := &entities.ShortUrl{ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, ActiveFrom: &launch}
+
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:abc123").Return("", assert.AnError)
+	suite.expectReloadLock("lock:short_url:abc123")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), "abc123").Return(shortUrl, nil).Once()
+	suite.redisRepo.EXPECT().SetIfEqual(mock.Anything, "short_url:abc123", mock.AnythingOfType("string"), mock.AnythingOfType("string"), shortUrlCacheTTL).Return(true, nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "", "abc123")
+
+	assert.ErrorIs(suite.T(), err, service.ErrShortUrlNotAvailable)
+	assert.Equal(suite.T(), shortUrl, result)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestCreateShortUrl_ActiveFromAfterExpiry() {
+	activeFrom := time.Now().Add(2 * time.Hour)
+
+	_, err := suite.service.CreateShortUrl(suite.ctx, &dto.CreateShortUrlRequest{LongUrl: "https://example.com", TTL: 3600, ActiveFrom: &activeFrom}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidActiveFrom)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_PasswordBeforeUnsafe() {
+	shortUrl := &entities.ShortUrl{
+		ShortCode:    "abc123",
+		LongUrl:      "http://192.0.2.1/login",
+		IsActive:     true,
+		PasswordHash: "$2a$10$hash",
+		UrlSafety:    &entities.UrlSafety{IsSafe: false},
+	}
+
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:abc123").Return("", assert.AnError)
+	suite.expectReloadLock("lock:short_url:abc123")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), "abc123").Return(shortUrl, nil)
+	suite.redisRepo.EXPECT().SetIfEqual(mock.Anything, "short_url:abc123", mock.AnythingOfType("string"), mock.AnythingOfType("string"), shortUrlCacheTTL).Return(true, nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "", "abc123")
+
+	assert.ErrorIs(suite.T(), err, service.ErrShortUrlPasswordRequired)
+	assert.Equal(suite.T(), shortUrl, result)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_CacheTTLCappedByExpiry() {
+	expireAt := time.Now().Add(10 * time.Minute)
+	shortUrl := &entities.ShortUrl{ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, ExpireAt: &expireAt}
+
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:abc123").Return("", assert.AnError)
+	suite.expectReloadLock("lock:short_url:abc123")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), "abc123").Return(shortUrl, nil)
+	suite.redisRepo.EXPECT().
+		SetIfEqual(mock.Anything, "short_url:abc123", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.MatchedBy(func(ttl time.Duration) bool {
+			return ttl > 0 && ttl <= 10*time.Minute
+		})).
+		Return(true, nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "", "abc123")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shortUrl, result)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_ResolvesCustomDomain() {
+	shortUrl := &entities.ShortUrl{DomainID: 4, ShortCode: "promo", LongUrl: "https://example.com", IsActive: true}
+
+	suite.redisRepo.EXPECT().Get(suite.ctx, "custom_domain:go.example.com").Return("", assert.AnError)
+	suite.domainRepo.EXPECT().FindVerifiedByHost(suite.ctx, "go.example.com").Return(&entities.CustomDomain{ID: 4, Host: "go.example.com"}, nil)
+	suite.redisRepo.EXPECT().Set(suite.ctx, "custom_domain:go.example.com", "4", customDomainCacheTTL).Return(nil)
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:4:promo").Return("", assert.AnError)
+	suite.expectReloadLock("lock:short_url:4:promo")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(4), "promo").Return(shortUrl, nil)
+	suite.redisRepo.EXPECT().SetIfEqual(mock.Anything, "short_url:4:promo", mock.AnythingOfType("string"), mock.Anything, mock.Anything).Return(true, nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "Go.Example.com:443", "promo")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shortUrl, result)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_UnknownHostUsesOwnDomain() {
+	shortUrl := &entities.ShortUrl{ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}
+
+	suite.redisRepo.EXPECT().Get(suite.ctx, "custom_domain:sho.rt").Return("", assert.AnError)
+	suite.domainRepo.EXPECT().FindVerifiedByHost(suite.ctx, "sho.rt").Return(nil, gorm.ErrRecordNotFound)
+	suite.redisRepo.EXPECT().Set(suite.ctx, "custom_domain:sho.rt", "0", missingCacheTTL).Return(nil)
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:abc123").Return("", assert.AnError)
+	suite.expectReloadLock("lock:short_url:abc123")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), "abc123").Return(shortUrl, nil)
+	suite.redisRepo.EXPECT().SetIfEqual(mock.Anything, "short_url:abc123", mock.AnythingOfType("string"), mock.Anything, mock.Anything).Return(true, nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "sho.rt", "abc123")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), shortUrl, result)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_ServedFromCache() {
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	expireAt := createdAt.Add(30 * 24 * time.Hour)
+	fallbackUrl := "https://example.com/ended"
+	shortUrl := &entities.ShortUrl{
+		ID:                 9,
+		UserID:             2,
+		DomainID:           4,
+		ShortCode:          "promo",
+		LongUrl:            "https://example.com",
+		IsActive:           true,
+		ExpireAt:           &expireAt,
+		FallbackUrl:        &fallbackUrl,
+		PasswordHash:       "$2a$10$hash",
+		ForwardQuery:       true,
+		Utm:                entities.UtmParams{Source: "newsletter"},
+		TargetingRules:     []entities.TargetingRule{{OS: []string{entities.TargetOSIOS}, Destination: "https://apps.apple.com/app/id1"}},
+		Destinations:       []entities.ShortUrlDestination{{ID: 1, ShortUrlID: 9, Url: "https://example.com/a", Weight: 70}, {ID: 2, ShortUrlID: 9, Url: "https://example.com/b", Weight: 30}},
+		StickyDestinations: true,
+		Preview:            true,
+		RedirectType:       entities.RedirectTypePermanentRedirect,
+		UrlSafety:          &entities.UrlSafety{ShortUrlID: 9, IsSafe: true, CheckedAt: createdAt},
+		CreatedAt:          createdAt,
+	}
+
+	suite.redisRepo.EXPECT().Get(suite.ctx, "custom_domain:go.example.com").Return("4", nil)
+	suite.redisRepo.EXPECT().Get(suite.ctx, "short_url:4:promo").Return(suite.cachedRecord(shortUrl), nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "go.example.com", "promo")
+
+	assert.ErrorIs(suite.T(), err, service.ErrShortUrlPasswordRequired)
+	assert.Equal(suite.T(), shortUrl, result)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_CachesMissingCode() {
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:nope").Return("", assert.AnError)
+	suite.expectReloadLock("lock:short_url:nope")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), "nope").Return(nil, gorm.ErrRecordNotFound)
+	suite.redisRepo.EXPECT().SetIfEqual(mock.Anything, "short_url:nope", mock.AnythingOfType("string"), missingCacheEntry, missingCacheTTL).Return(true, nil)
+
+	_, err := suite.service.GetByShortCodePublic(suite.ctx, "", "nope")
+
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_CachedMissingCode() {
+	suite.redisRepo.EXPECT().Get(suite.ctx, "short_url:nope").Return(missingCacheEntry, nil)
+
+	_, err := suite.service.GetByShortCodePublic(suite.ctx, "", "nope")
+
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestGetByShortCodePublic_IgnoresLegacyEntry() {
+	shortUrl := &entities.ShortUrl{ID: 3, ShortCode: "abc123", LongUrl: "https://example.com/new", IsActive: true}
+
+	suite.redisRepo.EXPECT().Get(mock.Anything, "short_url:abc123").Return(`{"long_url":"https://example.com/old"}`, nil)
+	suite.expectReloadLock("lock:short_url:abc123")
+	suite.queryRepo.EXPECT().FindByShortCode(mock.Anything, uint(0), "abc123").Return(shortUrl, nil)
+	suite.redisRepo.EXPECT().SetIfEqual(mock.Anything, "short_url:abc123", mock.AnythingOfType("string"), mock.AnythingOfType("string"), shortUrlCacheTTL).Return(true, nil)
+
+	result, err := suite.service.GetByShortCodePublic(suite.ctx, "", "abc123")
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "https://example.com/new", result.LongUrl)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_InvalidatesCache() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}
+	newLongUrl := "https://example.com/new"
+	inactive := false
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, shortUrl).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, shortUrl).Return(dto.UrlSafetyVerdict{Safe: true}, nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:abc123").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:abc123").Return(nil)
+
+	result, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{LongUrl: &newLongUrl, IsActive: &inactive, TTL: 60}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), newLongUrl, result.LongUrl)
+	assert.False(suite.T(), result.IsActive)
+	assert.NotNil(suite.T(), result.ExpireAt)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_RemovesPassword() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, PasswordHash: "$2a$10$hash"}
+	noPassword := ""
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, shortUrl).Return(nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:abc123").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:abc123").Return(nil)
+
+	result, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{Password: &noPassword}, 1)
+
+	suite.Require().NoError(err)
+	assert.False(suite.T(), result.IsPasswordProtected())
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_TogglesForwarding() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, ForwardPath: true}
+	forwardQuery, forwardPath := true, false
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, shortUrl).Return(nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:abc123").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:abc123").Return(nil)
+
+	result, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{ForwardQuery: &forwardQuery, ForwardPath: &forwardPath}, 1)
+
+	suite.Require().NoError(err)
+	assert.True(suite.T(), result.ForwardQuery)
+	assert.False(suite.T(), result.ForwardPath)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_TargetingRulesRescanDestinations() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}
+	rules := []dto.TargetingRule{{OS: []string{"android"}, Destination: "https://play.example.com"}}
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, shortUrl).Return(nil)
+	suite.safety.EXPECT().Scan(suite.ctx, shortUrl).Return(dto.UrlSafetyVerdict{Safe: true}, nil).Once()
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:abc123").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:abc123").Return(nil)
+
+	result, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{TargetingRules: &rules}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), []string{"https://example.com", "https://play.example.com"}, result.DestinationUrls())
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_ReplacesDestinations() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}
+	destinations := []dto.ShortUrlDestination{{Url: "https://example.com/a", Weight: 50}, {Url: "https://example.com/b", Weight: 50}}
+	stored := []entities.ShortUrlDestination{
+		{ID: 1, ShortUrlID: 7, Url: "https://example.com/a", Weight: 50, Position: 0},
+		{ID: 2, ShortUrlID: 7, Url: "https://example.com/b", Weight: 50, Position: 1},
+	}
+	sticky := true
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, shortUrl).Return(nil)
+	suite.commandRepo.EXPECT().ReplaceDestinations(suite.ctx, uint(7), []entities.ShortUrlDestination{
+		{Url: "https://example.com/a", Weight: 50, Position: 0},
+		{Url: "https://example.com/b", Weight: 50, Position: 1},
+	}).Return(stored, nil)
+	suite.safety.EXPECT().Scan(suite.ctx, shortUrl).Return(dto.UrlSafetyVerdict{Safe: true}, nil).Once()
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:abc123").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:abc123").Return(nil)
+
+	result, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{Destinations: &destinations, StickyDestinations: &sticky}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), stored, result.Destinations)
+	assert.True(suite.T(), result.StickyDestinations)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_InvalidDestinations() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}
+	destinations := []dto.ShortUrlDestination{{Url: "https://example.com/a", Weight: 50}}
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+
+	_, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{Destinations: &destinations}, 1)
+
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidDestinations)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_ResetsClickBudget() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true, MaxClicks: helper.Int64Ptr(1), RemainingClicks: helper.Int64Ptr(0)}
+	maxClicks := int64(10)
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, shortUrl).Return(nil)
+	suite.commandRepo.EXPECT().ResetClickBudget(suite.ctx, uint(7), &maxClicks).Return(nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:abc123").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:abc123").Return(nil)
+
+	result, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{MaxClicks: &maxClicks}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), int64(10), *result.RemainingClicks)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestUpdateShortUrl_NotOwner() {
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(2)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := suite.service.UpdateShortUrl(suite.ctx, 0, "abc123", &dto.UpdateShortUrlRequest{}, 2)
+
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestDeleteShortUrl_InvalidatesCache() {
+	shortUrl := &entities.ShortUrl{ID: 7, UserID: 1, ShortCode: "abc123", LongUrl: "https://example.com", IsActive: true}
+
+	suite.queryRepo.EXPECT().FindByShortCodeAndUserIDAnyStatus(suite.ctx, uint(0), "abc123", uint(1)).Return(shortUrl, nil)
+	suite.commandRepo.EXPECT().Delete(suite.ctx, uint(7)).Return(nil)
+	suite.redisRepo.EXPECT().Delete(suite.ctx, "short_url:abc123").Return(nil)
+	suite.redisRepo.EXPECT().Publish(suite.ctx, shortUrlCacheChannel, "short_url:abc123").Return(nil)
+
+	err := suite.service.DeleteShortUrl(suite.ctx, 0, "abc123", 1)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ShortUrlServiceTestSuite) TestRecordClick_CountedOffTheCallersPath() {
+	counted := make(chan uint, 2)
+	suite.clickRepo.EXPECT().Increment(mock.Anything, mock.AnythingOfType("uint"), mock.AnythingOfType("time.Time")).
+		Run(func(ctx context.Context, shortUrlID uint, at time.Time) { counted <- shortUrlID }).
+		Return(nil)
+
+	suite.service.RecordClick(7)
+	suite.service.RecordClick(8)
+
+	// Queued clicks are still counted when the worker is stopped.
+	ctx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+	suite.service.CountClicks(ctx)
+
+	assert.ElementsMatch(suite.T(), []uint{7, 8}, []uint{<-counted, <-counted})
+}
+
+func TestShortUrlServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ShortUrlServiceTestSuite))
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSplitDestinations_Validates(t *testing.T) {
+	destinations, err := toSplitDestinations([]dto.ShortUrlDestination{
+		{Url: " https://example.com/a ", Weight: 70},
+		{Url: "https://example.com/b", Weight: 30},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.ShortUrlDestination{
+		{Url: "https://example.com/a", Weight: 70, Position: 0},
+		{Url: "https://example.com/b", Weight: 30, Position: 1},
+	}, destinations)
+
+	destinations, err = toSplitDestinations(nil)
+	require.NoError(t, err)
+	assert.Nil(t, destinations)
+
+	invalid := [][]dto.ShortUrlDestination{
+		{{Url: "https://example.com/a", Weight: 1}},
+		{{Url: "https://example.com/a", Weight: 1}, {Url: "https://example.com/a", Weight: 1}},
+		{{Url: "https://example.com/a", Weight: 0}, {Url: "https://example.com/b", Weight: 1}},
+		{{Url: "https://example.com/a", Weight: maxDestinationWeight + 1}, {Url: "https://example.com/b", Weight: 1}},
+		{{Url: "ftp://example.com/a", Weight: 1}, {Url: "https://example.com/b", Weight: 1}},
+	}
+	for _, reqs := range invalid {
+		_, err := toSplitDestinations(reqs)
+		assert.ErrorIs(t, err, service.ErrInvalidDestinations, "%+v", reqs)
+	}
+
+	tooMany := make([]dto.ShortUrlDestination, maxSplitDestinations+1)
+	for i := range tooMany {
+		tooMany[i] = dto.ShortUrlDestination{Url: "https://example.com/" + string(rune('a'+i)), Weight: 1}
+	}
+	_, err = toSplitDestinations(tooMany)
+	assert.ErrorIs(t, err, service.ErrInvalidDestinations)
+}
+
+func TestPickDestination_FollowsWeights(t *testing.T) {
+	shortUrl := &entities.ShortUrl{Destinations: []entities.ShortUrlDestination{
+		{ID: 1, Weight: 3},
+		{ID: 2, Weight: 1},
+	}}
+
+	picked := make(map[uint]int)
+	for roll := 0; roll < shortUrl.TotalDestinationWeight(); roll++ {
+		picked[shortUrl.PickDestination(roll).ID]++
+	}
+	assert.Equal(t, map[uint]int{1: 3, 2: 1}, picked)
+	assert.Nil(t, shortUrl.PickDestination(-1))
+	assert.Nil(t, shortUrl.PickDestination(4))
+}
+
+func TestSelectDestination_Split(t *testing.T) {
+	svc := NewShortUrlService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, dto.LinkCacheConfig{})
+	shortUrl := &entities.ShortUrl{
+		LongUrl: "https://example.com",
+		Destinations: []entities.ShortUrlDestination{
+			{ID: 1, Url: "https://example.com/a", Weight: 1},
+			{ID: 2, Url: "https://example.com/b", Weight: 1},
+		},
+		TargetingRules: []entities.TargetingRule{{OS: []string{entities.TargetOSIOS}, Destination: "https://apps.example.com"}},
+	}
+	ctx := context.Background()
+
+	iPhone := dto.RedirectVisitor{UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"}
+	assert.Equal(t, dto.RedirectDestination{Url: "https://apps.example.com"}, svc.SelectDestination(ctx, shortUrl, iPhone))
+
+	served := make(map[uint]int)
+	for range 200 {
+		destination := svc.SelectDestination(ctx, shortUrl, dto.RedirectVisitor{})
+		require.NotNil(t, shortUrl.FindDestination(destination.DestinationID))
+		assert.Equal(t, shortUrl.FindDestination(destination.DestinationID).Url, destination.Url)
+		served[destination.DestinationID]++
+	}
+	assert.Len(t, served, 2, "both variants are served")
+
+	// A remembered destination only counts for sticky links that still have it.
+	returning := dto.RedirectVisitor{DestinationID: 2}
+	shortUrl.StickyDestinations = true
+	for range 20 {
+		assert.Equal(t, uint(2), svc.SelectDestination(ctx, shortUrl, returning).DestinationID)
+	}
+	shortUrl.Destinations = shortUrl.Destinations[:1]
+	assert.Equal(t, uint(1), svc.SelectDestination(ctx, shortUrl, returning).DestinationID)
+
+	shortUrl.Destinations = nil
+	assert.Equal(t, dto.RedirectDestination{Url: "https://example.com"}, svc.SelectDestination(ctx, shortUrl, returning))
+}
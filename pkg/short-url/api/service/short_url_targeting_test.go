@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTargetingRules_Validates(t *testing.T) {
+	rules, err := toTargetingRules([]dto.TargetingRule{
+		{OS: []string{"iOS"}, Languages: []string{"pt-BR"}, Countries: []string{"br"}, Destination: " https://example.com/br "},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.TargetingRule{
+		{OS: []string{"ios"}, Languages: []string{"pt-BR"}, Countries: []string{"BR"}, Destination: "https://example.com/br"},
+	}, rules)
+
+	rules, err = toTargetingRules(nil)
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+
+	invalid := []dto.TargetingRule{
+		{Destination: "https://example.com"},
+		{OS: []string{"windows"}, Destination: "https://example.com"},
+		{Languages: []string{"de_AT"}, Destination: "https://example.com"},
+		{Languages: []string{""}, Destination: "https://example.com"},
+		{Countries: []string{"DEU"}, Destination: "https://example.com"},
+		{Countries: []string{"D1"}, Destination: "https://example.com"},
+		{OS: []string{"ios"}, Destination: "javascript:alert(1)"},
+		{OS: []string{"ios"}, Destination: "/relative"},
+	}
+	for _, req := range invalid {
+		_, err := toTargetingRules([]dto.TargetingRule{req})
+		assert.ErrorIs(t, err, service.ErrInvalidTargetingRules, "%+v", req)
+	}
+
+	tooMany := make([]dto.TargetingRule, maxTargetingRules+1)
+	for i := range tooMany {
+		tooMany[i] = dto.TargetingRule{OS: []string{"ios"}, Destination: "https://example.com"}
+	}
+	_, err = toTargetingRules(tooMany)
+	assert.ErrorIs(t, err, service.ErrInvalidTargetingRules)
+}
+
+func TestSelectDestination_FirstMatchingRuleWins(t *testing.T) {
+	countries, err := NewCidrCountryResolver(map[string]string{"192.0.2.0/24": "DE", "2001:db8::/32": "AT"})
+	require.NoError(t, err)
+	svc := NewShortUrlService(nil, nil, nil, nil, nil, nil, nil, countries, nil, nil, dto.LinkCacheConfig{})
+
+	shortUrl := &entities.ShortUrl{
+		LongUrl: "https://example.com",
+		TargetingRules: []entities.TargetingRule{
+			{OS: []string{entities.TargetOSIOS}, Countries: []string{"AT"}, Destination: "https://example.at/ios"},
+			{OS: []string{entities.TargetOSIOS, entities.TargetOSAndroid}, Destination: "https://example.com/app"},
+			{Languages: []string{"de"}, Destination: "https://example.de"},
+			{Countries: []string{"DE", "AT"}, Destination: "https://example.com/dach"},
+			{OS: []string{entities.TargetOSDesktop}, Languages: []string{"pt-BR"}, Destination: "https://example.com.br"},
+		},
+	}
+
+	iPhone := "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"
+	android := "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/120.0 Mobile Safari/537.36"
+	windows := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36"
+
+	cases := []struct {
+		name    string
+		visitor dto.RedirectVisitor
+		want    string
+	}{
+		{"ios in austria", dto.RedirectVisitor{UserAgent: iPhone, IP: "2001:db8::1"}, "https://example.at/ios"},
+		{"ios elsewhere", dto.RedirectVisitor{UserAgent: iPhone, IP: "198.51.100.1"}, "https://example.com/app"},
+		{"android", dto.RedirectVisitor{UserAgent: android, AcceptLanguage: "de-DE"}, "https://example.com/app"},
+		{"language prefix", dto.RedirectVisitor{UserAgent: windows, AcceptLanguage: "en;q=0.5, de-CH"}, "https://example.de"},
+		{"language is not a prefix of another", dto.RedirectVisitor{UserAgent: windows, AcceptLanguage: "dea"}, "https://example.com"},
+		{"country", dto.RedirectVisitor{UserAgent: windows, IP: "192.0.2.44"}, "https://example.com/dach"},
+		{"ipv4 mapped address", dto.RedirectVisitor{IP: "::ffff:192.0.2.44"}, "https://example.com/dach"},
+		{"desktop and language", dto.RedirectVisitor{UserAgent: windows, AcceptLanguage: "pt-br"}, "https://example.com.br"},
+		{"exact language does not match others", dto.RedirectVisitor{UserAgent: windows, AcceptLanguage: "pt-PT"}, "https://example.com"},
+		{"bot matches no os", dto.RedirectVisitor{UserAgent: "curl/8.0", AcceptLanguage: "pt-BR"}, "https://example.com"},
+		{"nothing known", dto.RedirectVisitor{}, "https://example.com"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, svc.SelectDestination(context.Background(), shortUrl, tc.visitor).Url)
+		})
+	}
+}
+
+func TestSelectDestination_WithoutCountryResolver(t *testing.T) {
+	svc := NewShortUrlService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, dto.LinkCacheConfig{})
+	shortUrl := &entities.ShortUrl{
+		LongUrl:        "https://example.com",
+		TargetingRules: []entities.TargetingRule{{Countries: []string{"DE"}, Destination: "https://example.de"}},
+	}
+
+	assert.Equal(t, "https://example.com", svc.SelectDestination(context.Background(), shortUrl, dto.RedirectVisitor{IP: "192.0.2.1"}).Url)
+}
+
+func TestCidrCountryResolver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "countries.csv")
+	content := "network,country_iso_code\n# test networks\n\n192.0.2.0/25,de\n192.0.2.128/25,AT\n2001:db8::/32,CH\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	resolver, err := NewCidrCountryResolverFromFile(path)
+	require.NoError(t, err)
+
+	cases := map[string]string{
+		"192.0.2.0":        "DE",
+		"192.0.2.127":      "DE",
+		"192.0.2.128":      "AT",
+		"192.0.2.255":      "AT",
+		"192.0.3.0":        "",
+		"192.0.1.255":      "",
+		"::ffff:192.0.2.1": "DE",
+		"2001:db8:1::5":    "CH",
+		"2001:db9::":       "",
+		"not an ip":        "",
+	}
+	for ip, want := range cases {
+		assert.Equal(t, want, resolver.ResolveCountry(ip), ip)
+	}
+
+	_, err = NewCidrCountryResolver(map[string]string{"192.0.2.0/24": "DE", "192.0.2.128/25": "AT"})
+	assert.Error(t, err, "overlapping networks")
+
+	require.NoError(t, os.WriteFile(path, []byte("192.0.2.0/24,DE\nbogus,AT\n"), 0o600))
+	_, err = NewCidrCountryResolverFromFile(path)
+	assert.ErrorContains(t, err, "line 2")
+
+	resolver, err = NewCountryResolver("")
+	require.NoError(t, err)
+	assert.Nil(t, resolver)
+}
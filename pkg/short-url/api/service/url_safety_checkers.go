@@ -0,0 +1,184 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"short-url/domains/dto"
+	"short-url/domains/service"
+)
+
+const (
+	maxSubdomainLength = 40
+	maxSubdomainLabels = 4
+)
+
+// NewDefaultUrlSafetyCheckers builds the built-in checkers. The scheme
+// allowlist always runs first; the blocklist is skipped when no file is set.
+func NewDefaultUrlSafetyCheckers(blocklistFile string, allowedSchemes string) ([]service.UrlSafetyChecker, error) {
+	checkers := []service.UrlSafetyChecker{
+		NewSchemeAllowlistChecker(strings.Split(allowedSchemes, ",")),
+		NewSuspiciousPatternChecker(),
+	}
+
+	if blocklistFile != "" {
+		blocklist, err := NewBlocklistCheckerFromFile(blocklistFile)
+		if err != nil {
+			return nil, err
+		}
+		checkers = append(checkers, blocklist)
+	}
+
+	return checkers, nil
+}
+
+type schemeAllowlistChecker struct {
+	allowed map[string]struct{}
+}
+
+func NewSchemeAllowlistChecker(schemes []string) service.UrlSafetyChecker {
+	allowed := make(map[string]struct{}, len(schemes))
+	for _, scheme := range schemes {
+		if scheme = strings.ToLower(strings.TrimSpace(scheme)); scheme != "" {
+			allowed[scheme] = struct{}{}
+		}
+	}
+	return &schemeAllowlistChecker{allowed: allowed}
+}
+
+func (c *schemeAllowlistChecker) Name() string {
+	return "scheme_allowlist"
+}
+
+func (c *schemeAllowlistChecker) Check(ctx context.Context, rawUrl string) (dto.UrlSafetyVerdict, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil {
+		return dto.UrlSafetyVerdict{Reason: "URL cannot be parsed"}, nil
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if _, ok := c.allowed[scheme]; !ok {
+		return dto.UrlSafetyVerdict{Reason: fmt.Sprintf("scheme %q is not allowed", scheme)}, nil
+	}
+	return dto.UrlSafetyVerdict{Safe: true}, nil
+}
+
+type suspiciousPatternChecker struct{}
+
+func NewSuspiciousPatternChecker() service.UrlSafetyChecker {
+	return &suspiciousPatternChecker{}
+}
+
+func (c *suspiciousPatternChecker) Name() string {
+	return "suspicious_pattern"
+}
+
+// Check flags hosts that are commonly used to disguise a destination: raw IP
+// addresses, punycode labels that can imitate another domain, embedded
+// credentials and unusually long subdomain chains.
+func (c *suspiciousPatternChecker) Check(ctx context.Context, rawUrl string) (dto.UrlSafetyVerdict, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil {
+		return dto.UrlSafetyVerdict{Reason: "URL cannot be parsed"}, nil
+	}
+
+	if parsed.User != nil {
+		return dto.UrlSafetyVerdict{Reason: "URL embeds credentials before the host"}, nil
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "" {
+		return dto.UrlSafetyVerdict{Safe: true}, nil
+	}
+
+	if net.ParseIP(host) != nil {
+		return dto.UrlSafetyVerdict{Reason: "host is an IP address literal"}, nil
+	}
+
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if strings.HasPrefix(label, "xn--") {
+			return dto.UrlSafetyVerdict{Reason: "host uses a punycode label that may imitate another domain"}, nil
+		}
+	}
+
+	if len(labels) > 2 {
+		subdomain := labels[:len(labels)-2]
+		if len(subdomain) > maxSubdomainLabels || len(strings.Join(subdomain, ".")) > maxSubdomainLength {
+			return dto.UrlSafetyVerdict{Reason: "host has an unusually long subdomain"}, nil
+		}
+	}
+
+	return dto.UrlSafetyVerdict{Safe: true}, nil
+}
+
+type blocklistChecker struct {
+	domains map[string]struct{}
+}
+
+// NewBlocklistCheckerFromFile loads one domain per line. Blank lines and lines
+// starting with '#' are ignored. A listed domain also blocks its subdomains.
+func NewBlocklistCheckerFromFile(path string) (service.UrlSafetyChecker, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open url blocklist: %w", err)
+	}
+	defer file.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read url blocklist: %w", err)
+	}
+
+	return NewBlocklistChecker(domains), nil
+}
+
+func NewBlocklistChecker(domains []string) service.UrlSafetyChecker {
+	set := make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if domain != "" {
+			set[domain] = struct{}{}
+		}
+	}
+	return &blocklistChecker{domains: set}
+}
+
+func (c *blocklistChecker) Name() string {
+	return "domain_blocklist"
+}
+
+func (c *blocklistChecker) Check(ctx context.Context, rawUrl string) (dto.UrlSafetyVerdict, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil {
+		return dto.UrlSafetyVerdict{Reason: "URL cannot be parsed"}, nil
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	for host != "" {
+		if _, ok := c.domains[host]; ok {
+			return dto.UrlSafetyVerdict{Reason: fmt.Sprintf("domain %s is blocklisted", host)}, nil
+		}
+
+		dot := strings.IndexByte(host, '.')
+		if dot < 0 {
+			break
+		}
+		host = host[dot+1:]
+	}
+
+	return dto.UrlSafetyVerdict{Safe: true}, nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+)
+
+const (
+	urlSafetyRecheckBatchSize = 100
+
+	// urlSafetyReasonMaxLength is the size of the reason column. Reasons can
+	// quote the destination's host, which may be longer.
+	urlSafetyReasonMaxLength = 255
+)
+
+type urlSafetyService struct {
+	checkers        []service.UrlSafetyChecker
+	commandRepo     repositories.UrlSafetyCommandRepositoryInterface
+	queryRepo       repositories.UrlSafetyQueryRepositoryInterface
+	cache           *shortUrlCache
+	recheckInterval time.Duration
+}
+
+func NewUrlSafetyService(
+	checkers []service.UrlSafetyChecker,
+	commandRepo repositories.UrlSafetyCommandRepositoryInterface,
+	queryRepo repositories.UrlSafetyQueryRepositoryInterface,
+	redisRepo repositories.RedisRepositoryInterface,
+	recheckInterval time.Duration,
+) service.UrlSafetyServiceInterface {
+	if recheckInterval <= 0 {
+		recheckInterval = 24 * time.Hour
+	}
+	return &urlSafetyService{
+		checkers:        checkers,
+		commandRepo:     commandRepo,
+		queryRepo:       queryRepo,
+		cache:           newShortUrlCache(redisRepo, nil),
+		recheckInterval: recheckInterval,
+	}
+}
+
+// Evaluate runs the checkers in order and returns the first unsafe verdict.
+func (s *urlSafetyService) Evaluate(ctx context.Context, rawUrl string) dto.UrlSafetyVerdict {
+	for _, checker := range s.checkers {
+		verdict, err := checker.Check(ctx, rawUrl)
+		if err != nil {
+			log.Printf("Url safety checker %s failed: %v", checker.Name(), err)
+			continue
+		}
+		if !verdict.Safe {
+			verdict.Checker = checker.Name()
+			return verdict
+		}
+	}
+	return dto.UrlSafetyVerdict{Safe: true}
+}
+
+// Scan evaluates every destination of the link, its long URL, those of its
+// targeting rules, its split destinations and its fallback URL, and stores
+// the first unsafe verdict on it. The cached link is dropped when the verdict
+// changes, so the public redirect sees it.
+func (s *urlSafetyService) Scan(ctx context.Context, shortUrl *entities.ShortUrl) (dto.UrlSafetyVerdict, error) {
+	var verdict dto.UrlSafetyVerdict
+	for _, destination := range shortUrl.DestinationUrls() {
+		verdict = s.Evaluate(ctx, destination)
+		if !verdict.Safe {
+			break
+		}
+	}
+
+	safety := &entities.UrlSafety{
+		ShortUrlID: shortUrl.ID,
+		IsSafe:     verdict.Safe,
+		Checker:    verdict.Checker,
+		Reason:     truncateReason(verdict.Reason),
+		CheckedAt:  time.Now(),
+	}
+	if err := s.commandRepo.Upsert(ctx, safety); err != nil {
+		return verdict, err
+	}
+
+	previous := shortUrl.UrlSafety
+	shortUrl.UrlSafety = safety
+	if previous == nil || previous.IsSafe != safety.IsSafe {
+		s.cache.invalidate(ctx, shortUrl)
+	}
+	return verdict, nil
+}
+
+// truncateReason cuts reason to urlSafetyReasonMaxLength characters.
+func truncateReason(reason string) string {
+	runes := []rune(reason)
+	if len(runes) <= urlSafetyReasonMaxLength {
+		return reason
+	}
+	return string(runes[:urlSafetyReasonMaxLength])
+}
+
+// Recheck rescans every link that was not checked since the run started, so
+// links flagged by an updated blocklist are caught. A link whose verdict
+// cannot be saved is logged and left for the next run.
+func (s *urlSafetyService) Recheck(ctx context.Context) error {
+	// Truncated to the database precision so a fresh verdict never sorts before it.
+	startedAt := time.Now().Truncate(time.Microsecond)
+
+	var afterID uint
+	for {
+		shortUrls, err := s.queryRepo.FindShortUrlsDueForCheck(ctx, startedAt, afterID, urlSafetyRecheckBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(shortUrls) == 0 {
+			return nil
+		}
+		afterID = shortUrls[len(shortUrls)-1].ID
+
+		for i := range shortUrls {
+			verdict, err := s.Scan(ctx, &shortUrls[i])
+			if err != nil {
+				log.Printf("Failed to recheck short url %s: %v", shortUrls[i].ShortCode, err)
+				continue
+			}
+			if !verdict.Safe {
+				log.Printf("Short url %s flagged as unsafe by %s: %s", shortUrls[i].ShortCode, verdict.Checker, verdict.Reason)
+			}
+		}
+	}
+}
+
+// Run rechecks all links on every interval until ctx is cancelled.
+func (s *urlSafetyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.recheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Recheck(ctx); err != nil {
+				log.Printf("Url safety recheck failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories"
+	"short-url/domains/service"
+)
+
+const (
+	maxUtmTemplateNameLength = 100
+	maxUtmValueLength        = 255
+)
+
+type utmTemplateService struct {
+	commandRepo repositories.UtmTemplateCommandRepositoryInterface
+	queryRepo   repositories.UtmTemplateQueryRepositoryInterface
+}
+
+func NewUtmTemplateService(
+	commandRepo repositories.UtmTemplateCommandRepositoryInterface,
+	queryRepo repositories.UtmTemplateQueryRepositoryInterface,
+) service.UtmTemplateServiceInterface {
+	return &utmTemplateService{
+		commandRepo: commandRepo,
+		queryRepo:   queryRepo,
+	}
+}
+
+func (s *utmTemplateService) CreateUtmTemplate(ctx context.Context, req *dto.CreateUtmTemplateRequest, userID uint) (*entities.UtmTemplate, error) {
+	name, err := utmTemplateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	utm, err := toUtmParams(nil, &req.Utm)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &entities.UtmTemplate{
+		UserID:    userID,
+		Name:      name,
+		Utm:       utm,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.commandRepo.Save(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to save utm template: %w", err)
+	}
+	return template, nil
+}
+
+func (s *utmTemplateService) ListUtmTemplates(ctx context.Context, userID uint) ([]entities.UtmTemplate, error) {
+	return s.queryRepo.FindByUserID(ctx, userID)
+}
+
+func (s *utmTemplateService) GetUtmTemplate(ctx context.Context, id uint, userID uint) (*entities.UtmTemplate, error) {
+	return s.queryRepo.FindByIDAndUserID(ctx, id, userID)
+}
+
+func (s *utmTemplateService) UpdateUtmTemplate(ctx context.Context, id uint, req *dto.UpdateUtmTemplateRequest, userID uint) (*entities.UtmTemplate, error) {
+	template, err := s.queryRepo.FindByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		template.Name, err = utmTemplateName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.Utm != nil {
+		template.Utm, err = toUtmParams(nil, req.Utm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	template.UpdatedAt = time.Now()
+
+	if err := s.commandRepo.Update(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to update utm template: %w", err)
+	}
+	return template, nil
+}
+
+func (s *utmTemplateService) DeleteUtmTemplate(ctx context.Context, id uint, userID uint) error {
+	template, err := s.queryRepo.FindByIDAndUserID(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.commandRepo.Delete(ctx, template.ID); err != nil {
+		return fmt.Errorf("failed to delete utm template: %w", err)
+	}
+	return nil
+}
+
+func utmTemplateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxUtmTemplateNameLength {
+		return "", service.ErrInvalidUtmTemplateName
+	}
+	return name, nil
+}
+
+// toUtmParams starts from the values of template, if any, and overrides them
+// with the non-empty values of req.
+func toUtmParams(template *entities.UtmTemplate, req *dto.UtmParams) (entities.UtmParams, error) {
+	var utm entities.UtmParams
+	if template != nil {
+		utm = template.Utm
+	}
+	if req == nil {
+		return utm, nil
+	}
+
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&utm.Source, req.Source},
+		{&utm.Medium, req.Medium},
+		{&utm.Campaign, req.Campaign},
+		{&utm.Term, req.Term},
+		{&utm.Content, req.Content},
+	} {
+		value := strings.TrimSpace(field.value)
+		if len(value) > maxUtmValueLength {
+			return entities.UtmParams{}, service.ErrInvalidUtm
+		}
+		if value != "" {
+			*field.target = value
+		}
+	}
+	return utm, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"short-url/domains/dto"
+	"short-url/domains/entities"
+	"short-url/domains/repositories/mocks"
+	"short-url/domains/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type UtmTemplateServiceTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	commandRepo *mocks.MockUtmTemplateCommandRepositoryInterface
+	queryRepo   *mocks.MockUtmTemplateQueryRepositoryInterface
+	service     service.UtmTemplateServiceInterface
+}
+
+func (suite *UtmTemplateServiceTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.commandRepo = mocks.NewMockUtmTemplateCommandRepositoryInterface(suite.T())
+	suite.queryRepo = mocks.NewMockUtmTemplateQueryRepositoryInterface(suite.T())
+	suite.service = NewUtmTemplateService(suite.commandRepo, suite.queryRepo)
+}
+
+func (suite *UtmTemplateServiceTestSuite) TestCreateUtmTemplate_TrimsValues() {
+	suite.commandRepo.EXPECT().Save(suite.ctx, mock.AnythingOfType("*entities.UtmTemplate")).Return(nil)
+
+	template, err := suite.service.CreateUtmTemplate(suite.ctx, &dto.CreateUtmTemplateRequest{
+		Name: " newsletter ",
+		Utm:  dto.UtmParams{Source: " newsletter", Medium: "email "},
+	}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), uint(1), template.UserID)
+	assert.Equal(suite.T(), "newsletter", template.Name)
+	assert.Equal(suite.T(), entities.UtmParams{Source: "newsletter", Medium: "email"}, template.Utm)
+}
+
+func (suite *UtmTemplateServiceTestSuite) TestCreateUtmTemplate_Invalid() {
+	_, err := suite.service.CreateUtmTemplate(suite.ctx, &dto.CreateUtmTemplateRequest{Name: "  "}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidUtmTemplateName)
+
+	_, err = suite.service.CreateUtmTemplate(suite.ctx, &dto.CreateUtmTemplateRequest{Name: "ads", Utm: dto.UtmParams{Term: strings.Repeat("x", 256)}}, 1)
+	assert.ErrorIs(suite.T(), err, service.ErrInvalidUtm)
+}
+
+func (suite *UtmTemplateServiceTestSuite) TestUpdateUtmTemplate_ReplacesValues() {
+	template := &entities.UtmTemplate{ID: 3, UserID: 1, Name: "newsletter", Utm: entities.UtmParams{Source: "newsletter", Medium: "email"}}
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(1)).Return(template, nil)
+	suite.commandRepo.EXPECT().Update(suite.ctx, template).Return(nil)
+
+	result, err := suite.service.UpdateUtmTemplate(suite.ctx, 3, &dto.UpdateUtmTemplateRequest{Utm: &dto.UtmParams{Campaign: "spring"}}, 1)
+
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "newsletter", result.Name)
+	assert.Equal(suite.T(), entities.UtmParams{Campaign: "spring"}, result.Utm)
+}
+
+func (suite *UtmTemplateServiceTestSuite) TestDeleteUtmTemplate_OtherUser() {
+	suite.queryRepo.EXPECT().FindByIDAndUserID(suite.ctx, uint(3), uint(2)).Return(nil, gorm.ErrRecordNotFound)
+
+	err := suite.service.DeleteUtmTemplate(suite.ctx, 3, 2)
+
+	assert.ErrorIs(suite.T(), err, gorm.ErrRecordNotFound)
+}
+
+func TestUtmTemplateServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(UtmTemplateServiceTestSuite))
+}
@@ -12,6 +12,9 @@ require (
 	github.com/gofiber/fiber/v2 v2.52.9
 	github.com/redis/go-redis/v9 v9.12.1
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/crypto v0.42.0
+	golang.org/x/sync v0.17.0
+	gorm.io/driver/sqlite v1.6.0
 	gorm.io/gorm v1.30.1
 	short-url v0.0.0
 	user-service v0.0.0-00010101000000-000000000000
@@ -35,13 +38,13 @@ require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/mattn/go-sqlite3 v1.14.22 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/rivo/uniseg v0.2.0 // indirect
+	github.com/stretchr/objx v0.5.0 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasthttp v1.51.0 // indirect
 	github.com/valyala/tcplisten v1.0.0 // indirect
-	golang.org/x/crypto v0.42.0 // indirect
-	golang.org/x/sync v0.17.0 // indirect
 	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"log"
+	"net/http"
+	"time"
 
 	"short-url-service/api/controller"
 	"short-url-service/api/repository"
@@ -49,16 +52,87 @@ func main() {
 		log.Fatal("Failed to connect to Redis:", err)
 	}
 
+	location, err := time.LoadLocation(cfg.DBTimezone)
+	if err != nil {
+		log.Fatal("Failed to load timezone:", err)
+	}
+
 	commandRepo := repository.NewShortUrlCommandRepository(db)
 	queryRepo := repository.NewShortUrlQueryRepository(db)
 	redisRepo := repository.NewRedisRepository(redisClient)
+	clickCounterRepo := repository.NewClickCounterRepository(redisClient, location)
+	clickDailyCommandRepo := repository.NewShortClickDailyCommandRepository(db)
+	clickDailyQueryRepo := repository.NewShortClickDailyQueryRepository(db)
+	clickEventCommandRepo := repository.NewClickEventCommandRepository(db)
+	clickEventQueryRepo := repository.NewClickEventQueryRepository(db)
+	urlSafetyCommandRepo := repository.NewUrlSafetyCommandRepository(db)
+	urlSafetyQueryRepo := repository.NewUrlSafetyQueryRepository(db)
+	utmTemplateCommandRepo := repository.NewUtmTemplateCommandRepository(db)
+	utmTemplateQueryRepo := repository.NewUtmTemplateQueryRepository(db)
+	customDomainCommandRepo := repository.NewCustomDomainCommandRepository(db)
+	customDomainQueryRepo := repository.NewCustomDomainQueryRepository(db)
+	institutionSettingCommandRepo := repository.NewInstitutionSettingCommandRepository(db)
+	institutionSettingQueryRepo := repository.NewInstitutionSettingQueryRepository(db)
+
+	urlSafetyCheckers, err := service.NewDefaultUrlSafetyCheckers(cfg.UrlBlocklistFile, cfg.UrlAllowedSchemes)
+	if err != nil {
+		log.Fatal("Failed to load url safety checkers:", err)
+	}
+
+	countryResolver, err := service.NewCountryResolver(cfg.GeoIPCountryFile)
+	if err != nil {
+		log.Fatal("Failed to load ip country file:", err)
+	}
 
-	shortUrlService := service.NewShortUrlService(commandRepo, queryRepo, redisRepo)
+	shortCodeSequenceRepo := repository.NewShortCodeSequenceRepository(db, database.ShortCodeSequence)
+	shortCodeGenerator, err := service.NewShortCodeGenerator(cfg.ShortCodeStrategy, cfg.ShortCodeAlphabet, cfg.ShortCodeLength, cfg.ShortCodeSalt, shortCodeSequenceRepo)
+	if err != nil {
+		log.Fatal("Failed to configure short code generator:", err)
+	}
+
+	urlSafetyService := service.NewUrlSafetyService(urlSafetyCheckers, urlSafetyCommandRepo, urlSafetyQueryRepo, redisRepo, cfg.UrlSafetyRecheckInterval)
+	shortUrlService := service.NewShortUrlService(commandRepo, queryRepo, redisRepo, clickCounterRepo, urlSafetyService, shortCodeGenerator, utmTemplateQueryRepo, countryResolver, customDomainQueryRepo, institutionSettingQueryRepo, dto.LinkCacheConfig{
+		LocalSize: cfg.LinkCacheLocalSize,
+		LocalTTL:  cfg.LinkCacheLocalTTL,
+	})
+	analyticsService := service.NewAnalyticsService(queryRepo, clickDailyQueryRepo, clickEventQueryRepo, location)
+	qrCodeService := service.NewQrCodeService(queryRepo, redisRepo, cfg.PublicBaseUrl)
+	utmTemplateService := service.NewUtmTemplateService(utmTemplateCommandRepo, utmTemplateQueryRepo)
+	customDomainService := service.NewCustomDomainService(customDomainCommandRepo, customDomainQueryRepo, service.NewDomainProofResolver(), redisRepo)
+	institutionSettingService := service.NewInstitutionSettingService(institutionSettingCommandRepo, institutionSettingQueryRepo)
+	shortUrlAccessService := service.NewShortUrlAccessService(redisRepo, cfg.JWTSecret, cfg.LinkAccessTTL)
+	clickFlusherService := service.NewClickFlusherService(clickCounterRepo, clickDailyCommandRepo, cfg.ClickFlushInterval)
+	clickEventRecorderService := service.NewClickEventRecorderService(clickEventCommandRepo)
+
+	flushCtx, stopFlusher := context.WithCancel(ctx)
+	defer stopFlusher()
+	go clickFlusherService.Run(flushCtx)
+	go clickEventRecorderService.Run(flushCtx)
+	go urlSafetyService.Run(flushCtx)
+	go shortUrlService.SyncCache(flushCtx)
+	go shortUrlService.CountClicks(flushCtx)
+	expvar.Publish("link_cache", expvar.Func(func() any { return shortUrlService.CacheStats() }))
+	// The counters are served on their own listener, never on the public host.
+	if cfg.MetricsAddr != "" {
+		go func() {
+			if err := http.ListenAndServe(cfg.MetricsAddr, expvar.Handler()); err != nil {
+				log.Printf("Metrics listener stopped: %v", err)
+			}
+		}()
+	}
 
-	shortUrlController := controller.NewShortUrlController(shortUrlService)
+	shortUrlController := controller.NewShortUrlController(shortUrlService, clickEventRecorderService, shortUrlAccessService, dto.UnavailableLinkConfig{
+		Status:      cfg.LinkUnavailableStatus,
+		RedirectUrl: cfg.LinkUnavailableUrl,
+	})
+	analyticsController := controller.NewAnalyticsController(analyticsService)
+	qrCodeController := controller.NewQrCodeController(qrCodeService)
+	utmTemplateController := controller.NewUtmTemplateController(utmTemplateService)
+	customDomainController := controller.NewCustomDomainController(customDomainService)
+	institutionSettingController := controller.NewInstitutionSettingController(institutionSettingService)
 
 	sessionQueryRepo := userrepo.NewUserSessionQueryRepository(db)
-	app := router.NewRouter(shortUrlController, sessionQueryRepo)
+	app := router.NewRouter(shortUrlController, analyticsController, qrCodeController, utmTemplateController, customDomainController, institutionSettingController, sessionQueryRepo)
 
 	log.Println("Starting server on :8080...")
 	if err := app.Listen(":8080"); err != nil {
@@ -8,7 +8,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewRouter(shortUrlController *controller.ShortUrlController, sessionQueryRepo repositories.UserSessionQueryRepositoryInterface) *fiber.App {
+func NewRouter(shortUrlController *controller.ShortUrlController, analyticsController *controller.AnalyticsController, qrCodeController *controller.QrCodeController, utmTemplateController *controller.UtmTemplateController, customDomainController *controller.CustomDomainController, institutionSettingController *controller.InstitutionSettingController, sessionQueryRepo repositories.UserSessionQueryRepositoryInterface) *fiber.App {
 	app := fiber.New()
 
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -20,6 +20,11 @@ func NewRouter(shortUrlController *controller.ShortUrlController, sessionQueryRe
 	v1 := app.Group("/api/v1")
 	protected := v1.Group("/", middleware.JWTAuth(sessionQueryRepo))
 	shortUrlController.RegisterRoutes(protected)
+	analyticsController.RegisterRoutes(protected)
+	qrCodeController.RegisterRoutes(protected)
+	utmTemplateController.RegisterRoutes(protected)
+	customDomainController.RegisterRoutes(protected)
+	institutionSettingController.RegisterRoutes(protected)
 
 	return app
 }